@@ -0,0 +1,347 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultAPIAddress = "127.0.0.1:7070"
+
+// apiBackend is the part of the app that the API server exposes. WtfApp implements it
+type apiBackend interface {
+	apiWidgets() []wtf.Wtfable
+	focusWidget(name string) error
+	refreshWidget(widget wtf.Wtfable)
+	refreshedAt(name string) time.Time
+	widgetContent(widget wtf.Wtfable) string
+}
+
+// apiSettings defines where the API server listens. The server is opt-in:
+//
+//    wtf:
+//      api:
+//        enabled: true
+//        address: "127.0.0.1:7070"
+//        # or, instead of address:
+//        socket: "~/.config/wtf/wtf.sock"
+//
+type apiSettings struct {
+	address string
+	enabled bool
+	socket  string
+}
+
+// apiWidget is the JSON representation of a widget
+type apiWidget struct {
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`
+	Focusable   bool       `json:"focusable"`
+	LastRefresh *time.Time `json:"lastRefresh,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+	Content     *string    `json:"content,omitempty"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// apiServer serves the state of the app's widgets as JSON over HTTP:
+//
+//    GET  /widgets               lists every widget
+//    GET  /widgets/:name         returns a single widget, including its rendered content
+//    POST /widgets/:name/refresh refreshes the widget
+//    POST /widgets/:name/focus   moves the onscreen focus to the widget
+//
+// Requests must be addressed to the loopback interface, so that a web page can't reach
+// the API by rebinding its own domain name to 127.0.0.1
+type apiServer struct {
+	address string
+	backend apiBackend
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ServeHTTP routes the API requests
+func (server *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !server.allowedHost(r.Host) {
+		server.writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	if parts[0] != "widgets" {
+		server.writeError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+
+	switch len(parts) {
+	case 1:
+		server.handleList(w, r)
+	case 2:
+		server.handleWidget(w, r, parts[1])
+	case 3:
+		server.handleAction(w, r, parts[1], parts[2])
+	default:
+		server.writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func loadAPISettings(config *config.Config) apiSettings {
+	return apiSettings{
+		address: config.UString("wtf.api.address", defaultAPIAddress),
+		enabled: config.UBool("wtf.api.enabled", false),
+		socket:  config.UString("wtf.api.socket", ""),
+	}
+}
+
+// listen opens the listener the API server is served on. TCP addresses must be on the
+// loopback interface so that the API is never exposed to the network
+func (settings apiSettings) listen() (net.Listener, error) {
+	if settings.socket != "" {
+		socketPath, err := utils.ExpandHomeDir(settings.socket)
+		if err != nil {
+			return nil, err
+		}
+
+		// Remove a socket left behind by a previous run, but never a file that isn't one
+		if info, err := os.Lstat(socketPath); err == nil {
+			if info.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("wtf.api.socket %s already exists and is not a socket", socketPath)
+			}
+
+			if err := os.Remove(socketPath); err != nil {
+				return nil, err
+			}
+		}
+
+		return net.Listen("unix", socketPath)
+	}
+
+	host, _, err := net.SplitHostPort(settings.address)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("wtf.api.address must be a loopback address, not %q", host)
+	}
+
+	return net.Listen("tcp", settings.address)
+}
+
+// allowedHost returns true if the request's Host header names localhost, a loopback IP,
+// or the address the server is configured to listen on
+func (server *apiServer) allowedHost(host string) bool {
+	if server.address != "" && host == server.address {
+		return true
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (server *apiServer) findWidget(name string) wtf.Wtfable {
+	return findWidget(server.backend.apiWidgets(), name)
+}
+
+func (server *apiServer) handleAction(w http.ResponseWriter, r *http.Request, name, action string) {
+	if r.Method != http.MethodPost {
+		server.writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s requires POST", action))
+		return
+	}
+
+	widget := server.findWidget(name)
+	if widget == nil {
+		server.writeError(w, http.StatusNotFound, fmt.Errorf("widget %q not found", name))
+		return
+	}
+
+	switch action {
+	case "refresh":
+		go server.backend.refreshWidget(widget)
+		w.WriteHeader(http.StatusAccepted)
+	case "focus":
+		if err := server.backend.focusWidget(name); err != nil {
+			server.writeError(w, http.StatusConflict, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		server.writeError(w, http.StatusNotFound, fmt.Errorf("unknown action %q", action))
+	}
+}
+
+func (server *apiServer) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		server.writeError(w, http.StatusMethodNotAllowed, errors.New("widgets requires GET"))
+		return
+	}
+
+	widgets := []apiWidget{}
+	for _, widget := range server.backend.apiWidgets() {
+		widgets = append(widgets, server.toAPIWidget(widget))
+	}
+
+	server.writeJSON(w, http.StatusOK, widgets)
+}
+
+func (server *apiServer) handleWidget(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodGet {
+		server.writeError(w, http.StatusMethodNotAllowed, errors.New("widget requires GET"))
+		return
+	}
+
+	widget := server.findWidget(name)
+	if widget == nil {
+		server.writeError(w, http.StatusNotFound, fmt.Errorf("widget %q not found", name))
+		return
+	}
+
+	output := server.toAPIWidget(widget)
+
+	content := server.backend.widgetContent(widget)
+	if r.URL.Query().Get("format") == RenderFormatANSI {
+		content = utils.ColorTagsToANSI(content)
+	} else {
+		content = utils.StripColorTags(content)
+	}
+	output.Content = &content
+
+	server.writeJSON(w, http.StatusOK, output)
+}
+
+func (server *apiServer) toAPIWidget(widget wtf.Wtfable) apiWidget {
+	output := apiWidget{
+		Name:      widget.Name(),
+		Type:      widget.CommonSettings().Module.Type,
+		Title:     widget.CommonSettings().Title,
+		Focusable: widget.Focusable(),
+	}
+
+	if refreshed := server.backend.refreshedAt(widget.Name()); !refreshed.IsZero() {
+		output.LastRefresh = &refreshed
+	}
+
+	if err := widget.RefreshError(); err != nil {
+		output.LastError = err.Error()
+	}
+
+	return output
+}
+
+func (server *apiServer) writeError(w http.ResponseWriter, status int, err error) {
+	server.writeJSON(w, status, apiError{Error: err.Error()})
+}
+
+func (server *apiServer) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+/* -------------------- WtfApp API Backend -------------------- */
+
+func (wtfApp *WtfApp) apiWidgets() []wtf.Wtfable {
+	wtfApp.widgetsMutex.Lock()
+	defer wtfApp.widgetsMutex.Unlock()
+
+	return append([]wtf.Wtfable{}, wtfApp.widgets...)
+}
+
+// focusWidget moves the onscreen focus to the named widget, switching to the page it
+// is on if necessary
+func (wtfApp *WtfApp) focusWidget(name string) error {
+	result := make(chan error)
+
+	wtfApp.app.QueueUpdateDraw(func() {
+		for idx, page := range wtfApp.dashboardPages {
+			widget := findWidget(page.widgets, name)
+			if widget == nil {
+				continue
+			}
+
+			if !widget.Focusable() {
+				result <- fmt.Errorf("widget %q is not focusable", name)
+				return
+			}
+
+			wtfApp.showPage(idx)
+			wtfApp.focusTracker().focusWidget(widget)
+
+			result <- nil
+			return
+		}
+
+		result <- fmt.Errorf("widget %q is not on any page", name)
+	})
+
+	return <-result
+}
+
+func (wtfApp *WtfApp) recordRefresh(widget wtf.Wtfable) {
+	wtfApp.refreshMutex.Lock()
+	defer wtfApp.refreshMutex.Unlock()
+
+	wtfApp.refreshTimes[widget.Name()] = time.Now()
+}
+
+func (wtfApp *WtfApp) refreshedAt(name string) time.Time {
+	wtfApp.refreshMutex.Lock()
+	defer wtfApp.refreshMutex.Unlock()
+
+	return wtfApp.refreshTimes[name]
+}
+
+func (wtfApp *WtfApp) refreshWidget(widget wtf.Wtfable) {
+	widget.Refresh()
+	wtfApp.recordRefresh(widget)
+}
+
+// serveAPI runs the API server until the app exits. If the server cannot be
+// started the error is displayed onscreen
+func (wtfApp *WtfApp) serveAPI(settings apiSettings) {
+	listener, err := settings.listen()
+	if err != nil {
+		wtfApp.showError("Could not start the API server", err.Error())
+		return
+	}
+
+	_ = http.Serve(listener, &apiServer{address: settings.address, backend: wtfApp})
+}
+
+// widgetContent returns the widget's current text, tags included. The text is read
+// on the UI goroutine so that it isn't read while the widget is being redrawn
+func (wtfApp *WtfApp) widgetContent(widget wtf.Wtfable) string {
+	content := make(chan string)
+
+	wtfApp.app.QueueUpdate(func() {
+		content <- widget.TextView().GetText(false)
+	})
+
+	return <-content
+}
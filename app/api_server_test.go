@@ -0,0 +1,238 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type fakeAPIBackend struct {
+	focused   string
+	refreshed chan string
+	widgets   []wtf.Wtfable
+}
+
+func (backend *fakeAPIBackend) apiWidgets() []wtf.Wtfable {
+	return backend.widgets
+}
+
+func (backend *fakeAPIBackend) focusWidget(name string) error {
+	if name != "clocks" {
+		return errors.New("not focusable")
+	}
+
+	backend.focused = name
+	return nil
+}
+
+func (backend *fakeAPIBackend) refreshWidget(widget wtf.Wtfable) {
+	backend.refreshed <- widget.Name()
+}
+
+func (backend *fakeAPIBackend) refreshedAt(name string) time.Time {
+	if name == "clocks" {
+		return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	return time.Time{}
+}
+
+func (backend *fakeAPIBackend) widgetContent(widget wtf.Wtfable) string {
+	return "[red]UTC 12:00"
+}
+
+func newTestAPIServer(t *testing.T) (*httptest.Server, *fakeAPIBackend) {
+	cfg, _ := config.ParseYaml(renderConfig)
+
+	backend := &fakeAPIBackend{
+		refreshed: make(chan string, 1),
+		widgets:   []wtf.Wtfable{MakeWidget(nil, nil, "clocks", cfg)},
+	}
+
+	server := httptest.NewServer(&apiServer{backend: backend})
+	t.Cleanup(server.Close)
+
+	return server, backend
+}
+
+func Test_apiServer_widgets(t *testing.T) {
+	server, _ := newTestAPIServer(t)
+
+	resp, err := http.Get(server.URL + "/widgets")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	widgets := []apiWidget{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&widgets))
+
+	assert.Len(t, widgets, 1)
+	assert.Equal(t, "clocks", widgets[0].Name)
+	assert.Equal(t, "clocks", widgets[0].Type)
+	assert.Equal(t, "2020-01-02T03:04:05Z", widgets[0].LastRefresh.Format(time.RFC3339))
+	assert.Nil(t, widgets[0].Content)
+}
+
+func Test_apiServer_widget(t *testing.T) {
+	server, _ := newTestAPIServer(t)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "plain", query: "", expected: "UTC 12:00"},
+		{name: "ansi", query: "?format=ansi", expected: "\x1b[38;2;255;0;0mUTC 12:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + "/widgets/clocks" + tt.query)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			widget := apiWidget{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&widget))
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.True(t, strings.HasPrefix(*widget.Content, tt.expected))
+		})
+	}
+}
+
+func Test_apiServer_actions(t *testing.T) {
+	server, backend := newTestAPIServer(t)
+
+	resp, err := http.Post(server.URL+"/widgets/clocks/refresh", "", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "clocks", <-backend.refreshed)
+
+	resp, err = http.Post(server.URL+"/widgets/clocks/focus", "", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "clocks", backend.focused)
+}
+
+func Test_apiServer_errors(t *testing.T) {
+	server, _ := newTestAPIServer(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected int
+	}{
+		{name: "unknown path", method: http.MethodGet, path: "/cats", expected: http.StatusNotFound},
+		{name: "unknown widget", method: http.MethodGet, path: "/widgets/todo", expected: http.StatusNotFound},
+		{name: "unknown action", method: http.MethodPost, path: "/widgets/clocks/cats", expected: http.StatusNotFound},
+		{name: "action with GET", method: http.MethodGet, path: "/widgets/clocks/refresh", expected: http.StatusMethodNotAllowed},
+		{name: "list with POST", method: http.MethodPost, path: "/widgets", expected: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, server.URL+tt.path, nil)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			apiErr := apiError{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&apiErr))
+
+			assert.Equal(t, tt.expected, resp.StatusCode)
+			assert.NotEmpty(t, apiErr.Error)
+		})
+	}
+}
+
+func Test_apiServer_host(t *testing.T) {
+	server, _ := newTestAPIServer(t)
+
+	tests := []struct {
+		name     string
+		host     string
+		expected int
+	}{
+		{name: "loopback IP", host: "127.0.0.1:7070", expected: http.StatusOK},
+		{name: "loopback IPv6", host: "[::1]:7070", expected: http.StatusOK},
+		{name: "localhost", host: "localhost:7070", expected: http.StatusOK},
+		{name: "localhost without port", host: "localhost", expected: http.StatusOK},
+		{name: "foreign host", host: "attacker.example.com:7070", expected: http.StatusForbidden},
+		{name: "foreign host without port", host: "attacker.example.com", expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/widgets", nil)
+			req.Host = tt.host
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
+
+func Test_apiSettings_listen(t *testing.T) {
+	_, err := apiSettings{address: "0.0.0.0:0"}.listen()
+	assert.EqualError(t, err, `wtf.api.address must be a loopback address, not "0.0.0.0"`)
+
+	listener, err := apiSettings{address: "127.0.0.1:0"}.listen()
+	assert.NoError(t, err)
+	listener.Close()
+}
+
+func Test_apiSettings_listen_socket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	// A socket left behind by a previous run is replaced
+	socketPath := filepath.Join(dir, "wtf.sock")
+
+	stale, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	listener, err := apiSettings{socket: socketPath}.listen()
+	assert.NoError(t, err)
+	listener.Close()
+
+	// Any other file is left alone
+	filePath := filepath.Join(dir, "notes.txt")
+	if err := ioutil.WriteFile(filePath, []byte("notes"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = apiSettings{socket: filePath}.listen()
+	assert.EqualError(t, err, "wtf.api.socket "+filePath+" already exists and is not a socket")
+
+	data, _ := ioutil.ReadFile(filePath)
+	assert.Equal(t, "notes", string(data))
+}
@@ -0,0 +1,216 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	// palettePage is the name of the page the command palette is displayed on
+	palettePage = "palette"
+
+	paletteWidth  = 80
+	paletteHeight = 20
+)
+
+// keyboardActioner is a widget that responds to keys set with SetKeyboardChar and SetKeyboardKey
+type keyboardActioner interface {
+	KeyboardActions() []view.KeyboardAction
+}
+
+// paletteCommand is an entry in the command palette
+type paletteCommand struct {
+	label  string
+	detail string
+	run    func()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// filterCommands returns the commands whose labels fuzzy-match the query, best match first.
+// Commands that match equally well keep their order
+func filterCommands(commands []paletteCommand, query string) []paletteCommand {
+	type scoredCommand struct {
+		command paletteCommand
+		score   int
+	}
+
+	scored := []scoredCommand{}
+	for _, command := range commands {
+		if match, score := utils.FuzzyMatch(query, command.label); match {
+			scored = append(scored, scoredCommand{command, score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	filtered := []paletteCommand{}
+	for _, item := range scored {
+		filtered = append(filtered, item.command)
+	}
+
+	return filtered
+}
+
+// widgetCommands returns the commands for a widget: one that focuses it, if it can be
+// focused, and one for each of its keyboard actions
+func widgetCommands(widget wtf.Wtfable, show func(widget wtf.Wtfable) bool) []paletteCommand {
+	title := strings.TrimSpace(utils.StripColorTags(widget.CommonSettings().Title))
+	if title == "" {
+		title = widget.Name()
+	}
+
+	commands := []paletteCommand{}
+
+	if widget.Focusable() {
+		commands = append(commands, paletteCommand{
+			label:  title,
+			detail: "focus",
+			run:    func() { show(widget) },
+		})
+	}
+
+	actioner, ok := widget.(keyboardActioner)
+	if !ok {
+		return commands
+	}
+
+	for _, action := range actioner.KeyboardActions() {
+		action := action
+
+		commands = append(commands, paletteCommand{
+			label:  fmt.Sprintf("%s: %s", title, action.Help),
+			detail: action.Key,
+			run: func() {
+				// Actions act on the widget as if it had focus when the key was pressed
+				if widget.Focusable() {
+					show(widget)
+				}
+
+				action.Action()
+			},
+		})
+	}
+
+	return commands
+}
+
+// paletteCommands returns the commands for every enabled widget on a page
+func (wtfApp *WtfApp) paletteCommands() []paletteCommand {
+	wtfApp.widgetsMutex.Lock()
+	widgets := append([]wtf.Wtfable{}, wtfApp.widgets...)
+	wtfApp.widgetsMutex.Unlock()
+
+	commands := []paletteCommand{}
+
+	for _, widget := range widgets {
+		if widget.Disabled() || !wtfApp.onPage(widget) {
+			continue
+		}
+
+		commands = append(commands, widgetCommands(widget, wtfApp.showWidget)...)
+	}
+
+	return commands
+}
+
+// paletteOpen returns TRUE if the command palette is onscreen
+func (wtfApp *WtfApp) paletteOpen() bool {
+	return wtfApp.pages.HasPage(palettePage)
+}
+
+// showCommandPalette displays a searchable list of the widgets that can be focused and
+// the keyboard actions of every widget. Choosing a widget focuses it, and choosing an
+// action runs it
+func (wtfApp *WtfApp) showCommandPalette() {
+	if wtfApp.paletteOpen() {
+		return
+	}
+
+	commands := wtfApp.paletteCommands()
+	matches := commands
+
+	input := tview.NewInputField()
+	input.SetLabel("> ")
+
+	list := tview.NewList()
+	list.ShowSecondaryText(false)
+	list.SetHighlightFullLine(true)
+
+	fill := func(query string) {
+		matches = filterCommands(commands, query)
+
+		list.Clear()
+		for _, command := range matches {
+			list.AddItem(fmt.Sprintf("%s [gray](%s)[white]", tview.Escape(command.label), tview.Escape(command.detail)), "", 0, nil)
+		}
+	}
+
+	closeFunc := func() {
+		wtfApp.pages.RemovePage(palettePage)
+		wtfApp.focusTracker().Refocus()
+	}
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeFunc()
+			return nil
+		case tcell.KeyEnter:
+			idx := list.GetCurrentItem()
+			closeFunc()
+
+			if idx >= 0 && idx < len(matches) {
+				matches[idx].run()
+			}
+
+			return nil
+		case tcell.KeyDown, tcell.KeyTab:
+			if list.GetItemCount() > 0 {
+				list.SetCurrentItem((list.GetCurrentItem() + 1) % list.GetItemCount())
+			}
+			return nil
+		case tcell.KeyUp, tcell.KeyBacktab:
+			if list.GetItemCount() > 0 {
+				list.SetCurrentItem((list.GetCurrentItem() - 1 + list.GetItemCount()) % list.GetItemCount())
+			}
+			return nil
+		default:
+			return event
+		}
+	})
+	input.SetChangedFunc(fill)
+
+	fill("")
+
+	palette := tview.NewFlex().SetDirection(tview.FlexRow)
+	palette.AddItem(input, 1, 0, true)
+	palette.AddItem(list, 0, 1, false)
+	palette.SetBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	palette.SetBorder(true)
+	palette.SetTitle(" Commands ")
+
+	// Centers the palette on the screen. The empty items are transparent, so the widgets
+	// stay visible around it
+	column := tview.NewFlex().SetDirection(tview.FlexRow)
+	column.AddItem(nil, 0, 1, false)
+	column.AddItem(palette, paletteHeight, 0, true)
+	column.AddItem(nil, 0, 1, false)
+
+	centered := tview.NewFlex()
+	centered.AddItem(nil, 0, 1, false)
+	centered.AddItem(column, paletteWidth, 0, true)
+	centered.AddItem(nil, 0, 1, false)
+
+	wtfApp.pages.AddPage(palettePage, centered, true, true)
+	wtfApp.app.SetFocus(input)
+}
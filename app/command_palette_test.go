@@ -0,0 +1,69 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const paletteConfig = `
+wtf:
+  mods:
+    clocks:
+      enabled: true
+      title: "[green]World Clocks"
+    feedreader:
+      enabled: true
+      feeds: []`
+
+func Test_widgetCommands(t *testing.T) {
+	cfg, err := config.ParseYaml(paletteConfig)
+	assert.NoError(t, err)
+
+	shown := []string{}
+	show := func(widget wtf.Wtfable) bool {
+		shown = append(shown, widget.Name())
+		return true
+	}
+
+	clocks := MakeWidget(tview.NewApplication(), tview.NewPages(), "clocks", cfg)
+	assert.Empty(t, widgetCommands(clocks, show))
+
+	feedreader := MakeWidget(tview.NewApplication(), tview.NewPages(), "feedreader", cfg)
+	commands := widgetCommands(feedreader, show)
+
+	assert.Equal(t, "Feed Reader", commands[0].label)
+	assert.Equal(t, "focus", commands[0].detail)
+	assert.Equal(t, "Feed Reader: Show/hide this help prompt", commands[1].label)
+	assert.Equal(t, "/", commands[1].detail)
+	assert.Equal(t, "Feed Reader: Select next item", commands[3].label)
+	assert.Equal(t, "j", commands[3].detail)
+
+	commands[0].run()
+	assert.Equal(t, []string{"feedreader"}, shown)
+}
+
+func Test_filterCommands(t *testing.T) {
+	commands := []paletteCommand{
+		{label: "Feed Reader"},
+		{label: "Feed Reader: Open story in browser"},
+		{label: "GitHub: Open pull request"},
+		{label: "GitHub"},
+	}
+
+	labels := func(commands []paletteCommand) []string {
+		result := []string{}
+		for _, command := range commands {
+			result = append(result, command.label)
+		}
+		return result
+	}
+
+	assert.Equal(t, labels(commands), labels(filterCommands(commands, "")))
+	assert.Equal(t, []string{"GitHub: Open pull request", "GitHub"}, labels(filterCommands(commands, "git")))
+	assert.Equal(t, []string{"Feed Reader: Open story in browser", "GitHub: Open pull request"}, labels(filterCommands(commands, "open")))
+	assert.Empty(t, filterCommands(commands, "zzz"))
+}
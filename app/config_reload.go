@@ -0,0 +1,253 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// configChanges describes how a newly-loaded configuration differs from the one
+// the app is currently running with
+type configChanges struct {
+	// global is TRUE if settings outside of wtf.mods, wtf.grid, and wtf.pages changed.
+	// Those can affect every module, so all of them have to be rebuilt
+	global bool
+
+	// modules contains the names of the modules that were added, removed, or changed
+	modules []string
+
+	// regrid is TRUE if the grid or page definitions or any module's position changed
+	regrid bool
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// diffConfigs compares two configurations and reports which parts of the app have to be
+// rebuilt to move from the old one to the new one
+func diffConfigs(oldConfig, newConfig *config.Config) configChanges {
+	changes := configChanges{}
+
+	changes.global = !reflect.DeepEqual(
+		globalSettings(oldConfig),
+		globalSettings(newConfig),
+	)
+
+	changes.regrid = !reflect.DeepEqual(subtree(oldConfig, "wtf.grid"), subtree(newConfig, "wtf.grid")) ||
+		!reflect.DeepEqual(subtree(oldConfig, "wtf.pages"), subtree(newConfig, "wtf.pages"))
+
+	for _, name := range moduleNames(oldConfig, newConfig) {
+		if changes.global || !reflect.DeepEqual(subtree(oldConfig, "wtf.mods."+name), subtree(newConfig, "wtf.mods."+name)) {
+			changes.modules = append(changes.modules, name)
+		}
+
+		if !reflect.DeepEqual(subtree(oldConfig, "wtf.mods."+name+".position"), subtree(newConfig, "wtf.mods."+name+".position")) {
+			changes.regrid = true
+		}
+	}
+
+	if changes.global {
+		changes.regrid = true
+	}
+
+	return changes
+}
+
+// globalSettings returns the top-level wtf settings, less the modules and the layout
+func globalSettings(cfg *config.Config) map[string]interface{} {
+	settings := map[string]interface{}{}
+
+	wtfSettings, err := cfg.Map("wtf")
+	if err != nil {
+		return settings
+	}
+
+	for key, val := range wtfSettings {
+		if key == "mods" || key == "grid" || key == "pages" {
+			continue
+		}
+
+		settings[key] = val
+	}
+
+	return settings
+}
+
+// moduleNames returns the sorted, de-duplicated names of all the modules defined in
+// any of the given configs
+func moduleNames(configs ...*config.Config) []string {
+	found := map[string]bool{}
+
+	for _, cfg := range configs {
+		mods, _ := cfg.Map("wtf.mods")
+		for name := range mods {
+			found[name] = true
+		}
+	}
+
+	names := []string{}
+	for name := range found {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func subtree(cfg *config.Config, path string) interface{} {
+	sub, err := cfg.Get(path)
+	if err != nil {
+		return nil
+	}
+
+	return sub.Root
+}
+
+// reloadConfig applies a newly-loaded configuration to the running app. Only the modules
+// whose settings changed are stopped and rebuilt, so every other widget keeps its data,
+// scroll position, and selection. The reload happens on the UI goroutine, as that is
+// where the app's config, widgets, and pages are read
+func (wtfApp *WtfApp) reloadConfig(newConfig *config.Config) {
+	wtfApp.app.QueueUpdateDraw(func() {
+		wtfApp.applyConfig(newConfig)
+	})
+}
+
+// applyConfig rebuilds the modules that changed between the current config and the new
+// one, and swaps them in. It must only be called on the UI goroutine
+func (wtfApp *WtfApp) applyConfig(newConfig *config.Config) {
+	changes := diffConfigs(wtfApp.config, newConfig)
+
+	newWidgets := map[string]wtf.Wtfable{}
+	for _, name := range changes.modules {
+		if widget := MakeWidget(wtfApp.app, wtfApp.pages, name, newConfig); widget != nil {
+			newWidgets[name] = widget
+		}
+	}
+
+	// If the new modules have invalid settings, leave the current layout untouched
+	widgets := []wtf.Wtfable{}
+	for _, widget := range newWidgets {
+		widgets = append(widgets, widget)
+	}
+
+	if validationErrors := validate(widgets); len(validationErrors) > 0 {
+		for _, widget := range widgets {
+			widget.Stop()
+		}
+
+		messages := []string{}
+		for _, err := range validationErrors {
+			messages = append(messages, err.plainErrorMessages()...)
+		}
+
+		wtfApp.showConfigError(strings.Join(messages, "\n"))
+
+		return
+	}
+
+	openURLUtil := utils.ToStrs(newConfig.UList("wtf.openUrlUtil", []interface{}{}))
+	utils.Init(newConfig.UString("wtf.openFileUtil", "open"), openURLUtil)
+
+	wtfApp.pages.RemovePage(errorPage)
+
+	wasFocused := wtfApp.focusTracker().focusState() == widgetFocused
+
+	// Swap the changed widgets in place so that the rest keep their order. Widgets
+	// that were removed or disabled are stopped and dropped
+	updated := []wtf.Wtfable{}
+	for _, widget := range wtfApp.widgets {
+		if !containsString(changes.modules, widget.Name()) {
+			updated = append(updated, widget)
+			continue
+		}
+
+		widget.Stop()
+		if !changes.regrid {
+			for _, page := range wtfApp.dashboardPages {
+				page.display.remove(widget)
+			}
+		}
+
+		if replacement, ok := newWidgets[widget.Name()]; ok {
+			updated = append(updated, replacement)
+			delete(newWidgets, widget.Name())
+		}
+	}
+
+	// Whatever is left over are modules that did not exist before
+	for _, name := range changes.modules {
+		if widget, ok := newWidgets[name]; ok {
+			updated = append(updated, widget)
+		}
+	}
+
+	wtfApp.config = newConfig
+
+	wtfApp.widgetsMutex.Lock()
+	wtfApp.widgets = updated
+	wtfApp.widgetsMutex.Unlock()
+
+	if changes.regrid {
+		wtfApp.buildPages()
+	} else {
+		assigned := assignWidgets(loadPageSettings(newConfig), wtfApp.widgets)
+
+		for idx, page := range wtfApp.dashboardPages {
+			wtfApp.pageMutex.Lock()
+			page.widgets = assigned[idx]
+			wtfApp.pageMutex.Unlock()
+
+			for _, name := range changes.modules {
+				if widget := findWidget(page.widgets, name); widget != nil {
+					page.display.add(widget)
+				}
+			}
+
+			page.focusTracker.update(page.widgets, newConfig)
+		}
+	}
+
+	if wasFocused {
+		wtfApp.focusTracker().Refocus()
+	}
+
+	for _, name := range changes.modules {
+		if widget := findWidget(wtfApp.widgets, name); widget != nil {
+			go wtfApp.schedule(widget)
+		}
+	}
+}
+
+// showConfigError displays the error in a modal on top of the current layout
+func (wtfApp *WtfApp) showConfigError(message string) {
+	wtfApp.showError(
+		fmt.Sprintf("Could not reload %s", wtfApp.configFilePath),
+		fmt.Sprintf("The previous configuration is still in use.\n\n%s", message),
+	)
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findWidget(widgets []wtf.Wtfable, name string) wtf.Wtfable {
+	for _, widget := range widgets {
+		if widget.Name() == name {
+			return widget
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const baseReloadConfig = `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 30
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 3600`
+
+func Test_diffConfigs(t *testing.T) {
+	tests := []struct {
+		name      string
+		newConfig string
+		expected  configChanges
+	}{
+		{
+			name:      "no changes",
+			newConfig: baseReloadConfig,
+			expected:  configChanges{},
+		},
+		{
+			name: "module setting changed",
+			newConfig: `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 15
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 3600`,
+			expected: configChanges{modules: []string{"clocks"}},
+		},
+		{
+			name: "module position changed",
+			newConfig: `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 1
+        height: 1
+        width: 1
+      refreshInterval: 30
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 3600`,
+			expected: configChanges{modules: []string{"clocks"}, regrid: true},
+		},
+		{
+			name: "grid changed",
+			newConfig: `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [30, 50]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 30
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 3600`,
+			expected: configChanges{regrid: true},
+		},
+		{
+			name: "pages added",
+			newConfig: baseReloadConfig + `
+  pages:
+    - name: main
+      grid:
+        columns: [80]
+        rows: [20]
+      modules: ["clocks"]`,
+			expected: configChanges{regrid: true},
+		},
+		{
+			name: "module removed",
+			newConfig: `
+wtf:
+  colors:
+    border:
+      focused: orange
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 30`,
+			expected: configChanges{modules: []string{"todo"}, regrid: true},
+		},
+		{
+			name: "global setting changed",
+			newConfig: `
+wtf:
+  colors:
+    border:
+      focused: red
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 30
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 3600`,
+			expected: configChanges{global: true, modules: []string{"clocks", "todo"}, regrid: true},
+		},
+	}
+
+	oldConfig, _ := config.ParseYaml(baseReloadConfig)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newConfig, err := config.ParseYaml(tt.newConfig)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, diffConfigs(oldConfig, newConfig))
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSuggestionDistance is how many edits an unknown key can be from a known key for the
+// known key to be suggested in its place
+const maxSuggestionDistance = 2
+
+// ConfigError is a problem found in a config file, and where in the file it was found
+type ConfigError struct {
+	File    string
+	Line    int
+	Column  int
+	Module  string
+	Message string
+}
+
+// configSource is the contents of a single config file
+type configSource struct {
+	file string
+	data []byte
+}
+
+// moduleNode is a module's block in a config file, and the settings read from it. settings
+// is the type of the module's own settings, or nil if the module's type is unknown
+type moduleNode struct {
+	file     string
+	order    int
+	name     string
+	key      *yaml.Node
+	value    *yaml.Node
+	common   *cfg.Common
+	settings reflect.Type
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Error returns the error as "file:line:column: module: message"
+func (err ConfigError) Error() string {
+	location := fmt.Sprintf("%d:%d", err.Line, err.Column)
+	if err.File != "" {
+		location = err.File + ":" + location
+	}
+
+	return fmt.Sprintf("%s: %s: %s", location, err.Module, err.Message)
+}
+
+// ValidateConfig checks the settings of every enabled module in the config against the
+// Settings struct of the module. It reports keys the module does not read, values that cannot
+// be read as the type the module expects, invalid positions, positions that overlap other
+// modules on the same page, and focus characters outside of 1-9. Disabled modules are not
+// checked, because they are never built
+func ValidateConfig(source []byte) ([]ConfigError, error) {
+	config, err := config.ParseYamlBytes(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateSources([]configSource{{data: source}}, config)
+}
+
+// ValidateConfigFile validates the config file at the given path, and every file it
+// includes, with the same checks as ValidateConfig
+func ValidateConfigFile(filePath string) ([]ConfigError, error) {
+	config, err := cfg.ParseWtfConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := cfg.WtfConfigFiles(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []configSource{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, configSource{file: file, data: data})
+	}
+
+	return validateSources(sources, config)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// validateSources validates the module blocks in each of the config files against the
+// settings read from the config the files merge into. A module can be defined in more than
+// one file, in which case the keys of every definition are checked, and the rest of the
+// checks are reported on the definition that takes precedence
+func validateSources(sources []configSource, config *config.Config) ([]ConfigError, error) {
+	errs := []ConfigError{}
+	primary := []moduleNode{}
+	seen := map[string]bool{}
+
+	for order, source := range sources {
+		root := yaml.Node{}
+		if err := yaml.Unmarshal(source.data, &root); err != nil {
+			if source.file != "" {
+				return nil, fmt.Errorf("%s: %v", source.file, err)
+			}
+
+			return nil, err
+		}
+
+		for _, module := range loadModuleNodes(&root, config) {
+			module.file = source.file
+			module.order = order
+
+			errs = append(errs, validateKeys(module)...)
+
+			// The config files are in order of precedence, so the first definition wins
+			if !seen[module.name] {
+				seen[module.name] = true
+				primary = append(primary, module)
+			}
+		}
+	}
+
+	for _, module := range primary {
+		errs = append(errs, validateModule(module)...)
+	}
+	errs = append(errs, validateOverlaps(primary, config)...)
+
+	fileOrder := map[string]int{}
+	for order, source := range sources {
+		fileOrder[source.file] = order
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].File != errs[j].File {
+			return fileOrder[errs[i].File] < fileOrder[errs[j].File]
+		}
+
+		if errs[i].Line == errs[j].Line {
+			return errs[i].Column < errs[j].Column
+		}
+
+		return errs[i].Line < errs[j].Line
+	})
+
+	return errs, nil
+}
+
+// loadModuleNodes returns the enabled modules under wtf.mods, in the order they appear in the file
+func loadModuleNodes(root *yaml.Node, config *config.Config) []moduleNode {
+	_, mods := findNode(root, "wtf", "mods")
+	if mods == nil || mods.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	modules := []moduleNode{}
+
+	for i := 0; i+1 < len(mods.Content); i += 2 {
+		name := mods.Content[i].Value
+
+		common, settings, ok := moduleSettings(name, config)
+		if !ok {
+			continue
+		}
+
+		modules = append(modules, moduleNode{
+			name:     name,
+			key:      mods.Content[i],
+			value:    mods.Content[i+1],
+			common:   common,
+			settings: settings,
+		})
+	}
+
+	return modules
+}
+
+// findNode walks down the mappings from node, following the given keys. It returns the
+// key and value nodes of the last key, or nils if the path does not exist
+func findNode(node *yaml.Node, keys ...string) (*yaml.Node, *yaml.Node) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	var keyNode *yaml.Node
+
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				keyNode, node = node.Content[i], node.Content[i+1]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, nil
+		}
+	}
+
+	return keyNode, node
+}
+
+// error returns a ConfigError located at the given node of the module's block
+func (module moduleNode) error(node *yaml.Node, format string, args ...interface{}) ConfigError {
+	return ConfigError{
+		File:    module.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Module:  module.name,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// moduleSettings returns the common settings of the module with the given name, and the
+// type of its own settings, or nil if its type is unknown. The settings are read the same
+// way MakeWidget reads them, but no widget is built. It returns FALSE if the module is not
+// defined or not enabled, as MakeWidget does not build those
+func moduleSettings(name string, config *config.Config) (*cfg.Common, reflect.Type, bool) {
+	moduleConfig, _ := config.Get("wtf.mods." + name)
+	if moduleConfig == nil || !moduleConfig.UBool("enabled", false) {
+		return nil, nil, false
+	}
+
+	common := cfg.NewCommonSettingsFromModule(name, name, false, moduleConfig, config)
+
+	makeSettings, ok := settingsMakers[moduleConfig.UString("type", name)]
+	if !ok {
+		return common, nil, true
+	}
+
+	return common, reflect.TypeOf(makeSettings(name, moduleConfig, config)), true
+}
+
+// suggestKey returns the known key closest to the given key, if one is close enough to
+// likely be what was meant
+func suggestKey(key string, schema *cfg.SettingsSchema) string {
+	knownKeys := []string{}
+	for known := range schema.Keys {
+		knownKeys = append(knownKeys, known)
+	}
+	sort.Strings(knownKeys)
+
+	suggestion := ""
+	best := maxSuggestionDistance + 1
+
+	for _, known := range knownKeys {
+		distance := editDistance(strings.ToLower(key), strings.ToLower(known))
+		if distance < best {
+			suggestion, best = known, distance
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = utils.MinInt(utils.MinInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+
+		prev = curr
+	}
+
+	return prev[len(b)]
+}
+
+// validateKeys checks each key in the module's block against the module's settings
+func validateKeys(module moduleNode) []ConfigError {
+	if module.value.Kind != yaml.MappingNode {
+		return []ConfigError{module.error(module.value, "module settings must be a map")}
+	}
+
+	// Unknown modules have no settings to check against. validateModule reports them
+	if module.settings == nil {
+		return nil
+	}
+
+	errs := []ConfigError{}
+
+	schema := cfg.NewSettingsSchema(module.settings)
+
+	for i := 0; i+1 < len(module.value.Content); i += 2 {
+		keyNode, valueNode := module.value.Content[i], module.value.Content[i+1]
+
+		// Keys ending in "+" append to a list defined in an included file
+		key := strings.TrimSuffix(keyNode.Value, "+")
+
+		if _, ok := schema.Keys[key]; !ok {
+			if schema.Open {
+				continue
+			}
+
+			if suggestion := suggestKey(key, schema); suggestion != "" {
+				errs = append(errs, module.error(keyNode, "unknown key %q, did you mean %q?", key, suggestion))
+			} else {
+				errs = append(errs, module.error(keyNode, "unknown key %q", key))
+			}
+
+			continue
+		}
+
+		// Interpolated values aren't known until the config is loaded
+		if valueNode.Kind == yaml.ScalarNode && strings.Contains(valueNode.Value, "${") {
+			continue
+		}
+
+		var value interface{}
+		if err := valueNode.Decode(&value); err != nil {
+			errs = append(errs, module.error(valueNode, "%s: %v", key, err))
+			continue
+		}
+
+		if err := schema.CheckValue(key, value); err != nil {
+			errs = append(errs, module.error(valueNode, "%s: %v", key, err))
+			continue
+		}
+
+		if key == "focusChar" {
+			if focusChar, err := strconv.Atoi(fmt.Sprint(value)); err == nil && (focusChar < 1 || focusChar > 9) {
+				errs = append(errs, module.error(valueNode, "focusChar must be between 1 and 9, got %d", focusChar))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateModule checks the common settings read from the module's block
+func validateModule(module moduleNode) []ConfigError {
+	if module.value.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	if module.settings == nil {
+		keyNode, _ := findNode(module.value, "type")
+		if keyNode == nil {
+			keyNode = module.key
+		}
+
+		return []ConfigError{module.error(keyNode, "unknown module type %q", module.common.Module.Type)}
+	}
+
+	errs := []ConfigError{}
+
+	for _, val := range module.common.Validations() {
+		if !val.HasError() {
+			continue
+		}
+
+		node := module.key
+		if keyNode, valueNode := findNode(module.value, "position", val.Name()); keyNode != nil {
+			node = valueNode
+		} else if keyNode, _ := findNode(module.value, "position"); keyNode != nil {
+			node = keyNode
+		}
+
+		errs = append(errs, module.error(node, "position.%s: %v", val.Name(), val.Error()))
+	}
+
+	errs = append(errs, validatePositionRanges(module)...)
+
+	return errs
+}
+
+// validateOverlaps reports modules whose positions overlap another module on the same page
+func validateOverlaps(modules []moduleNode, config *config.Config) []ConfigError {
+	names := []string{}
+	nodes := map[string]moduleNode{}
+
+	for _, module := range modules {
+		names = append(names, module.name)
+		nodes[module.name] = module
+	}
+
+	settings := loadPageSettings(config)
+	errs := []ConfigError{}
+
+	for idx, pageNames := range assignModules(settings, names) {
+		for i, name := range pageNames {
+			for _, otherName := range pageNames[:i] {
+				module := nodes[name]
+				otherModule := nodes[otherName]
+
+				if !positionsOverlap(module.common, otherModule.common) {
+					continue
+				}
+
+				// Report the overlap on whichever of the two comes later in the config
+				if module.order < otherModule.order || (module.order == otherModule.order && module.key.Line < otherModule.key.Line) {
+					module, otherModule = otherModule, module
+				}
+
+				node := module.key
+				if keyNode, _ := findNode(module.value, "position"); keyNode != nil {
+					node = keyNode
+				}
+
+				message := fmt.Sprintf("position overlaps with %q", otherModule.name)
+				if len(settings) > 1 {
+					message += fmt.Sprintf(" on page %q", settings[idx].name)
+				}
+
+				errs = append(errs, module.error(node, "%s", message))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validatePositionRanges reports positions that are off the grid or that have no size
+func validatePositionRanges(module moduleNode) []ConfigError {
+	position := module.common.PositionSettings
+
+	ranges := []struct {
+		name  string
+		value int
+		min   int
+	}{
+		{"top", position.Top, 0},
+		{"left", position.Left, 0},
+		{"width", position.Width, 1},
+		{"height", position.Height, 1},
+	}
+
+	errs := []ConfigError{}
+
+	for _, r := range ranges {
+		_, valueNode := findNode(module.value, "position", r.name)
+		if valueNode == nil || r.value >= r.min {
+			continue
+		}
+
+		errs = append(errs, module.error(valueNode, "position.%s must be at least %d, got %d", r.name, r.min, r.value))
+	}
+
+	return errs
+}
+
+func positionsOverlap(a, b *cfg.Common) bool {
+	if a.Width <= 0 || a.Height <= 0 || b.Width <= 0 || b.Height <= 0 {
+		return false
+	}
+
+	return a.Left < b.Left+b.Width && b.Left < a.Left+a.Width &&
+		a.Top < b.Top+b.Height && b.Top < a.Top+a.Height
+}
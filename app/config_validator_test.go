@@ -0,0 +1,179 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const invalidConfig = `
+wtf:
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      focusChar: 12
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 2
+      refreshIntervl: 30
+      border: "sometimes"
+    todo:
+      enabled: true
+      filename: "todo.yml"
+      position:
+        top: 0
+        left: 1
+        height: 1
+        width: 1
+    textfile:
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: -1
+    cats:
+      enabled: true
+      position:
+        top: 1
+        left: 1
+        height: 1
+        width: 1
+    disabled:
+      enabled: false
+      type: dogs`
+
+func Test_ValidateConfig(t *testing.T) {
+	errs, err := ValidateConfig([]byte(invalidConfig))
+	assert.NoError(t, err)
+
+	messages := []string{}
+	for _, configErr := range errs {
+		messages = append(messages, configErr.Error())
+	}
+
+	assert.Equal(t,
+		[]string{
+			"9:18: clocks: focusChar must be between 1 and 9, got 12",
+			`15:7: clocks: unknown key "refreshIntervl", did you mean "refreshInterval"?`,
+			`16:15: clocks: border: expected true or false, got "sometimes"`,
+			`20:7: todo: position overlaps with "clocks"`,
+			"31:16: textfile: position.width must be at least 1, got -1",
+			`32:5: cats: unknown module type "cats"`,
+		},
+		messages,
+	)
+}
+
+func Test_ValidateConfig_Valid(t *testing.T) {
+	errs, err := ValidateConfig([]byte(renderConfig))
+
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+}
+
+func Test_ValidateConfig_Pages(t *testing.T) {
+	config := renderConfig + `
+    todo:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+  pages:
+    - name: main
+      modules: ["clocks"]
+    - name: other
+      modules: ["todo"]`
+
+	errs, err := ValidateConfig([]byte(config))
+
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+}
+
+func Test_ValidateConfigFile_Includes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-validate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := `
+wtf:
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      locations:
+        UTC: "Etc/UTC"
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshIntervl: 15
+`
+
+	config := `
+include: base.yml
+wtf:
+  mods:
+    clocks:
+      focusChar: 12
+      title: "${WTF_CLOCKS_TITLE:-Clocks}"
+`
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "base.yml"), []byte(base), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "config.yml"), []byte(config), 0600))
+
+	errs, err := ValidateConfigFile(filepath.Join(dir, "config.yml"))
+	assert.NoError(t, err)
+
+	messages := []string{}
+	for _, configErr := range errs {
+		messages = append(messages, configErr.Error())
+	}
+
+	assert.Equal(t,
+		[]string{
+			filepath.Join(dir, "config.yml") + ":6:18: clocks: focusChar must be between 1 and 9, got 12",
+			filepath.Join(dir, "base.yml") + `:16:7: clocks: unknown key "refreshIntervl", did you mean "refreshInterval"?`,
+		},
+		messages,
+	)
+}
+
+// The sample configs only use keys that modules read, so the validator must accept them all
+func Test_ValidateConfigFile_SampleConfigs(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "_sample_configs", "*.yml"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			errs, err := ValidateConfigFile(file)
+
+			assert.NoError(t, err)
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("cats", "cats"))
+	assert.Equal(t, 1, editDistance("cats", "cat"))
+	assert.Equal(t, 1, editDistance("cats", "bats"))
+	assert.Equal(t, 4, editDistance("", "cats"))
+}
@@ -9,42 +9,59 @@ import (
 
 // Display is the container for the onscreen representation of a WtfApp
 type Display struct {
-	Grid   *tview.Grid
-	config *config.Config
+	Grid        *tview.Grid
+	active      int
+	breakpoints []breakpoint
+	config      *config.Config
+	gridPath    string
+	widgets     []wtf.Wtfable
 }
 
-// NewDisplay creates and returns a Display
+// NewDisplay creates and returns a Display laid out using the wtf.grid settings
 func NewDisplay(widgets []wtf.Wtfable, config *config.Config) *Display {
+	return newGridDisplay(widgets, config, "wtf.grid")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// newGridDisplay creates and returns a Display laid out using the grid settings
+// found at gridPath in the config
+func newGridDisplay(widgets []wtf.Wtfable, config *config.Config, gridPath string) *Display {
 	display := Display{
-		Grid:   tview.NewGrid(),
-		config: config,
+		Grid:        tview.NewGrid(),
+		active:      -1,
+		breakpoints: loadBreakpoints(config, gridPath),
+		config:      config,
+		gridPath:    gridPath,
 	}
 
-	firstWidget := widgets[0]
-	display.Grid.SetBackgroundColor(
-		wtf.ColorFor(
-			firstWidget.CommonSettings().Colors.WidgetTheme.Background,
-		),
-	)
+	if len(widgets) > 0 {
+		firstWidget := widgets[0]
+		display.Grid.SetBackgroundColor(
+			wtf.ColorFor(
+				firstWidget.CommonSettings().Colors.WidgetTheme.Background,
+			),
+		)
+	}
 
 	display.build(widgets)
 
 	return &display
 }
 
-/* -------------------- Unexported Functions -------------------- */
-
+// add places the widget in the grid where the current layout puts it
 func (display *Display) add(widget wtf.Wtfable) {
-	if widget.Disabled() {
-		return
-	}
+	// Re-laying out the whole grid keeps stacked layouts in order
+	display.build(append(display.widgets, widget))
+}
 
+func (display *Display) addItem(item gridItem) {
 	display.Grid.AddItem(
-		widget.TextView(),
-		widget.CommonSettings().Top,
-		widget.CommonSettings().Left,
-		widget.CommonSettings().Height,
-		widget.CommonSettings().Width,
+		item.widget.TextView(),
+		item.position.top,
+		item.position.left,
+		item.position.height,
+		item.position.width,
 		0,
 		0,
 		false,
@@ -52,16 +69,69 @@ func (display *Display) add(widget wtf.Wtfable) {
 }
 
 func (display *Display) build(widgets []wtf.Wtfable) *tview.Grid {
-	cols := utils.ToInts(display.config.UList("wtf.grid.columns"))
-	rows := utils.ToInts(display.config.UList("wtf.grid.rows"))
+	display.widgets = append([]wtf.Wtfable{}, widgets...)
 
-	display.Grid.SetColumns(cols...)
-	display.Grid.SetRows(rows...)
+	display.Grid.Clear()
 	display.Grid.SetBorder(false)
 
-	for _, widget := range widgets {
-		display.add(widget)
+	layout := display.layout(widgets)
+
+	display.Grid.SetColumns(layout.columns...)
+	display.Grid.SetRows(layout.rows...)
+
+	for _, item := range layout.items {
+		display.addItem(item)
 	}
 
 	return display.Grid
 }
+
+// isHidden returns TRUE if the current layout does not display the widget
+func (display *Display) isHidden(widget wtf.Wtfable) bool {
+	for _, item := range display.layout(display.widgets).items {
+		if item.widget == widget {
+			return false
+		}
+	}
+
+	return true
+}
+
+// layout arranges the widgets using the active breakpoint, if there is one
+func (display *Display) layout(widgets []wtf.Wtfable) gridLayout {
+	cols := utils.ToInts(display.config.UList(display.gridPath + ".columns"))
+	rows := utils.ToInts(display.config.UList(display.gridPath + ".rows"))
+
+	var bp *breakpoint
+	if display.active >= 0 {
+		bp = &display.breakpoints[display.active]
+	}
+
+	return computeLayout(widgets, cols, rows, bp)
+}
+
+// remove takes the widget out of the grid, leaving the rest of the layout as it is
+func (display *Display) remove(widget wtf.Wtfable) {
+	display.Grid.RemoveItem(widget.TextView())
+
+	for idx, existing := range display.widgets {
+		if existing == widget {
+			display.widgets = append(display.widgets[:idx:idx], display.widgets[idx+1:]...)
+			break
+		}
+	}
+}
+
+// resize picks the breakpoint that matches the new terminal size and, if it is not the one
+// in use, lays the grid out again. It returns TRUE if the layout changed
+func (display *Display) resize(width, height int) bool {
+	active := selectBreakpoint(display.breakpoints, width, height)
+	if active == display.active {
+		return false
+	}
+
+	display.active = active
+	display.build(display.widgets)
+
+	return true
+}
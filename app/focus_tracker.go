@@ -26,6 +26,10 @@ type FocusTracker struct {
 	Widgets   []wtf.Wtfable
 
 	config *config.Config
+
+	// hidden, if set, returns TRUE for widgets that the current layout does not display.
+	// Hidden widgets cannot be focused
+	hidden func(widget wtf.Wtfable) bool
 }
 
 func NewFocusTracker(app *tview.Application, widgets []wtf.Wtfable, config *config.Config) FocusTracker {
@@ -114,6 +118,19 @@ func (tracker *FocusTracker) Refocus() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// update replaces the widgets being tracked, i.e.: after a config reload, keeping the
+// current focus index where it is still valid
+func (tracker *FocusTracker) update(widgets []wtf.Wtfable, config *config.Config) {
+	tracker.Widgets = widgets
+	tracker.config = config
+
+	if tracker.Idx >= len(tracker.focusables()) {
+		tracker.Idx = len(tracker.focusables()) - 1
+	}
+
+	tracker.assignHotKeys()
+}
+
 // AssignHotKeys assigns an alphabetic keyboard character to each focusable
 // widget so that the widget can be brought into focus by pressing that keyboard key
 // Valid numbers are between 1 and 9, inclusive
@@ -203,9 +220,15 @@ func (tracker *FocusTracker) focusables() []wtf.Wtfable {
 	focusable := []wtf.Wtfable{}
 
 	for _, widget := range tracker.Widgets {
-		if widget.Focusable() {
-			focusable = append(focusable, widget)
+		if !widget.Focusable() {
+			continue
+		}
+
+		if tracker.hidden != nil && tracker.hidden(widget) {
+			continue
 		}
+
+		focusable = append(focusable, widget)
 	}
 
 	// Sort for deterministic ordering
@@ -225,6 +248,23 @@ func (tracker *FocusTracker) focusables() []wtf.Wtfable {
 	return focusable
 }
 
+// focusWidget sets the focus on the given widget. It returns FALSE if the widget
+// is not one of the focusable widgets being tracked
+func (tracker *FocusTracker) focusWidget(widget wtf.Wtfable) bool {
+	for idx, focusable := range tracker.focusables() {
+		if focusable == widget {
+			tracker.blur(tracker.Idx)
+			tracker.Idx = idx
+			tracker.focus(tracker.Idx)
+			tracker.IsFocused = true
+
+			return true
+		}
+	}
+
+	return false
+}
+
 func (tracker *FocusTracker) focusableAt(idx int) wtf.Wtfable {
 	if idx < 0 || idx >= len(tracker.focusables()) {
 		return nil
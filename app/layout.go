@@ -0,0 +1,241 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// gridPosition is the cell range a widget occupies in the grid
+type gridPosition struct {
+	top    int
+	left   int
+	height int
+	width  int
+}
+
+// gridItem is a widget and where it is placed in the grid
+type gridItem struct {
+	widget   wtf.Wtfable
+	position gridPosition
+}
+
+// gridLayout is the complete arrangement of a grid: its column and row sizes and the
+// widgets placed in it. Widgets that are not in items are not displayed
+type gridLayout struct {
+	columns []int
+	rows    []int
+	items   []gridItem
+}
+
+// breakpoint is an alternate layout used when the terminal is at or below a given size.
+// Breakpoints are defined as a list under the grid they modify:
+//
+//    wtf:
+//      grid:
+//        columns: [40, 40, 40, 40]
+//        rows: [10, 10, 10]
+//        breakpoints:
+//          - name: tiny
+//            maxWidth: 60
+//            maxHeight: 20
+//            stack: true
+//          - name: laptop
+//            maxWidth: 120
+//            columns: [40, 40]
+//            rows: [10, 10, 10, 10]
+//            modules:
+//              clocks:
+//                top: 2
+//                left: 0
+//                height: 1
+//                width: 2
+//              security:
+//                hidden: true
+//
+// The first breakpoint that matches the terminal size is used, so smaller breakpoints should
+// be listed before larger ones. A breakpoint can replace the columns and rows of the grid,
+// move or hide modules, or, with stack, ignore the module positions and stack the modules on
+// top of each other in a single column
+type breakpoint struct {
+	name      string
+	maxWidth  int
+	maxHeight int
+
+	columns   []int
+	rows      []int
+	hidden    map[string]bool
+	positions map[string]gridPosition
+	stack     bool
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loadBreakpoints reads the breakpoints defined for the grid at gridPath
+func loadBreakpoints(config *config.Config, gridPath string) []breakpoint {
+	list, err := config.List(gridPath + ".breakpoints")
+	if err != nil {
+		return nil
+	}
+
+	breakpoints := []breakpoint{}
+
+	for idx := range list {
+		path := fmt.Sprintf("%s.breakpoints.%d", gridPath, idx)
+
+		bp := breakpoint{
+			name:      config.UString(path+".name", fmt.Sprintf("breakpoint%d", idx+1)),
+			maxWidth:  config.UInt(path+".maxWidth", 0),
+			maxHeight: config.UInt(path+".maxHeight", 0),
+
+			columns:   utils.ToInts(config.UList(path + ".columns")),
+			rows:      utils.ToInts(config.UList(path + ".rows")),
+			hidden:    map[string]bool{},
+			positions: map[string]gridPosition{},
+			stack:     config.UBool(path+".stack", false),
+		}
+
+		for name := range config.UMap(path + ".modules") {
+			modulePath := path + ".modules." + name
+
+			if config.UBool(modulePath+".hidden", false) {
+				bp.hidden[name] = true
+				continue
+			}
+
+			if _, err := config.Int(modulePath + ".top"); err != nil {
+				continue
+			}
+
+			bp.positions[name] = gridPosition{
+				top:    config.UInt(modulePath + ".top"),
+				left:   config.UInt(modulePath + ".left"),
+				height: config.UInt(modulePath+".height", 1),
+				width:  config.UInt(modulePath+".width", 1),
+			}
+		}
+
+		breakpoints = append(breakpoints, bp)
+	}
+
+	return breakpoints
+}
+
+// selectBreakpoint returns the index of the first breakpoint that matches a terminal of the
+// given size, or -1 if none do and the base layout should be used
+func selectBreakpoint(breakpoints []breakpoint, width, height int) int {
+	for idx, bp := range breakpoints {
+		if bp.matches(width, height) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// matches returns TRUE if the breakpoint applies to a terminal of the given size
+func (bp *breakpoint) matches(width, height int) bool {
+	if bp.maxWidth > 0 && width > bp.maxWidth {
+		return false
+	}
+
+	if bp.maxHeight > 0 && height > bp.maxHeight {
+		return false
+	}
+
+	return true
+}
+
+// computeLayout arranges the widgets in the base grid, modified by the breakpoint if one is given
+func computeLayout(widgets []wtf.Wtfable, columns, rows []int, bp *breakpoint) gridLayout {
+	layout := gridLayout{columns: columns, rows: rows}
+
+	visible := []wtf.Wtfable{}
+	for _, widget := range widgets {
+		if widget.Disabled() {
+			continue
+		}
+
+		if bp != nil && bp.hidden[widget.Name()] {
+			continue
+		}
+
+		visible = append(visible, widget)
+	}
+
+	if bp == nil {
+		for _, widget := range visible {
+			layout.items = append(layout.items, gridItem{widget: widget, position: basePosition(widget)})
+		}
+
+		return layout
+	}
+
+	if bp.stack {
+		return stackLayout(visible)
+	}
+
+	if len(bp.columns) > 0 {
+		layout.columns = bp.columns
+	}
+
+	if len(bp.rows) > 0 {
+		layout.rows = bp.rows
+	}
+
+	for _, widget := range visible {
+		position, ok := bp.positions[widget.Name()]
+		if !ok {
+			position = basePosition(widget)
+		}
+
+		layout.items = append(layout.items, gridItem{widget: widget, position: position})
+	}
+
+	return layout
+}
+
+// stackLayout places the widgets in a single column, one per row, in the order they
+// appear in the base grid. The rows share the height of the screen equally
+func stackLayout(widgets []wtf.Wtfable) gridLayout {
+	sorted := append([]wtf.Wtfable{}, widgets...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iSettings := sorted[i].CommonSettings()
+		jSettings := sorted[j].CommonSettings()
+
+		if iSettings.Top == jSettings.Top {
+			return iSettings.Left < jSettings.Left
+		}
+
+		return iSettings.Top < jSettings.Top
+	})
+
+	layout := gridLayout{
+		columns: []int{0},
+		rows:    make([]int, len(sorted)),
+	}
+
+	for idx, widget := range sorted {
+		layout.items = append(layout.items, gridItem{
+			widget:   widget,
+			position: gridPosition{top: idx, left: 0, height: 1, width: 1},
+		})
+	}
+
+	return layout
+}
+
+func basePosition(widget wtf.Wtfable) gridPosition {
+	settings := widget.CommonSettings()
+
+	return gridPosition{
+		top:    settings.Top,
+		left:   settings.Left,
+		height: settings.Height,
+		width:  settings.Width,
+	}
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const layoutConfig = `
+wtf:
+  grid:
+    columns: [40, 40, 40]
+    rows: [10, 10]
+    breakpoints:
+      - maxWidth: 60
+        maxHeight: 20
+        stack: true
+      - name: laptop
+        maxWidth: 120
+        columns: [40, 40]
+        rows: [10, 10, 10]
+        modules:
+          clocks_c:
+            top: 2
+            left: 0
+            height: 1
+            width: 2
+          clocks_b:
+            hidden: true
+  mods:
+    clocks_a:
+      type: clocks
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+    clocks_b:
+      type: clocks
+      enabled: true
+      position:
+        top: 0
+        left: 1
+        height: 1
+        width: 1
+    clocks_c:
+      type: clocks
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 3`
+
+func makeLayoutWidgets(t *testing.T) (*config.Config, []wtf.Wtfable) {
+	cfg, err := config.ParseYaml(layoutConfig)
+	assert.NoError(t, err)
+
+	widgets := []wtf.Wtfable{}
+	for _, name := range []string{"clocks_a", "clocks_b", "clocks_c"} {
+		widgets = append(widgets, MakeWidget(tview.NewApplication(), nil, name, cfg))
+	}
+
+	return cfg, widgets
+}
+
+func Test_loadBreakpoints(t *testing.T) {
+	cfg, _ := config.ParseYaml(layoutConfig)
+
+	breakpoints := loadBreakpoints(cfg, "wtf.grid")
+
+	assert.Len(t, breakpoints, 2)
+
+	assert.Equal(t, "breakpoint1", breakpoints[0].name)
+	assert.Equal(t, 60, breakpoints[0].maxWidth)
+	assert.Equal(t, 20, breakpoints[0].maxHeight)
+	assert.True(t, breakpoints[0].stack)
+
+	assert.Equal(t, "laptop", breakpoints[1].name)
+	assert.Equal(t, 120, breakpoints[1].maxWidth)
+	assert.Equal(t, 0, breakpoints[1].maxHeight)
+	assert.Equal(t, []int{40, 40}, breakpoints[1].columns)
+	assert.Equal(t, map[string]bool{"clocks_b": true}, breakpoints[1].hidden)
+	assert.Equal(t, map[string]gridPosition{"clocks_c": {top: 2, left: 0, height: 1, width: 2}}, breakpoints[1].positions)
+
+	assert.Empty(t, loadBreakpoints(cfg, "wtf.pages.0.grid"))
+}
+
+func Test_selectBreakpoint(t *testing.T) {
+	cfg, _ := config.ParseYaml(layoutConfig)
+	breakpoints := loadBreakpoints(cfg, "wtf.grid")
+
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		expected int
+	}{
+		{name: "wide", width: 200, height: 50, expected: -1},
+		{name: "at the width limit", width: 120, height: 50, expected: 1},
+		{name: "narrow", width: 100, height: 50, expected: 1},
+		{name: "very narrow but tall", width: 50, height: 50, expected: 1},
+		{name: "very narrow and short", width: 50, height: 15, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, selectBreakpoint(breakpoints, tt.width, tt.height))
+		})
+	}
+}
+
+func Test_computeLayout(t *testing.T) {
+	cfg, widgets := makeLayoutWidgets(t)
+	breakpoints := loadBreakpoints(cfg, "wtf.grid")
+
+	positions := func(layout gridLayout) map[string]gridPosition {
+		result := map[string]gridPosition{}
+		for _, item := range layout.items {
+			result[item.widget.Name()] = item.position
+		}
+		return result
+	}
+
+	base := computeLayout(widgets, []int{40, 40, 40}, []int{10, 10}, nil)
+	assert.Equal(t, []int{40, 40, 40}, base.columns)
+	assert.Equal(t,
+		map[string]gridPosition{
+			"clocks_a": {top: 0, left: 0, height: 1, width: 1},
+			"clocks_b": {top: 0, left: 1, height: 1, width: 1},
+			"clocks_c": {top: 1, left: 0, height: 1, width: 3},
+		},
+		positions(base),
+	)
+
+	laptop := computeLayout(widgets, []int{40, 40, 40}, []int{10, 10}, &breakpoints[1])
+	assert.Equal(t, []int{40, 40}, laptop.columns)
+	assert.Equal(t, []int{10, 10, 10}, laptop.rows)
+	assert.Equal(t,
+		map[string]gridPosition{
+			"clocks_a": {top: 0, left: 0, height: 1, width: 1},
+			"clocks_c": {top: 2, left: 0, height: 1, width: 2},
+		},
+		positions(laptop),
+	)
+
+	stacked := computeLayout(widgets, []int{40, 40, 40}, []int{10, 10}, &breakpoints[0])
+	assert.Equal(t, []int{0}, stacked.columns)
+	assert.Equal(t, []int{0, 0, 0}, stacked.rows)
+	assert.Equal(t,
+		map[string]gridPosition{
+			"clocks_a": {top: 0, left: 0, height: 1, width: 1},
+			"clocks_b": {top: 1, left: 0, height: 1, width: 1},
+			"clocks_c": {top: 2, left: 0, height: 1, width: 1},
+		},
+		positions(stacked),
+	)
+}
+
+func Test_Display_resize(t *testing.T) {
+	cfg, widgets := makeLayoutWidgets(t)
+
+	display := NewDisplay(widgets, cfg)
+	assert.False(t, display.isHidden(widgets[1]))
+
+	assert.True(t, display.resize(100, 50))
+	assert.True(t, display.isHidden(widgets[1]))
+
+	assert.False(t, display.resize(110, 40))
+
+	assert.True(t, display.resize(200, 50))
+	assert.False(t, display.isHidden(widgets[1]))
+}
@@ -56,6 +56,18 @@ func validate(widgets []wtf.Wtfable) (widgetErrors []widgetError) {
 	return widgetErrors
 }
 
+// plainErrorMessages returns the error messages without terminal color codes, suitable
+// for displaying inside the app
+func (err widgetError) plainErrorMessages() (messages []string) {
+	messages = append(messages, fmt.Sprintf(" Errors in %s.position configuration", err.name))
+
+	for _, e := range err.validationErrors {
+		messages = append(messages, fmt.Sprintf(" - %s\tError: %v", e.String(), e.Error()))
+	}
+
+	return messages
+}
+
 func (err widgetError) errorMessages() (messages []string) {
 	widgetMessage := fmt.Sprintf(
 		"%s in %s configuration",
@@ -0,0 +1,364 @@
+package app
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// defaultPageName is the name of the single page used by configs that do not define wtf.pages
+const defaultPageName = "grid"
+
+// pageSettings defines a single named page, as read from the config
+type pageSettings struct {
+	name            string
+	gridPath        string
+	modules         []string
+	pauseWhenHidden bool
+}
+
+// page is a named dashboard: a grid of widgets that is displayed on its own screen. Configs
+// that do not have a wtf.pages section have a single page that contains every module
+type page struct {
+	pageSettings
+
+	display      *Display
+	focusTracker FocusTracker
+	widgets      []wtf.Wtfable
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// SwitchToPage displays the page with the given name
+func (wtfApp *WtfApp) SwitchToPage(name string) error {
+	for idx, page := range wtfApp.dashboardPages {
+		if page.name == name {
+			wtfApp.showPage(idx)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("page %q is not defined", name)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loadPageSettings reads the page definitions from the config. Pages are defined as a list
+// under wtf.pages, each with a name, a grid, and the names of the modules it shows:
+//
+//    wtf:
+//      pages:
+//        - name: main
+//          grid:
+//            columns: [40, 40]
+//            rows: [10, 10]
+//          modules: ["clocks", "todo"]
+//        - name: ops
+//          grid:
+//            columns: [80]
+//            rows: [20]
+//          modules: ["pagerduty"]
+//          pauseWhenHidden: true
+//
+// Enabled modules that are not listed on any page are displayed on the first page
+func loadPageSettings(config *config.Config) []pageSettings {
+	pageList, err := config.List("wtf.pages")
+	if err != nil || len(pageList) == 0 {
+		return []pageSettings{
+			{name: defaultPageName, gridPath: "wtf.grid"},
+		}
+	}
+
+	settings := []pageSettings{}
+
+	for idx := range pageList {
+		path := fmt.Sprintf("wtf.pages.%d", idx)
+
+		settings = append(settings, pageSettings{
+			name:            config.UString(path+".name", fmt.Sprintf("page%d", idx+1)),
+			gridPath:        path + ".grid",
+			modules:         utils.ToStrs(config.UList(path + ".modules")),
+			pauseWhenHidden: config.UBool(path+".pauseWhenHidden", false),
+		})
+	}
+
+	return settings
+}
+
+// assignModules returns, for each page, the names of the modules it displays
+func assignModules(settings []pageSettings, names []string) [][]string {
+	assigned := make([][]string, len(settings))
+	listed := map[string]bool{}
+
+	for idx, setting := range settings {
+		for _, name := range setting.modules {
+			listed[name] = true
+
+			if containsString(names, name) {
+				assigned[idx] = append(assigned[idx], name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if !listed[name] {
+			assigned[0] = append(assigned[0], name)
+		}
+	}
+
+	return assigned
+}
+
+// assignWidgets returns, for each page, the widgets it displays
+func assignWidgets(settings []pageSettings, widgets []wtf.Wtfable) [][]wtf.Wtfable {
+	names := []string{}
+	for _, widget := range widgets {
+		names = append(names, widget.Name())
+	}
+
+	assigned := make([][]wtf.Wtfable, len(settings))
+
+	for idx, pageNames := range assignModules(settings, names) {
+		for _, name := range pageNames {
+			assigned[idx] = append(assigned[idx], findWidget(widgets, name))
+		}
+	}
+
+	return assigned
+}
+
+// makePages builds a page for each page definition in the config
+func makePages(app *tview.Application, widgets []wtf.Wtfable, config *config.Config) []*page {
+	settings := loadPageSettings(config)
+	assigned := assignWidgets(settings, widgets)
+
+	pages := []*page{}
+
+	for idx, setting := range settings {
+		newPage := &page{
+			pageSettings: setting,
+
+			display:      newGridDisplay(assigned[idx], config, setting.gridPath),
+			focusTracker: NewFocusTracker(app, assigned[idx], config),
+			widgets:      assigned[idx],
+		}
+		newPage.focusTracker.hidden = newPage.display.isHidden
+
+		pages = append(pages, newPage)
+	}
+
+	return pages
+}
+
+func (p *page) contains(widget wtf.Wtfable) bool {
+	return findWidget(p.widgets, widget.Name()) != nil
+}
+
+// resize re-lays out the page for the new terminal size, if its grid has a breakpoint for
+// it. The focus stays on the focused widget if the new layout still displays it
+func (p *page) resize(width, height int) {
+	tracker := &p.focusTracker
+
+	var focused wtf.Wtfable
+	if tracker.IsFocused {
+		focused = tracker.focusableAt(tracker.Idx)
+	}
+
+	if !p.display.resize(width, height) {
+		return
+	}
+
+	tracker.Idx = -1
+	tracker.update(p.widgets, tracker.config)
+
+	if focused != nil && !tracker.focusWidget(focused) {
+		focused.TextView().Blur()
+		tracker.IsFocused = false
+	}
+}
+
+// buildPages creates the pages defined in the config and adds them to the app, replacing
+// any that already exist. The current page and each page's focus are kept where possible
+func (wtfApp *WtfApp) buildPages() {
+	oldPages := wtfApp.dashboardPages
+	currentName := ""
+	if len(oldPages) > 0 {
+		currentName = wtfApp.page().name
+	}
+
+	for _, oldPage := range oldPages {
+		wtfApp.pages.RemovePage(oldPage.name)
+	}
+
+	newPages := makePages(wtfApp.app, wtfApp.widgets, wtfApp.config)
+
+	current := 0
+	for idx, newPage := range newPages {
+		if newPage.name == currentName {
+			current = idx
+		}
+
+		for _, oldPage := range oldPages {
+			if oldPage.name == newPage.name {
+				newPage.focusTracker.Idx = oldPage.focusTracker.Idx
+				newPage.focusTracker.update(newPage.widgets, wtfApp.config)
+			}
+		}
+	}
+
+	wtfApp.pageMutex.Lock()
+	wtfApp.dashboardPages = newPages
+	wtfApp.currentPage = current
+	wtfApp.pageMutex.Unlock()
+
+	for idx, newPage := range newPages {
+		wtfApp.pages.AddPage(newPage.name, newPage.display.Grid, true, idx == current)
+	}
+}
+
+// focusTracker returns the focus tracker for the page currently onscreen
+func (wtfApp *WtfApp) focusTracker() *FocusTracker {
+	return &wtfApp.page().focusTracker
+}
+
+// modalOpen returns TRUE if something other than a page, such as the command palette or a
+// widget's prompt, is displayed in front of the pages
+func (wtfApp *WtfApp) modalOpen() bool {
+	name, _ := wtfApp.pages.GetFrontPage()
+	if name == "" {
+		return false
+	}
+
+	wtfApp.pageMutex.Lock()
+	defer wtfApp.pageMutex.Unlock()
+
+	for _, page := range wtfApp.dashboardPages {
+		if page.name == name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nextPage displays the next page. If the current page is the last one it wraps
+// around to the first
+func (wtfApp *WtfApp) nextPage() {
+	wtfApp.pageMutex.Lock()
+	next := (wtfApp.currentPage + 1) % len(wtfApp.dashboardPages)
+	wtfApp.pageMutex.Unlock()
+
+	wtfApp.showPage(next)
+}
+
+// page returns the page currently onscreen
+func (wtfApp *WtfApp) page() *page {
+	wtfApp.pageMutex.Lock()
+	defer wtfApp.pageMutex.Unlock()
+
+	return wtfApp.dashboardPages[wtfApp.currentPage]
+}
+
+// schedule starts refreshing the widget, pausing whenever it is only on pages that are
+// hidden and that are configured to pause when hidden
+func (wtfApp *WtfApp) schedule(widget wtf.Wtfable) {
+	interval := time.Duration(widget.RefreshInterval()) * time.Second
+
+	sched := newScheduler(interval, realClock{}, rand.Float64)
+	sched.paused = func() bool { return wtfApp.widgetPaused(widget) }
+	sched.refreshed = func() { wtfApp.recordRefresh(widget) }
+
+	sched.run(widget)
+}
+
+// onPage returns TRUE if the widget is on any of the pages
+func (wtfApp *WtfApp) onPage(widget wtf.Wtfable) bool {
+	wtfApp.pageMutex.Lock()
+	defer wtfApp.pageMutex.Unlock()
+
+	for _, page := range wtfApp.dashboardPages {
+		if page.contains(widget) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (wtfApp *WtfApp) showPage(idx int) {
+	wtfApp.pageMutex.Lock()
+	current := wtfApp.currentPage
+	wtfApp.pageMutex.Unlock()
+
+	if idx == current {
+		return
+	}
+
+	wtfApp.focusTracker().None()
+
+	wtfApp.pageMutex.Lock()
+	wtfApp.currentPage = idx
+	newPage := wtfApp.dashboardPages[idx]
+	wtfApp.pageMutex.Unlock()
+
+	wtfApp.pages.SwitchToPage(newPage.name)
+
+	// Widgets that were paused while hidden have stale data, so bring them up to date
+	if newPage.pauseWhenHidden {
+		for _, widget := range newPage.widgets {
+			widget.RequestRefresh()
+		}
+	}
+}
+
+// showWidget switches to a page the widget is on, preferring the current page, and focuses
+// the widget. It returns FALSE if the widget is not on any page
+func (wtfApp *WtfApp) showWidget(widget wtf.Wtfable) bool {
+	if wtfApp.page().contains(widget) {
+		return wtfApp.focusTracker().focusWidget(widget)
+	}
+
+	wtfApp.pageMutex.Lock()
+	pages := wtfApp.dashboardPages
+	wtfApp.pageMutex.Unlock()
+
+	for idx, page := range pages {
+		if !page.contains(widget) {
+			continue
+		}
+
+		wtfApp.showPage(idx)
+
+		return wtfApp.focusTracker().focusWidget(widget)
+	}
+
+	return false
+}
+
+// widgetPaused returns TRUE if the widget should not be refreshed because every page it
+// is on is hidden and set to pause when hidden
+func (wtfApp *WtfApp) widgetPaused(widget wtf.Wtfable) bool {
+	wtfApp.pageMutex.Lock()
+	defer wtfApp.pageMutex.Unlock()
+
+	found := false
+
+	for idx, page := range wtfApp.dashboardPages {
+		if !page.contains(widget) {
+			continue
+		}
+
+		if idx == wtfApp.currentPage || !page.pauseWhenHidden {
+			return false
+		}
+
+		found = true
+	}
+
+	return found
+}
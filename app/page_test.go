@@ -0,0 +1,118 @@
+package app
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const pagesConfig = `
+wtf:
+  pages:
+    - name: main
+      grid:
+        columns: [40, 40]
+        rows: [10, 10]
+      modules: ["clocks_a"]
+    - grid:
+        columns: [80]
+        rows: [20]
+      modules: ["clocks_b", "missing"]
+      pauseWhenHidden: true
+  mods:
+    clocks_a:
+      type: clocks
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+    clocks_b:
+      type: clocks
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+    clocks_c:
+      type: clocks
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1`
+
+func Test_loadPageSettings(t *testing.T) {
+	withoutPages, _ := config.ParseYaml(enabled)
+	assert.Equal(
+		t,
+		[]pageSettings{{name: defaultPageName, gridPath: "wtf.grid"}},
+		loadPageSettings(withoutPages),
+	)
+
+	withPages, _ := config.ParseYaml(pagesConfig)
+	assert.Equal(
+		t,
+		[]pageSettings{
+			{name: "main", gridPath: "wtf.pages.0.grid", modules: []string{"clocks_a"}},
+			{name: "page2", gridPath: "wtf.pages.1.grid", modules: []string{"clocks_b", "missing"}, pauseWhenHidden: true},
+		},
+		loadPageSettings(withPages),
+	)
+
+	assert.Equal(t, []int{40, 40}, utils.ToInts(withPages.UList("wtf.pages.0.grid.columns")))
+}
+
+func Test_assignWidgets(t *testing.T) {
+	cfg, _ := config.ParseYaml(pagesConfig)
+
+	widgets := []wtf.Wtfable{
+		MakeWidget(nil, nil, "clocks_a", cfg),
+		MakeWidget(nil, nil, "clocks_b", cfg),
+		MakeWidget(nil, nil, "clocks_c", cfg),
+	}
+
+	assigned := assignWidgets(loadPageSettings(cfg), widgets)
+
+	assert.Equal(t, []string{"clocks_a", "clocks_c"}, widgetNames(assigned[0]))
+	assert.Equal(t, []string{"clocks_b"}, widgetNames(assigned[1]))
+}
+
+func Test_widgetPaused(t *testing.T) {
+	cfg, _ := config.ParseYaml(pagesConfig)
+
+	clocksA := MakeWidget(nil, nil, "clocks_a", cfg)
+	clocksB := MakeWidget(nil, nil, "clocks_b", cfg)
+
+	wtfApp := WtfApp{
+		pageMutex: &sync.Mutex{},
+		dashboardPages: []*page{
+			{pageSettings: pageSettings{name: "main"}, widgets: []wtf.Wtfable{clocksA}},
+			{pageSettings: pageSettings{name: "ops", pauseWhenHidden: true}, widgets: []wtf.Wtfable{clocksB}},
+		},
+	}
+
+	assert.False(t, wtfApp.widgetPaused(clocksA))
+	assert.True(t, wtfApp.widgetPaused(clocksB))
+
+	wtfApp.currentPage = 1
+
+	assert.False(t, wtfApp.widgetPaused(clocksA))
+	assert.False(t, wtfApp.widgetPaused(clocksB))
+}
+
+func widgetNames(widgets []wtf.Wtfable) []string {
+	names := []string{}
+	for _, widget := range widgets {
+		names = append(names, widget.Name())
+	}
+
+	return names
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// The output formats supported by RenderWidgets
+const (
+	RenderFormatANSI  = "ansi"
+	RenderFormatJSON  = "json"
+	RenderFormatPlain = "plain"
+)
+
+// renderFlushes is how many times the update queue is flushed after the widgets
+// refresh, to let draws that were queued by other draws run
+const renderFlushes = 3
+
+// RenderOptions defines which widgets RenderWidgets renders, and how
+type RenderOptions struct {
+	// Format is one of RenderFormatANSI, RenderFormatJSON, or RenderFormatPlain
+	Format string
+
+	// Modules limits the output to the named modules. If empty, every enabled module is rendered
+	Modules []string
+
+	// Timeout is how long to wait for the widgets to refresh. Widgets that take longer
+	// are rendered with whatever content they have and a timeout error. Zero waits forever
+	Timeout time.Duration
+}
+
+// renderedWidget is the output of a single widget
+type renderedWidget struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RenderWidgets builds the widgets defined in the config, refreshes each of them once, and
+// writes their content to w. It does not need a terminal, so it can be used in scripts,
+// status bars, and CI logs
+func RenderWidgets(config *config.Config, w io.Writer, opts RenderOptions) error {
+	switch opts.Format {
+	case "", RenderFormatANSI, RenderFormatJSON, RenderFormatPlain:
+	default:
+		return fmt.Errorf("unsupported render format %q", opts.Format)
+	}
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		return err
+	}
+
+	tviewApp := tview.NewApplication()
+	tviewApp.SetScreen(screen)
+
+	allWidgets := MakeWidgets(tviewApp, nil, config)
+	if len(allWidgets) == 0 {
+		return errors.New("no modules are enabled")
+	}
+
+	widgets, err := selectWidgets(allWidgets, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	// Lay the widgets out as they would be onscreen, because many of them size
+	// their content to fit their view
+	display := NewDisplay(allWidgets, config)
+	display.resize(screen.Size())
+	tviewApp.SetRoot(display.Grid, true)
+
+	// The widgets draw themselves through the application's update queue, so it has to
+	// be running for their content to show up
+	go func() { _ = tviewApp.Run() }()
+	defer tviewApp.Stop()
+
+	tviewApp.QueueUpdateDraw(func() {})
+	flushUpdates(tviewApp)
+
+	errs := refreshWithTimeout(widgets, opts.Timeout)
+
+	// Some widgets queue draws from inside other queued draws, so flush a few times
+	for i := 0; i < renderFlushes; i++ {
+		flushUpdates(tviewApp)
+	}
+
+	rendered := make(chan []renderedWidget)
+	tviewApp.QueueUpdate(func() {
+		rendered <- renderAll(widgets, errs, opts.Format)
+	})
+
+	return writeRendered(w, <-rendered, opts.Format)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// flushUpdates blocks until every update that is currently queued has run
+func flushUpdates(tviewApp *tview.Application) {
+	done := make(chan struct{})
+	tviewApp.QueueUpdate(func() { close(done) })
+	<-done
+}
+
+func refreshWithTimeout(widgets []wtf.Wtfable, timeout time.Duration) map[string]error {
+	done := make(chan string, len(widgets))
+
+	for _, widget := range widgets {
+		go func(widget wtf.Wtfable) {
+			widget.Refresh()
+			done <- widget.Name()
+		}(widget)
+	}
+
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		deadline = time.After(timeout)
+	}
+
+	pending := map[string]bool{}
+	for _, widget := range widgets {
+		pending[widget.Name()] = true
+	}
+
+	for len(pending) > 0 {
+		select {
+		case name := <-done:
+			delete(pending, name)
+		case <-deadline:
+			errs := map[string]error{}
+			for name := range pending {
+				errs[name] = fmt.Errorf("timed out after %s", timeout)
+			}
+
+			return errs
+		}
+	}
+
+	return map[string]error{}
+}
+
+func renderAll(widgets []wtf.Wtfable, errs map[string]error, format string) []renderedWidget {
+	rendered := []renderedWidget{}
+
+	for _, widget := range widgets {
+		view := widget.TextView()
+
+		output := renderedWidget{
+			Name:  widget.Name(),
+			Type:  widget.CommonSettings().Module.Type,
+			Title: strings.TrimSpace(utils.StripColorTags(view.GetTitle())),
+		}
+
+		if output.Title == "" {
+			output.Title = widget.Name()
+		}
+
+		content := strings.TrimRight(view.GetText(false), "\n")
+		if format == RenderFormatANSI {
+			output.Content = utils.ColorTagsToANSI(content)
+		} else {
+			output.Content = utils.StripColorTags(content)
+		}
+
+		err := errs[widget.Name()]
+		if err == nil {
+			err = widget.RefreshError()
+		}
+
+		if err != nil {
+			output.Error = err.Error()
+		}
+
+		rendered = append(rendered, output)
+	}
+
+	return rendered
+}
+
+// selectWidgets returns the widgets with the given names, sorted by their position on screen.
+// If no names are given it returns all the widgets
+func selectWidgets(widgets []wtf.Wtfable, names []string) ([]wtf.Wtfable, error) {
+	selected := []wtf.Wtfable{}
+
+	if len(names) == 0 {
+		selected = widgets
+	} else {
+		for _, name := range names {
+			widget := findWidget(widgets, name)
+			if widget == nil {
+				return nil, fmt.Errorf("module %q does not exist or is not enabled", name)
+			}
+
+			selected = append(selected, widget)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		iSettings := selected[i].CommonSettings()
+		jSettings := selected[j].CommonSettings()
+
+		if iSettings.Top == jSettings.Top {
+			return iSettings.Left < jSettings.Left
+		}
+
+		return iSettings.Top < jSettings.Top
+	})
+
+	return selected, nil
+}
+
+func writeRendered(w io.Writer, rendered []renderedWidget, format string) error {
+	switch format {
+	case RenderFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(rendered)
+	default:
+		for idx, output := range rendered {
+			if idx > 0 {
+				fmt.Fprintln(w)
+			}
+
+			fmt.Fprintf(w, "=== %s ===\n", output.Title)
+			fmt.Fprintln(w, strings.TrimRight(output.Content, "\n"))
+
+			if output.Error != "" {
+				fmt.Fprintf(w, "Error: %s\n", output.Error)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const renderConfig = `
+wtf:
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+      refreshInterval: 30
+      locations:
+        UTC: "Etc/UTC"`
+
+func Test_RenderWidgets(t *testing.T) {
+	cfg, _ := config.ParseYaml(renderConfig)
+
+	buf := &bytes.Buffer{}
+	err := RenderWidgets(cfg, buf, RenderOptions{Format: RenderFormatJSON})
+	assert.NoError(t, err)
+
+	rendered := []renderedWidget{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rendered))
+
+	assert.Len(t, rendered, 1)
+	assert.Equal(t, "clocks", rendered[0].Name)
+	assert.Equal(t, "Clocks", rendered[0].Title)
+	assert.Contains(t, rendered[0].Content, "UTC")
+	assert.NotContains(t, rendered[0].Content, "[white]")
+}
+
+func Test_RenderWidgets_Errors(t *testing.T) {
+	cfg, _ := config.ParseYaml(renderConfig)
+
+	err := RenderWidgets(cfg, &bytes.Buffer{}, RenderOptions{Format: "xml"})
+	assert.EqualError(t, err, `unsupported render format "xml"`)
+
+	err = RenderWidgets(cfg, &bytes.Buffer{}, RenderOptions{Modules: []string{"todo"}})
+	assert.EqualError(t, err, `module "todo" does not exist or is not enabled`)
+}
+
+func Test_writeRendered(t *testing.T) {
+	rendered := []renderedWidget{
+		{Name: "one", Title: "One", Content: "cats"},
+		{Name: "two", Title: "Two", Content: "", Error: "timed out after 1s"},
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, writeRendered(buf, rendered, RenderFormatPlain))
+
+	assert.Equal(t, "=== One ===\ncats\n\n=== Two ===\n\nError: timed out after 1s\n", buf.String())
+}
@@ -6,32 +6,179 @@ import (
 	"github.com/wtfutil/wtf/wtf"
 )
 
-// Schedule kicks off the first refresh of a module's data and then queues the rest of the
-// data refreshes on a timer
-func Schedule(widget wtf.Wtfable) {
+const (
+	// maxBackoff is the longest the scheduler will wait between retries of a failing
+	// widget, unless the widget's own refresh interval is longer than that
+	maxBackoff = 30 * time.Minute
+
+	// intervalSpread is the fraction of the refresh interval that is randomly added to
+	// the first scheduled refresh so that widgets sharing an interval don't all fire together
+	intervalSpread = 0.1
+)
+
+// clock abstracts the passing of time so that the scheduler can be tested without waiting
+type clock interface {
+	After(time.Duration) <-chan time.Time
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Now() time.Time                         { return time.Now() }
+
+// scheduledWidget is the subset of wtf.Wtfable that the scheduler needs
+type scheduledWidget interface {
+	wtf.Enablable
+	wtf.Schedulable
+
+	QuitChan() chan bool
+	RefreshChan() chan bool
+}
+
+// delayedWidget is implemented by widgets that sometimes have to be refreshed less often
+// than their refresh interval, i.e.: to make an API's rate limit last until it resets
+type delayedWidget interface {
+	// RefreshDelay returns the least time to wait before the next refresh
+	RefreshDelay() time.Duration
+}
+
+// scheduler tracks the refresh state of a single widget and decides when it
+// should next be refreshed
+type scheduler struct {
+	clock    clock
+	failures int
+	interval time.Duration
+	random   func() float64
+	spread   bool
+
+	// paused, if set, is checked before each scheduled refresh. While it returns TRUE
+	// the widget is not refreshed
+	paused func() bool
+
+	// refreshed, if set, is called after each refresh
+	refreshed func()
+}
+
+func newScheduler(interval time.Duration, clock clock, random func() float64) *scheduler {
+	return &scheduler{
+		clock:    clock,
+		interval: interval,
+		random:   random,
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// backoff returns the delay before the next retry of a failing widget. The delay doubles
+// with each consecutive failure, up to maxBackoff, and is jittered so that it falls
+// somewhere between half and all of that value, but never below the normal interval
+func (sched *scheduler) backoff() time.Duration {
+	ceiling := maxBackoff
+	if sched.interval > ceiling {
+		ceiling = sched.interval
+	}
+
+	delay := sched.interval
+	for i := 0; i < sched.failures && delay < ceiling; i++ {
+		delay *= 2
+	}
+
+	if delay > ceiling {
+		delay = ceiling
+	}
+
+	jittered := delay/2 + time.Duration(sched.random()*float64(delay/2))
+	if jittered < sched.interval {
+		jittered = sched.interval
+	}
+
+	return jittered
+}
+
+func (sched *scheduler) isPaused() bool {
+	return sched.paused != nil && sched.paused()
+}
+
+// nextDelay records the result of a refresh and returns how long to wait before the next one
+func (sched *scheduler) nextDelay(err error) time.Duration {
+	if err != nil {
+		sched.failures++
+		return sched.backoff()
+	}
+
+	sched.failures = 0
+
+	if !sched.spread {
+		sched.spread = true
+		return sched.interval + time.Duration(sched.random()*intervalSpread*float64(sched.interval))
+	}
+
+	return sched.interval
+}
+
+func (sched *scheduler) refresh(widget scheduledWidget) {
 	widget.Refresh()
 
-	interval := time.Duration(widget.RefreshInterval()) * time.Second
+	if sched.refreshed != nil {
+		sched.refreshed()
+	}
+}
+
+func (sched *scheduler) run(widget scheduledWidget) {
+	sched.refresh(widget)
+
+	if sched.interval <= 0 {
+		// Widgets that don't refresh on a timer are still refreshed when they ask to be
+		for sched.wait(widget, nil) {
+			sched.refresh(widget)
+		}
 
-	if interval <= 0 {
 		return
 	}
 
-	timer := time.NewTicker(interval)
-
 	for {
-		select {
-		case <-timer.C:
-			if widget.Enabled() {
-				widget.Refresh()
-			} else {
-				timer.Stop()
+		wasFailing := sched.failures > 0
+		delay := sched.nextDelay(widget.RefreshError())
+
+		if delayed, ok := widget.(delayedWidget); ok {
+			if widgetDelay := delayed.RefreshDelay(); widgetDelay > delay {
+				delay = widgetDelay
+			}
+		}
+
+		if sched.failures > 0 {
+			widget.SetNextRetry(sched.clock.Now().Add(delay))
+		} else if wasFailing {
+			widget.SetNextRetry(time.Time{})
+		}
+
+		if !sched.wait(widget, sched.clock.After(delay)) {
+			return
+		}
+
+		for sched.isPaused() {
+			if !sched.wait(widget, sched.clock.After(sched.interval)) {
 				return
 			}
+		}
+
+		sched.refresh(widget)
+	}
+}
+
+// wait blocks until the timer fires, the widget asks to be refreshed, or the widget is
+// told to quit. It returns FALSE if the widget should no longer be refreshed
+func (sched *scheduler) wait(widget scheduledWidget, timer <-chan time.Time) bool {
+	for {
+		select {
+		case <-timer:
+			return widget.Enabled()
+		case <-widget.RefreshChan():
+			return widget.Enabled()
 		case quit := <-widget.QuitChan():
 			if quit {
-				timer.Stop()
-				return
+				return false
 			}
 		}
 	}
@@ -0,0 +1,310 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errRefresh = errors.New("refresh failed")
+
+// fakeClock hands out timers that the test fires by hand and records every delay
+// the scheduler asks for
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+	timers chan chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:    time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		timers: make(chan chan time.Time, 1),
+	}
+}
+
+func (clock *fakeClock) After(d time.Duration) <-chan time.Time {
+	clock.delays = append(clock.delays, d)
+
+	timer := make(chan time.Time, 1)
+	clock.timers <- timer
+
+	return timer
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+// fire advances the clock to the pending timer and releases it
+func (clock *fakeClock) fire() {
+	timer := <-clock.timers
+	clock.now = clock.now.Add(clock.delays[len(clock.delays)-1])
+	timer <- clock.now
+}
+
+type fakeWidget struct {
+	enabled     bool
+	quitChan    chan bool
+	refreshChan chan bool
+	refreshes   int
+	results     []error
+	retries     []time.Time
+}
+
+func (widget *fakeWidget) Disable()                 { widget.enabled = false }
+func (widget *fakeWidget) Disabled() bool           { return !widget.enabled }
+func (widget *fakeWidget) Enabled() bool            { return widget.enabled }
+func (widget *fakeWidget) QuitChan() chan bool      { return widget.quitChan }
+func (widget *fakeWidget) RefreshChan() chan bool   { return widget.refreshChan }
+func (widget *fakeWidget) Refresh()                 { widget.refreshes++ }
+func (widget *fakeWidget) Refreshing() bool         { return false }
+func (widget *fakeWidget) RefreshInterval() int     { return 60 }
+func (widget *fakeWidget) SetNextRetry(t time.Time) { widget.retries = append(widget.retries, t) }
+
+func (widget *fakeWidget) RefreshError() error {
+	if widget.refreshes > len(widget.results) {
+		return nil
+	}
+
+	return widget.results[widget.refreshes-1]
+}
+
+func Test_nextDelay(t *testing.T) {
+	interval := time.Minute
+
+	tests := []struct {
+		name     string
+		random   float64
+		results  []error
+		expected []time.Duration
+	}{
+		{
+			name:     "successes spread the first refresh only",
+			random:   0.5,
+			results:  []error{nil, nil, nil},
+			expected: []time.Duration{63 * time.Second, time.Minute, time.Minute},
+		},
+		{
+			name:     "failures back off exponentially",
+			random:   1,
+			results:  []error{errRefresh, errRefresh, errRefresh},
+			expected: []time.Duration{2 * time.Minute, 4 * time.Minute, 8 * time.Minute},
+		},
+		{
+			name:     "backoff never drops below the interval",
+			random:   0,
+			results:  []error{errRefresh, errRefresh},
+			expected: []time.Duration{time.Minute, 2 * time.Minute},
+		},
+		{
+			name:     "success resets the backoff",
+			random:   1,
+			results:  []error{errRefresh, errRefresh, nil, errRefresh},
+			expected: []time.Duration{2 * time.Minute, 4 * time.Minute, 66 * time.Second, 2 * time.Minute},
+		},
+		{
+			name:     "backoff is capped",
+			random:   1,
+			results:  []error{errRefresh, errRefresh, errRefresh, errRefresh, errRefresh, errRefresh, errRefresh},
+			expected: []time.Duration{2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 30 * time.Minute, 30 * time.Minute, 30 * time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched := newScheduler(interval, newFakeClock(), func() float64 { return tt.random })
+
+			actual := []time.Duration{}
+			for _, result := range tt.results {
+				actual = append(actual, sched.nextDelay(result))
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_run(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+
+	widget := &fakeWidget{
+		enabled:  true,
+		quitChan: make(chan bool),
+		results:  []error{errRefresh, errRefresh, nil},
+	}
+
+	sched := newScheduler(time.Minute, clock, func() float64 { return 1 })
+
+	done := make(chan struct{})
+	go func() {
+		sched.run(widget)
+		close(done)
+	}()
+
+	// The initial refresh and the first retry fail, the second retry succeeds
+	clock.fire()
+	clock.fire()
+
+	widget.quitChan <- true
+	<-done
+
+	assert.Equal(t, 3, widget.refreshes)
+	assert.Equal(
+		t,
+		[]time.Duration{2 * time.Minute, 4 * time.Minute, 66 * time.Second},
+		clock.delays,
+	)
+	assert.Equal(
+		t,
+		[]time.Time{
+			start.Add(2 * time.Minute),
+			start.Add(6 * time.Minute),
+			{},
+		},
+		widget.retries,
+	)
+}
+
+func Test_run_paused(t *testing.T) {
+	clock := newFakeClock()
+
+	widget := &fakeWidget{
+		enabled:  true,
+		quitChan: make(chan bool),
+	}
+
+	pauses := 2
+	sched := newScheduler(time.Minute, clock, func() float64 { return 0 })
+	sched.paused = func() bool {
+		pauses--
+		return pauses >= 0
+	}
+
+	done := make(chan struct{})
+	go func() {
+		sched.run(widget)
+		close(done)
+	}()
+
+	// The first scheduled refresh is skipped twice while the widget is paused
+	clock.fire()
+	clock.fire()
+	clock.fire()
+
+	widget.quitChan <- true
+	<-done
+
+	assert.Equal(t, 2, widget.refreshes)
+	assert.Equal(
+		t,
+		[]time.Duration{time.Minute, time.Minute, time.Minute, time.Minute},
+		clock.delays,
+	)
+}
+
+func Test_run_requested(t *testing.T) {
+	clock := newFakeClock()
+
+	widget := &fakeWidget{
+		enabled:     true,
+		quitChan:    make(chan bool),
+		refreshChan: make(chan bool),
+		results:     []error{errRefresh, nil},
+	}
+
+	sched := newScheduler(time.Minute, clock, func() float64 { return 1 })
+
+	done := make(chan struct{})
+	go func() {
+		sched.run(widget)
+		close(done)
+	}()
+
+	// The requested refresh happens before the retry is due, and its success ends the backoff
+	<-clock.timers
+	widget.refreshChan <- true
+	<-clock.timers
+
+	widget.quitChan <- true
+	<-done
+
+	assert.Equal(t, 2, widget.refreshes)
+	assert.Equal(t, []time.Duration{2 * time.Minute, 66 * time.Second}, clock.delays)
+	assert.Equal(t, []time.Time{clock.Now().Add(2 * time.Minute), {}}, widget.retries)
+}
+
+func Test_run_requestedWithoutInterval(t *testing.T) {
+	widget := &fakeWidget{
+		enabled:     true,
+		quitChan:    make(chan bool),
+		refreshChan: make(chan bool),
+	}
+
+	sched := newScheduler(0, newFakeClock(), func() float64 { return 0 })
+
+	done := make(chan struct{})
+	go func() {
+		sched.run(widget)
+		close(done)
+	}()
+
+	widget.refreshChan <- true
+	widget.refreshChan <- true
+
+	widget.quitChan <- true
+	<-done
+
+	assert.Equal(t, 3, widget.refreshes)
+}
+
+// delayedFakeWidget asks for each of the delays in turn, and for none once they run out
+type delayedFakeWidget struct {
+	fakeWidget
+
+	delays []time.Duration
+}
+
+func (widget *delayedFakeWidget) RefreshDelay() time.Duration {
+	if len(widget.delays) == 0 {
+		return 0
+	}
+
+	delay := widget.delays[0]
+	widget.delays = widget.delays[1:]
+
+	return delay
+}
+
+func Test_run_delayed(t *testing.T) {
+	clock := newFakeClock()
+
+	widget := &delayedFakeWidget{
+		fakeWidget: fakeWidget{
+			enabled:  true,
+			quitChan: make(chan bool),
+		},
+		delays: []time.Duration{10 * time.Minute},
+	}
+
+	sched := newScheduler(time.Minute, clock, func() float64 { return 0 })
+
+	done := make(chan struct{})
+	go func() {
+		sched.run(widget)
+		close(done)
+	}()
+
+	// The delay is used while it is longer than the interval, and the interval after that
+	clock.fire()
+	clock.fire()
+
+	widget.quitChan <- true
+	<-done
+
+	assert.Equal(t, 3, widget.refreshes)
+	assert.Equal(t, []time.Duration{10 * time.Minute, time.Minute, time.Minute}, clock.delays)
+}
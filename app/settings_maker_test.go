@@ -0,0 +1,110 @@
+package app
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_settingsMakers(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  mods: {}")
+	moduleConfig, _ := config.ParseYaml("enabled: true")
+
+	for moduleType, makeSettings := range settingsMakers {
+		t.Run(moduleType, func(t *testing.T) {
+			settingsType := reflect.TypeOf(makeSettings(moduleType, moduleConfig, globalConfig))
+
+			// The validator checks config keys against the fields of the module's settings
+			assert.Equal(t, reflect.Ptr, settingsType.Kind())
+			assert.Equal(t, "Settings", settingsType.Elem().Name())
+
+			_, ok := settingsType.Elem().FieldByName("common")
+			assert.True(t, ok)
+		})
+	}
+}
+
+// settingsMakers must cover exactly the module types that MakeWidget builds, with the
+// settings of the same package, so the validator checks every module against its settings
+func Test_settingsMakers_MatchMakeWidget(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "widget_maker.go", nil, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+
+	file, err = parser.ParseFile(token.NewFileSet(), "widget_maker.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The package whose settings each case of MakeWidget's switch creates
+	built := map[string]string{}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		clause, ok := node.(*ast.CaseClause)
+		if !ok || len(clause.List) == 0 {
+			return true
+		}
+
+		// The package of the call that the case assigns its settings from
+		var pkgPath string
+		ast.Inspect(clause, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt)
+			if !ok || pkgPath != "" {
+				return pkgPath == ""
+			}
+
+			if ident, ok := assign.Lhs[0].(*ast.Ident); !ok || ident.Name != "settings" {
+				return true
+			}
+
+			if call, ok := assign.Rhs[0].(*ast.CallExpr); ok {
+				if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+					if pkg, ok := selector.X.(*ast.Ident); ok {
+						pkgPath = imports[pkg.Name]
+					}
+				}
+			}
+			return false
+		})
+
+		for _, expr := range clause.List {
+			if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING && pkgPath != "" {
+				moduleType, _ := strconv.Unquote(lit.Value)
+				built[moduleType] = pkgPath
+			}
+		}
+
+		return true
+	})
+
+	assert.NotEmpty(t, built)
+
+	globalConfig, _ := config.ParseYaml("wtf:\n  mods: {}")
+	moduleConfig, _ := config.ParseYaml("enabled: true")
+
+	made := map[string]string{}
+	for moduleType, makeSettings := range settingsMakers {
+		made[moduleType] = reflect.TypeOf(makeSettings(moduleType, moduleConfig, globalConfig)).Elem().PkgPath()
+	}
+
+	assert.Equal(t, built, made)
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/wtfutil/wtf/modules/mercurial"
 	"github.com/wtfutil/wtf/modules/nbascore"
 	"github.com/wtfutil/wtf/modules/newrelic"
+	"github.com/wtfutil/wtf/modules/oncall"
 	"github.com/wtfutil/wtf/modules/opsgenie"
 	"github.com/wtfutil/wtf/modules/pagerduty"
 	"github.com/wtfutil/wtf/modules/pihole"
@@ -208,7 +209,7 @@ func MakeWidget(
 		widget = jira.NewWidget(app, pages, settings)
 	case "kubernetes":
 		settings := kubernetes.NewSettingsFromYAML(moduleName, moduleConfig, config)
-		widget = kubernetes.NewWidget(app, settings)
+		widget = kubernetes.NewWidget(app, pages, settings)
 	case "logger":
 		settings := logger.NewSettingsFromYAML(moduleName, moduleConfig, config)
 		widget = logger.NewWidget(app, settings)
@@ -221,12 +222,15 @@ func MakeWidget(
 	case "newrelic":
 		settings := newrelic.NewSettingsFromYAML(moduleName, moduleConfig, config)
 		widget = newrelic.NewWidget(app, pages, settings)
+	case "oncall":
+		settings := oncall.NewSettingsFromYAML(moduleName, moduleConfig, config)
+		widget = oncall.NewWidget(app, pages, settings)
 	case "opsgenie":
 		settings := opsgenie.NewSettingsFromYAML(moduleName, moduleConfig, config)
 		widget = opsgenie.NewWidget(app, settings)
 	case "pagerduty":
 		settings := pagerduty.NewSettingsFromYAML(moduleName, moduleConfig, config)
-		widget = pagerduty.NewWidget(app, settings)
+		widget = pagerduty.NewWidget(app, pages, settings)
 	case "pihole":
 		settings := pihole.NewSettingsFromYAML(moduleName, moduleConfig, config)
 		widget = pihole.NewWidget(app, pages, settings)
@@ -241,7 +245,7 @@ func MakeWidget(
 		widget = pocket.NewWidget(app, pages, settings)
 	case "resourceusage":
 		settings := resourceusage.NewSettingsFromYAML(moduleName, moduleConfig, config)
-		widget = resourceusage.NewWidget(app, settings)
+		widget = resourceusage.NewWidget(app, pages, settings)
 	case "rollbar":
 		settings := rollbar.NewSettingsFromYAML(moduleName, moduleConfig, config)
 		widget = rollbar.NewWidget(app, pages, settings)
@@ -1,7 +1,10 @@
 package app
 
 import (
+	"fmt"
 	"log"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -10,23 +13,32 @@ import (
 	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/support"
 	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
 	"github.com/wtfutil/wtf/wtf"
 )
 
+// errorPage is the name of the page used to display errors on top of the widgets
+const errorPage = "error"
+
 // WtfApp is the container for a collection of widgets that are all constructed from a single
 // configuration file and displayed together
 type WtfApp struct {
 	app            *tview.Application
 	config         *config.Config
 	configFilePath string
-	display        *Display
+	currentPage    int
+	dashboardPages []*page
 	ghUser         *support.GitHubUser
-	focusTracker   FocusTracker
+	pageMutex      *sync.Mutex
 	pages          *tview.Pages
+	refreshMutex   *sync.Mutex
+	refreshTimes   map[string]time.Time
 	validator      *ModuleValidator
 	widgets        []wtf.Wtfable
+	widgetsMutex   *sync.Mutex
 }
 
 // NewWtfApp creates and returns an instance of WtfApp
@@ -35,26 +47,33 @@ func NewWtfApp(app *tview.Application, config *config.Config, configFilePath str
 		app:            app,
 		config:         config,
 		configFilePath: configFilePath,
+		pageMutex:      &sync.Mutex{},
 		pages:          tview.NewPages(),
+		refreshMutex:   &sync.Mutex{},
+		refreshTimes:   map[string]time.Time{},
+		widgetsMutex:   &sync.Mutex{},
 	}
 
 	wtfApp.app.SetBeforeDrawFunc(func(s tcell.Screen) bool {
 		s.Clear()
+
+		// The screen is redrawn after every resize event, so this is where the layout
+		// is adjusted to the new terminal size
+		wtfApp.page().resize(s.Size())
+
 		return false
 	})
 
 	wtfApp.app.SetInputCapture(wtfApp.keyboardIntercept)
 
 	wtfApp.widgets = MakeWidgets(wtfApp.app, wtfApp.pages, wtfApp.config)
-	wtfApp.display = NewDisplay(wtfApp.widgets, wtfApp.config)
-	wtfApp.focusTracker = NewFocusTracker(wtfApp.app, wtfApp.widgets, wtfApp.config)
+	wtfApp.buildPages()
 
 	githubAPIKey := readGitHubAPIKey(wtfApp.config)
 	wtfApp.ghUser = support.NewGitHubUser(githubAPIKey)
 
 	wtfApp.validator = NewModuleValidator()
 
-	wtfApp.pages.AddPage("grid", wtfApp.display.Grid, true, true)
 	wtfApp.app.SetRoot(wtfApp.pages, true)
 
 	wtfApp.validator.Validate(wtfApp.widgets)
@@ -73,10 +92,16 @@ func NewWtfApp(app *tview.Application, config *config.Config, configFilePath str
 
 // Start initializes the app
 func (wtfApp *WtfApp) Start() {
+	notify.Init(wtfApp.writeTerminal)
+
 	go wtfApp.scheduleWidgets()
 
 	go wtfApp.watchForConfigChanges()
 
+	if settings := loadAPISettings(wtfApp.config); settings.enabled {
+		go wtfApp.serveAPI(settings)
+	}
+
 	go func() { _ = wtfApp.ghUser.Load() }()
 }
 
@@ -87,6 +112,25 @@ func (wtfApp *WtfApp) Stop() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// showError displays an error in a modal on top of the current layout. Use this for
+// errors that happen while the app is running, instead of exiting
+func (wtfApp *WtfApp) showError(heading, message string) {
+	closeFunc := func() {
+		wtfApp.pages.RemovePage(errorPage)
+		wtfApp.focusTracker().Refocus()
+	}
+
+	text := fmt.Sprintf(" [red::b]%s[white]\n\n %s", heading, message)
+
+	wtfApp.app.QueueUpdateDraw(func() {
+		modal := view.NewBillboardModal(text, closeFunc)
+
+		wtfApp.pages.RemovePage(errorPage)
+		wtfApp.pages.AddPage(errorPage, modal, false, true)
+		wtfApp.app.SetFocus(modal)
+	})
+}
+
 func (wtfApp *WtfApp) stopAllWidgets() {
 	for _, widget := range wtfApp.widgets {
 		widget.Stop()
@@ -94,31 +138,43 @@ func (wtfApp *WtfApp) stopAllWidgets() {
 }
 
 func (wtfApp *WtfApp) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	// Modals, such as the command palette and text prompts, need every key to type with,
+	// except the one that quits
+	if wtfApp.modalOpen() && event.Key() != tcell.KeyCtrlC {
+		return event
+	}
+
 	// These keys are global keys used by the app. Widgets should not implement these keys
 	switch event.Key() {
 	case tcell.KeyCtrlC:
 		wtfApp.Stop()
 		wtfApp.app.Stop()
 		wtfApp.DisplayExitMessage()
+	case tcell.KeyCtrlN:
+		wtfApp.nextPage()
+		return nil
+	case tcell.KeyCtrlP:
+		wtfApp.showCommandPalette()
+		return nil
 	case tcell.KeyCtrlR:
 		wtfApp.refreshAllWidgets()
 		return nil
 	case tcell.KeyTab:
-		wtfApp.focusTracker.Next()
+		wtfApp.focusTracker().Next()
 	case tcell.KeyBacktab:
-		wtfApp.focusTracker.Prev()
+		wtfApp.focusTracker().Prev()
 		return nil
 	case tcell.KeyEsc:
-		wtfApp.focusTracker.None()
+		wtfApp.focusTracker().None()
 	}
 
 	// Checks to see if any widget has been assigned the pressed key as its focus key
-	if wtfApp.focusTracker.FocusOn(string(event.Rune())) {
+	if wtfApp.focusTracker().FocusOn(string(event.Rune())) {
 		return nil
 	}
 
 	// If no specific widget has focus, then allow the key presses to fall through to the app
-	if !wtfApp.focusTracker.IsFocused {
+	if !wtfApp.focusTracker().IsFocused {
 		switch string(event.Rune()) {
 		case "/":
 			return nil
@@ -131,16 +187,24 @@ func (wtfApp *WtfApp) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 
 func (wtfApp *WtfApp) refreshAllWidgets() {
 	for _, widget := range wtfApp.widgets {
-		go widget.Refresh()
+		go wtfApp.refreshWidget(widget)
 	}
 }
 
 func (wtfApp *WtfApp) scheduleWidgets() {
 	for _, widget := range wtfApp.widgets {
-		go Schedule(widget)
+		go wtfApp.schedule(widget)
 	}
 }
 
+// writeTerminal writes an escape sequence, such as a bell or a notification, to the terminal.
+// It writes between screen draws so that the sequence doesn't land in the middle of one
+func (wtfApp *WtfApp) writeTerminal(seq string) {
+	wtfApp.app.QueueUpdate(func() {
+		_, _ = os.Stdout.WriteString(seq)
+	})
+}
+
 func (wtfApp *WtfApp) watchForConfigChanges() {
 	watch := watcher.New()
 
@@ -151,14 +215,19 @@ func (wtfApp *WtfApp) watchForConfigChanges() {
 		for {
 			select {
 			case <-watch.Event:
-				wtfApp.Stop()
+				config, err := cfg.ParseWtfConfigFile(wtfApp.configFilePath)
+				if err != nil {
+					// Keep running with the old config until the file is fixed
+					wtfApp.showConfigError(tview.Escape(err.Error()))
+					continue
+				}
 
-				config := cfg.LoadWtfConfigFile(wtfApp.configFilePath)
-				newApp := NewWtfApp(wtfApp.app, config, wtfApp.configFilePath)
-				openURLUtil := utils.ToStrs(config.UList("wtf.openUrlUtil", []interface{}{}))
-				utils.Init(config.UString("wtf.openFileUtil", "open"), openURLUtil)
+				wtfApp.reloadConfig(config)
 
-				newApp.Start()
+				// The edit may have added or removed includes
+				if err := wtfApp.watchConfigFiles(watch); err != nil {
+					wtfApp.showError("Could not watch the config files", tview.Escape(err.Error()))
+				}
 			case err := <-watch.Error:
 				if err == watcher.ErrWatchedFileDeleted {
 					// Usually happens because the watcher looks for the file as the OS is updating it
@@ -171,9 +240,8 @@ func (wtfApp *WtfApp) watchForConfigChanges() {
 		}
 	}()
 
-	// Watch config file for changes.
-	absPath, _ := utils.ExpandHomeDir(wtfApp.configFilePath)
-	if err := watch.Add(absPath); err != nil {
+	// Watch config file, and the files it includes, for changes.
+	if err := wtfApp.watchConfigFiles(watch); err != nil {
 		log.Fatalln(err)
 	}
 
@@ -182,3 +250,29 @@ func (wtfApp *WtfApp) watchForConfigChanges() {
 		log.Fatalln(err)
 	}
 }
+
+// watchConfigFiles sets the watcher to watch the config file and every file it includes.
+// Files that are no longer included stop being watched
+func (wtfApp *WtfApp) watchConfigFiles(watch *watcher.Watcher) error {
+	files, err := cfg.WtfConfigFiles(wtfApp.configFilePath)
+	if err != nil {
+		// The includes can't be read, so keep watching whatever is already being watched
+		// until they can be
+		absPath, _ := utils.ExpandHomeDir(wtfApp.configFilePath)
+		return watch.Add(absPath)
+	}
+
+	for watched := range watch.WatchedFiles() {
+		if !containsString(files, watched) {
+			_ = watch.Remove(watched)
+		}
+	}
+
+	for _, file := range files {
+		if err := watch.Add(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
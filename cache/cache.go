@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// cacheDirName is the directory, inside the config directory, that the caches are written to
+const cacheDirName = "cache"
+
+// unsafeFileChars matches the characters in a module name that are replaced when the name
+// is used as a file name
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// Cache persists the last successful results of a module's refresh to disk, so that the
+// next time WTF starts the module can display them straight away instead of waiting on
+// its first refresh. Each module has its own cache file, in which it can store any number
+// of JSON-encodable payloads under different keys, i.e.: one per repository.
+//
+// Caching is enabled per module by setting cacheTTL, the number of seconds a stored
+// payload stays usable, in the module's config, or for every module that supports it
+// by setting wtf.cacheTTL:
+//
+//    wtf:
+//      cacheTTL: 86400
+//      mods:
+//        github:
+//          cacheTTL: 3600
+//
+// A cache with a TTL of zero is disabled: it loads nothing and stores nothing
+type Cache struct {
+	dir   string
+	name  string
+	ttl   time.Duration
+	mutex sync.Mutex
+}
+
+// entry is a single stored payload and when it was stored
+type entry struct {
+	StoredAt time.Time       `json:"storedAt"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// NewCache returns the cache for the module the settings belong to
+func NewCache(common *cfg.Common) *Cache {
+	dir := ""
+	if configDir, err := cfg.WtfConfigDir(); err == nil {
+		dir = filepath.Join(configDir, cacheDirName)
+	}
+
+	return newCache(dir, common.Name, time.Duration(common.CacheTTL)*time.Second)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Enabled returns TRUE if the cache loads and stores payloads
+func (cache *Cache) Enabled() bool {
+	return cache.dir != "" && cache.ttl > 0
+}
+
+// Load decodes the payload stored under key into payload, which should be a pointer. It
+// returns when the payload was stored, and FALSE if there is no payload younger than the
+// cache's TTL to load
+func (cache *Cache) Load(key string, payload interface{}) (time.Time, bool) {
+	if !cache.Enabled() {
+		return time.Time{}, false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entries := cache.read()
+
+	stored, ok := entries[key]
+	if !ok || time.Since(stored.StoredAt) > cache.ttl {
+		return time.Time{}, false
+	}
+
+	if err := json.Unmarshal(stored.Payload, payload); err != nil {
+		return time.Time{}, false
+	}
+
+	return stored.StoredAt, true
+}
+
+// Store encodes the payload and writes it to disk under key, replacing whatever was
+// stored under that key before. Failures are logged rather than returned, as a module
+// that cannot write its cache works as it would with the cache disabled
+func (cache *Cache) Store(key string, payload interface{}) {
+	if !cache.Enabled() {
+		return
+	}
+
+	if err := cache.store(key, payload); err != nil {
+		logger.Log(fmt.Sprintf("Storing the %s cache failed: %s", cache.name, err.Error()))
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func newCache(dir, name string, ttl time.Duration) *Cache {
+	return &Cache{
+		dir:  dir,
+		name: name,
+		ttl:  ttl,
+	}
+}
+
+func (cache *Cache) filePath() string {
+	return filepath.Join(cache.dir, unsafeFileChars.ReplaceAllString(cache.name, "_")+".json")
+}
+
+// read returns the entries in the cache file. A missing or unreadable file is an empty cache
+func (cache *Cache) read() map[string]entry {
+	entries := map[string]entry{}
+
+	data, err := ioutil.ReadFile(cache.filePath())
+	if err != nil {
+		return entries
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return map[string]entry{}
+	}
+
+	return entries
+}
+
+func (cache *Cache) store(key string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entries := cache.read()
+	entries[key] = entry{StoredAt: time.Now(), Payload: data}
+
+	contents, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return utils.WriteFileAtomic(cache.filePath(), contents)
+}
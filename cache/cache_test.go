@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPayload struct {
+	Count int
+	Names []string
+}
+
+func tempCacheDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "wtf-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func Test_StoreAndLoad(t *testing.T) {
+	dir := tempCacheDir(t)
+	defer os.RemoveAll(dir)
+
+	cache := newCache(filepath.Join(dir, "cache"), "github", time.Hour)
+	cache.Store("wtfutil/wtf", testPayload{Count: 2, Names: []string{"a", "b"}})
+	cache.Store("wtfutil/other", testPayload{Count: 1})
+
+	// A new cache for the same module reads what the first one stored
+	reloaded := newCache(filepath.Join(dir, "cache"), "github", time.Hour)
+
+	payload := testPayload{}
+	storedAt, ok := reloaded.Load("wtfutil/wtf", &payload)
+
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now(), storedAt, time.Minute)
+	assert.Equal(t, testPayload{Count: 2, Names: []string{"a", "b"}}, payload)
+
+	_, ok = reloaded.Load("wtfutil/other", &payload)
+	assert.True(t, ok)
+	assert.Equal(t, 1, payload.Count)
+
+	_, ok = reloaded.Load("wtfutil/missing", &payload)
+	assert.False(t, ok)
+}
+
+func Test_Load_Expired(t *testing.T) {
+	dir := tempCacheDir(t)
+	defer os.RemoveAll(dir)
+
+	cache := newCache(dir, "jira", time.Hour)
+	cache.Store("issues", testPayload{Count: 3})
+
+	expired := newCache(dir, "jira", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	payload := testPayload{}
+	_, ok := expired.Load("issues", &payload)
+
+	assert.False(t, ok)
+	assert.Equal(t, 0, payload.Count)
+}
+
+func Test_Disabled(t *testing.T) {
+	dir := tempCacheDir(t)
+	defer os.RemoveAll(dir)
+
+	cache := newCache(dir, "gitlab", 0)
+	assert.False(t, cache.Enabled())
+
+	cache.Store("project", testPayload{Count: 1})
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Empty(t, files)
+
+	_, ok := cache.Load("project", &testPayload{})
+	assert.False(t, ok)
+}
+
+func Test_filePath(t *testing.T) {
+	cache := newCache("/tmp/cache", "my feeds/news", time.Hour)
+
+	assert.Equal(t, "/tmp/cache/my_feeds_news.json", cache.filePath())
+}
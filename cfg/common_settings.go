@@ -23,18 +23,23 @@ type Sigils struct {
 	}
 }
 
+// Common defines the settings shared by every module. The yaml tags name the config
+// keys of the fields that are not named after their key
 type Common struct {
-	Module
-	PositionSettings `help:"Defines where in the grid this module’s widget will be displayed."`
-	Sigils
+	Module           `yaml:"type"`
+	PositionSettings `help:"Defines where in the grid this module’s widget will be displayed." yaml:"position"`
+	Sigils           `yaml:"-"`
+
+	Notify NotifySettings `help:"Defines which of the module’s events are sent as notifications, and where." optional:"true" yaml:"notify"`
 
 	Colors          ColorTheme
-	Bordered        bool   `help:"Whether or not the module should be displayed with a border." values:"true, false" optional:"true" default:"true"`
-	Enabled         bool   `help:"Whether or not this module is executed and if its data displayed onscreen." values:"true, false" optional:"true" default:"false"`
-	Focusable       bool   `help:"Whether or  not this module is focusable." values:"true, false" optional:"true" default:"false"`
-	RefreshInterval int    `help:"How often, in seconds, this module will update its data." values:"A positive integer, 0..n." optional:"true"`
-	Title           string `help:"The title string to show when displaying this module" optional:"true"`
-	Config          *config.Config
+	Bordered        bool           `help:"Whether or not the module should be displayed with a border." values:"true, false" optional:"true" default:"true" yaml:"border"`
+	CacheTTL        int            `help:"How long, in seconds, the module’s last results are cached on disk and displayed at startup. Only some modules support caching." values:"A positive integer, 0..n. 0 disables caching." optional:"true" default:"0"`
+	Enabled         bool           `help:"Whether or not this module is executed and if its data displayed onscreen." values:"true, false" optional:"true" default:"false"`
+	Focusable       bool           `help:"Whether or  not this module is focusable." values:"true, false" optional:"true" default:"false"`
+	RefreshInterval int            `help:"How often, in seconds, this module will update its data." values:"A positive integer, 0..n." optional:"true"`
+	Title           string         `help:"The title string to show when displaying this module" optional:"true"`
+	Config          *config.Config `yaml:"-"`
 
 	focusChar int `help:"Define one of the number keys as a short cut key to access the widget." optional:"true"`
 }
@@ -84,9 +89,11 @@ func NewCommonSettingsFromModule(name, defaultTitle string, defaultFocusable boo
 			Type: moduleConfig.UString("type", name),
 		},
 
+		Notify:           NewNotifySettingsFromYAML(moduleConfig, globalSettings),
 		PositionSettings: NewPositionSettingsFromYAML(name, moduleConfig),
 
 		Bordered:        moduleConfig.UBool("border", true),
+		CacheTTL:        moduleConfig.UInt("cacheTTL", globalSettings.UInt("wtf.cacheTTL", 0)),
 		Config:          moduleConfig,
 		Enabled:         moduleConfig.UBool("enabled", false),
 		Focusable:       moduleConfig.UBool("focusable", defaultFocusable),
@@ -88,12 +88,12 @@ func WtfConfigDir() (string, error) {
 	return configDir, nil
 }
 
-// LoadWtfConfigFile loads the specified config file
+// LoadWtfConfigFile loads the specified config file. If the file cannot be loaded
+// it displays the error and exits
 func LoadWtfConfigFile(filePath string) *config.Config {
-	absPath, _ := expandHomeDir(filePath)
-
-	cfg, err := config.ParseYamlFile(absPath)
+	cfg, err := ParseWtfConfigFile(filePath)
 	if err != nil {
+		absPath, _ := expandHomeDir(filePath)
 		displayWtfConfigFileLoadError(absPath, err)
 		os.Exit(1)
 	}
@@ -101,6 +101,13 @@ func LoadWtfConfigFile(filePath string) *config.Config {
 	return cfg
 }
 
+// ParseWtfConfigFile loads the specified config file, and any files it includes, and returns
+// any error encountered while doing so, rather than exiting
+func ParseWtfConfigFile(filePath string) (*config.Config, error) {
+	cfg, _, err := loadConfigWithIncludes(filePath)
+	return cfg, err
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 // chmodConfigFile sets the mode of the config file to r+w for the owner only
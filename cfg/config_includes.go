@@ -0,0 +1,322 @@
+package cfg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	// includeKey is the top-level key that lists the files a config file includes
+	includeKey = "include"
+
+	// appendSuffix marks a key whose list is appended to the list it overrides,
+	// instead of replacing it, i.e.: "repositories+:"
+	appendSuffix = "+"
+)
+
+// interpolationRegex matches ${ENV_VAR}, ${ENV_VAR:-default}, and ${file:path}. A leading $
+// escapes the match, so $${HOME} is left as ${HOME}
+var interpolationRegex = regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+
+// configLoader reads a config file and the files it includes, merging them into a single
+// config. Config files can include other files:
+//
+//    include:
+//      - team.yml
+//      - path: "~/.config/wtf/${WTF_ENV}.yml"
+//        optional: true
+//    wtf:
+//      mods:
+//        github:
+//          apiKey: "${file:~/.secrets/github}"
+//          repositories+: ["me/my-repo"]
+//
+// Included files are merged in the order they are listed, and the including file is merged
+// on top of them, so its values win. Maps are merged key by key. Lists replace the list
+// they override, unless the key ends in "+", in which case they are appended to it.
+// Relative include paths are relative to the including file.
+//
+// Every string value, including include paths, can use ${ENV_VAR} to insert an environment
+// variable (unset variables are empty unless a default is given as ${ENV_VAR:-default}) and
+// ${file:path} to insert the contents of a file
+type configLoader struct {
+	// files are the absolute paths of every file read, in the order they were read
+	files []string
+
+	// loading are the files currently being loaded, used to detect include cycles
+	loading map[string]bool
+}
+
+// configInclude is a single entry of an include list
+type configInclude struct {
+	path     string
+	optional bool
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// WtfConfigFiles returns the absolute paths of the config file and every file it
+// includes, directly or indirectly
+func WtfConfigFiles(filePath string) ([]string, error) {
+	_, files, err := loadConfigWithIncludes(filePath)
+	return files, err
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loadConfigWithIncludes reads the config file, resolving its includes and interpolations.
+// It returns the config and the absolute paths of every file that was read
+func loadConfigWithIncludes(filePath string) (*config.Config, []string, error) {
+	loader := &configLoader{loading: map[string]bool{}}
+
+	absPath, err := expandHomeDir(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err := loader.load(absPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &config.Config{Root: root}, loader.files, nil
+}
+
+func (loader *configLoader) load(filePath string) (map[string]interface{}, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if loader.loading[absPath] {
+		return nil, fmt.Errorf("%s is included by itself", absPath)
+	}
+	loader.loading[absPath] = true
+	defer delete(loader.loading, absPath)
+
+	loader.files = append(loader.files, absPath)
+
+	data, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := config.ParseYamlBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", absPath, err)
+	}
+
+	root, ok := parsed.Root.(map[string]interface{})
+	if !ok {
+		// An empty file has no settings to merge
+		if parsed.Root == nil {
+			return map[string]interface{}{}, nil
+		}
+
+		return nil, fmt.Errorf("%s: the top level of a config file must be a map", absPath)
+	}
+
+	dir := filepath.Dir(absPath)
+
+	interpolated, err := interpolate(root, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", absPath, err)
+	}
+	root = interpolated.(map[string]interface{})
+
+	includes, err := parseIncludes(root[includeKey])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", absPath, err)
+	}
+	delete(root, includeKey)
+
+	merged := map[string]interface{}{}
+
+	for _, include := range includes {
+		includePath, err := resolvePath(include.path, dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", absPath, err)
+		}
+
+		if include.optional {
+			if _, err := os.Stat(includePath); os.IsNotExist(err) {
+				continue
+			}
+		}
+
+		included, err := loader.load(includePath)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = mergeMaps(merged, included)
+	}
+
+	return mergeMaps(merged, root), nil
+}
+
+// parseIncludes reads the include directive, which is either a single path or a list of
+// entries that are each a path or a map with a path and an optional flag
+func parseIncludes(value interface{}) ([]configInclude, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []configInclude{{path: value}}, nil
+	case []interface{}:
+		includes := []configInclude{}
+
+		for _, entry := range value {
+			switch entry := entry.(type) {
+			case string:
+				includes = append(includes, configInclude{path: entry})
+			case map[string]interface{}:
+				path, ok := entry["path"].(string)
+				if !ok {
+					return nil, fmt.Errorf("%s entries must have a path", includeKey)
+				}
+
+				optional, _ := entry["optional"].(bool)
+				includes = append(includes, configInclude{path: path, optional: optional})
+			default:
+				return nil, fmt.Errorf("%s entries must be paths", includeKey)
+			}
+		}
+
+		return includes, nil
+	}
+
+	return nil, fmt.Errorf("%s must be a path or a list of paths", includeKey)
+}
+
+// resolvePath expands the home directory in the path and makes it relative to dir
+func resolvePath(path, dir string) (string, error) {
+	expanded, err := expandHomeDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !filepath.IsAbs(expanded) {
+		expanded = filepath.Join(dir, expanded)
+	}
+
+	return expanded, nil
+}
+
+// interpolate replaces the ${...} expressions in every string in the value. Relative
+// ${file:path} paths are relative to dir
+func interpolate(value interface{}, dir string) (interface{}, error) {
+	switch value := value.(type) {
+	case string:
+		return interpolateString(value, dir)
+	case map[string]interface{}:
+		for key, val := range value {
+			interpolated, err := interpolate(val, dir)
+			if err != nil {
+				return nil, err
+			}
+
+			value[key] = interpolated
+		}
+	case []interface{}:
+		for idx, val := range value {
+			interpolated, err := interpolate(val, dir)
+			if err != nil {
+				return nil, err
+			}
+
+			value[idx] = interpolated
+		}
+	}
+
+	return value, nil
+}
+
+func interpolateString(str, dir string) (string, error) {
+	var interpolateErr error
+
+	result := interpolationRegex.ReplaceAllStringFunc(str, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+
+		expr := match[2 : len(match)-1]
+
+		if strings.HasPrefix(expr, "file:") {
+			path, err := resolvePath(strings.TrimPrefix(expr, "file:"), dir)
+			if err != nil {
+				interpolateErr = err
+				return ""
+			}
+
+			contents, err := ioutil.ReadFile(path)
+			if err != nil {
+				interpolateErr = err
+				return ""
+			}
+
+			return strings.TrimRight(string(contents), "\r\n")
+		}
+
+		name, defaultValue := expr, ""
+		if idx := strings.Index(expr, ":-"); idx >= 0 {
+			name, defaultValue = expr[:idx], expr[idx+2:]
+		}
+
+		if envValue, ok := os.LookupEnv(name); ok && envValue != "" {
+			return envValue
+		}
+
+		return defaultValue
+	})
+
+	return result, interpolateErr
+}
+
+// mergeMaps returns base with override merged on top of it. Maps are merged recursively,
+// lists in keys ending in "+" are appended to the base list, and everything else in
+// override replaces what is in base
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, val := range base {
+		merged[key] = val
+	}
+
+	for key, val := range override {
+		if strings.HasSuffix(key, appendSuffix) {
+			listKey := strings.TrimSuffix(key, appendSuffix)
+
+			baseList, _ := merged[listKey].([]interface{})
+			if overrideList, ok := val.([]interface{}); ok {
+				merged[listKey] = append(append([]interface{}{}, baseList...), overrideList...)
+				continue
+			}
+
+			merged[listKey] = append(append([]interface{}{}, baseList...), val)
+			continue
+		}
+
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := val.(map[string]interface{})
+
+		if overrideIsMap {
+			// Merging into an empty map resolves any appends in the override
+			if !baseIsMap {
+				baseMap = map[string]interface{}{}
+			}
+
+			merged[key] = mergeMaps(baseMap, overrideMap)
+			continue
+		}
+
+		merged[key] = val
+	}
+
+	return merged
+}
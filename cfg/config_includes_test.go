@@ -0,0 +1,219 @@
+package cfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "wtf-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func Test_loadConfigWithIncludes(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": `
+include:
+  - shared/team.yml
+  - path: missing.yml
+    optional: true
+wtf:
+  refreshInterval: 5
+  mods:
+    github:
+      repositories+: ["me/mine"]
+`,
+		"shared/team.yml": `
+include: base.yml
+wtf:
+  mods:
+    github:
+      enabled: true
+      repositories: ["team/api", "team/web"]
+`,
+		"shared/base.yml": `
+wtf:
+  refreshInterval: 1
+  grid:
+    columns: [40, 40]
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	config, files, err := loadConfigWithIncludes(filepath.Join(dir, "config.yml"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "config.yml"),
+		filepath.Join(dir, "shared/team.yml"),
+		filepath.Join(dir, "shared/base.yml"),
+	}, files)
+
+	assert.Equal(t, 5, config.UInt("wtf.refreshInterval"))
+	assert.Equal(t, 2, len(config.UList("wtf.grid.columns")))
+	assert.True(t, config.UBool("wtf.mods.github.enabled"))
+	assert.Equal(t, []interface{}{"team/api", "team/web", "me/mine"}, config.UList("wtf.mods.github.repositories"))
+
+	_, err = config.Get("include")
+	assert.Error(t, err)
+}
+
+func Test_loadConfigWithIncludes_errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name: "with an include cycle",
+			files: map[string]string{
+				"config.yml": "include: other.yml\n",
+				"other.yml":  "include: config.yml\n",
+			},
+		},
+		{
+			name: "with a missing include",
+			files: map[string]string{
+				"config.yml": "include: missing.yml\n",
+			},
+		},
+		{
+			name: "with an invalid include",
+			files: map[string]string{
+				"config.yml": "include:\n  enabled: true\n",
+			},
+		},
+		{
+			name: "with a missing interpolated file",
+			files: map[string]string{
+				"config.yml": "wtf:\n  apiKey: \"${file:missing}\"\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigFiles(t, tt.files)
+			defer os.RemoveAll(dir)
+
+			_, _, err := loadConfigWithIncludes(filepath.Join(dir, "config.yml"))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_interpolateString(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"secret": "hunter2\n",
+	})
+	defer os.RemoveAll(dir)
+
+	os.Setenv("WTF_TEST_TEAM", "platform")
+	defer os.Unsetenv("WTF_TEST_TEAM")
+
+	tests := []struct {
+		name     string
+		str      string
+		expected string
+	}{
+		{
+			name:     "with no interpolation",
+			str:      "plain",
+			expected: "plain",
+		},
+		{
+			name:     "with an environment variable",
+			str:      "team-${WTF_TEST_TEAM}",
+			expected: "team-platform",
+		},
+		{
+			name:     "with an unset environment variable",
+			str:      "team-${WTF_TEST_UNSET}",
+			expected: "team-",
+		},
+		{
+			name:     "with a default",
+			str:      "${WTF_TEST_UNSET:-infra}",
+			expected: "infra",
+		},
+		{
+			name:     "with a default for a set variable",
+			str:      "${WTF_TEST_TEAM:-infra}",
+			expected: "platform",
+		},
+		{
+			name:     "with a file",
+			str:      "${file:secret}",
+			expected: "hunter2",
+		},
+		{
+			name:     "with an escape",
+			str:      "$${WTF_TEST_TEAM}",
+			expected: "${WTF_TEST_TEAM}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := interpolateString(tt.str, dir)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_mergeMaps(t *testing.T) {
+	base := map[string]interface{}{
+		"list":  []interface{}{"a"},
+		"other": "base",
+		"nested": map[string]interface{}{
+			"keep":     true,
+			"override": 1,
+		},
+	}
+
+	override := map[string]interface{}{
+		"list+": []interface{}{"b", "c"},
+		"nested": map[string]interface{}{
+			"override": 2,
+		},
+		"new": map[string]interface{}{
+			"items+": "x",
+		},
+	}
+
+	expected := map[string]interface{}{
+		"list":  []interface{}{"a", "b", "c"},
+		"other": "base",
+		"nested": map[string]interface{}{
+			"keep":     true,
+			"override": 2,
+		},
+		"new": map[string]interface{}{
+			"items": []interface{}{"x"},
+		},
+	}
+
+	assert.Equal(t, expected, mergeMaps(base, override))
+	assert.Equal(t, []interface{}{"a"}, base["list"])
+}
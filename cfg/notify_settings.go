@@ -0,0 +1,57 @@
+package cfg
+
+import (
+	"github.com/olebedev/config"
+)
+
+const (
+	notifyPath = "notify"
+
+	// defaultNotifySink is the sink notifications are sent to when none are configured
+	defaultNotifySink = "bell"
+)
+
+// NotifySettings defines which of the events a module publishes are sent as notifications,
+// and where they are sent. Modules send no notifications unless events are listed:
+//
+//    wtf:
+//      notify:
+//        sinks: ["osc777", "command"]
+//        command: "notify-send \"$WTF_NOTIFY_TITLE\" \"$WTF_NOTIFY_MESSAGE\""
+//      mods:
+//        pagerduty:
+//          notify:
+//            events: ["incidentTriggered"]
+//            sinks: ["bell"]
+//
+// The sinks and command default to those under wtf.notify
+type NotifySettings struct {
+	Command string   `help:"The shell command run by the command sink. The event is passed to it in the WTF_NOTIFY_EVENT, WTF_NOTIFY_MODULE, WTF_NOTIFY_TITLE, and WTF_NOTIFY_MESSAGE environment variables." optional:"true"`
+	Events  []string `help:"The events that are sent as notifications." values:"The event names the module publishes, i.e.: incidentTriggered, buildFailed, monitorDown." optional:"true"`
+	Sinks   []string `help:"Where notifications are sent." values:"bell, osc9, osc777, command" optional:"true" default:"bell"`
+}
+
+// NewNotifySettingsFromYAML creates and returns the notify settings of a module
+func NewNotifySettingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) NotifySettings {
+	globalSinks := globalConfig.UList("wtf.notify.sinks", []interface{}{defaultNotifySink})
+
+	return NotifySettings{
+		Command: moduleConfig.UString(notifyPath+".command", globalConfig.UString("wtf.notify.command")),
+		Events:  toStrs(moduleConfig.UList(notifyPath + ".events")),
+		Sinks:   toStrs(moduleConfig.UList(notifyPath+".sinks", globalSinks)),
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func toStrs(list []interface{}) []string {
+	strs := []string{}
+
+	for _, item := range list {
+		if str, ok := item.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+
+	return strs
+}
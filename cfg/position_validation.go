@@ -49,6 +49,11 @@ func (posVal *positionValidation) IntValue() int {
 	return posVal.intVal
 }
 
+// Name returns the name of the position setting being validated, i.e.: "top"
+func (posVal *positionValidation) Name() string {
+	return posVal.name
+}
+
 // String returns the Stringer representation of the positionValidation
 func (posVal *positionValidation) String() string {
 	return fmt.Sprintf("Invalid value for %s:\t%d", aurora.Yellow(posVal.name), posVal.intVal)
@@ -0,0 +1,189 @@
+package cfg
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/olebedev/config"
+)
+
+// SettingsSchema describes the config keys that a module's Settings struct is read from.
+// Each field is read from the key of the same name, with the first letter lowercased,
+// unless the field has a yaml tag naming its key. Fields tagged yaml:"-" are not read
+// from the module's config
+type SettingsSchema struct {
+	// Keys maps each config key to the type of the field it is read into
+	Keys map[string]reflect.Type
+
+	// Open is TRUE if the settings keep a raw config block, in which case they
+	// accept keys that are not listed in Keys
+	Open bool
+}
+
+// directKeys are the keys that widgets read straight from their module's config, through
+// Common.Config, rather than from the module's settings. Any module may set them
+type directKeys struct {
+	// view.BarGraph reads how its bars are drawn
+	GraphIcon  string
+	GraphStars int
+
+	// view.MultiSourceWidget reads a module's sources from a singular and a plural key
+	ApplicationID  string
+	ApplicationIDs []interface{}
+	Cityid         string
+	Cityids        []interface{}
+	FilePath       string
+	FilePaths      []interface{}
+	Namespace      string
+	Namespaces     []interface{}
+	Project        string
+	Projects       []interface{}
+	Repository     string
+	Repositories   []interface{}
+	ScreenName     string
+	ScreenNames    []interface{}
+	Workflow       string
+	Workflows      []interface{}
+}
+
+// NewSettingsSchema creates and returns the schema of the given Settings struct type. If the
+// type is nil only the common settings are known, and the schema accepts any other key
+func NewSettingsSchema(settingsType reflect.Type) *SettingsSchema {
+	schema := &SettingsSchema{Keys: map[string]reflect.Type{}}
+	schema.addFields(reflect.TypeOf(Common{}))
+	schema.addFields(reflect.TypeOf(directKeys{}))
+
+	if settingsType == nil {
+		schema.Open = true
+		return schema
+	}
+
+	if settingsType.Kind() == reflect.Ptr {
+		settingsType = settingsType.Elem()
+	}
+
+	if settingsType.Kind() == reflect.Struct {
+		schema.addFields(settingsType)
+	}
+
+	// Most modules still accept the old, all-lowercase, spelling of apiKey
+	if _, ok := schema.Keys["apiKey"]; ok {
+		schema.Keys["apikey"] = schema.Keys["apiKey"]
+	}
+
+	return schema
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// CheckValue returns an error if the value, as parsed from the config file, cannot be
+// read into the field the key is read into. The checks mirror the conversions done by
+// the UString, UBool, UInt, and UFloat64 config functions. Values of other types are
+// not checked
+func (schema *SettingsSchema) CheckValue(key string, value interface{}) error {
+	fieldType, ok := schema.Keys[key]
+	if !ok || value == nil {
+		return nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		switch value.(type) {
+		case bool, float64, int, string:
+			return nil
+		}
+
+		return fmt.Errorf("expected a string, got %s", describeValue(value))
+	case reflect.Bool:
+		switch val := value.(type) {
+		case bool:
+			return nil
+		case string:
+			if _, err := strconv.ParseBool(val); err == nil {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("expected true or false, got %s", describeValue(value))
+	case reflect.Int, reflect.Int64:
+		switch val := value.(type) {
+		case int:
+			return nil
+		case float64:
+			if val == float64(int(val)) {
+				return nil
+			}
+		case string:
+			if _, err := strconv.Atoi(val); err == nil {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("expected an integer, got %s", describeValue(value))
+	case reflect.Float32, reflect.Float64:
+		switch val := value.(type) {
+		case float64, int:
+			return nil
+		case string:
+			if _, err := strconv.ParseFloat(val, 64); err == nil {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("expected a number, got %s", describeValue(value))
+	}
+
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (schema *SettingsSchema) addFields(structType reflect.Type) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		// Every module's settings embed the common settings
+		if field.Name == "common" {
+			continue
+		}
+
+		key := field.Tag.Get("yaml")
+		switch key {
+		case "-":
+			continue
+		case "":
+			key = lowercaseFirst(field.Name)
+		}
+
+		if field.Type == reflect.TypeOf(&config.Config{}) {
+			schema.Open = true
+		}
+
+		schema.Keys[key] = field.Type
+	}
+}
+
+func describeValue(value interface{}) string {
+	switch val := value.(type) {
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "a map"
+	case string:
+		return strconv.Quote(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func lowercaseFirst(str string) string {
+	if str == "" {
+		return ""
+	}
+
+	r, n := utf8.DecodeRuneInString(str)
+	return string(unicode.ToLower(r)) + str[n:]
+}
@@ -0,0 +1,135 @@
+package cfg
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSettings struct {
+	common *Common
+
+	apiKey   string
+	count    int
+	todoPath string `yaml:"filename"`
+	internal string `yaml:"-"`
+	ratio    float64
+	verbose  bool
+}
+
+type testOpenSettings struct {
+	common *Common
+
+	backend *config.Config
+}
+
+func Test_NewSettingsSchema(t *testing.T) {
+	schema := NewSettingsSchema(reflect.TypeOf(&testSettings{}))
+
+	assert.False(t, schema.Open)
+
+	for _, key := range []string{"apiKey", "apikey", "count", "filename", "ratio", "verbose", "border", "enabled", "focusChar", "position", "refreshInterval", "title", "type", "colors", "graphIcon", "repositories"} {
+		assert.Contains(t, schema.Keys, key)
+	}
+
+	for _, key := range []string{"common", "todoPath", "internal", "bordered", "sigils", "config"} {
+		assert.NotContains(t, schema.Keys, key)
+	}
+
+	assert.True(t, NewSettingsSchema(reflect.TypeOf(&testOpenSettings{})).Open)
+	assert.True(t, NewSettingsSchema(nil).Open)
+}
+
+func Test_CheckValue(t *testing.T) {
+	schema := NewSettingsSchema(reflect.TypeOf(&testSettings{}))
+
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		expected string
+	}{
+		{name: "string", key: "apiKey", value: "cats"},
+		{name: "number as string", key: "apiKey", value: 12},
+		{name: "list as string", key: "apiKey", value: []interface{}{"cats"}, expected: "expected a string, got a list"},
+		{name: "int", key: "count", value: 3},
+		{name: "int as string", key: "count", value: "3"},
+		{name: "whole float as int", key: "count", value: 3.0},
+		{name: "float as int", key: "count", value: 3.5, expected: "expected an integer, got 3.5"},
+		{name: "word as int", key: "count", value: "three", expected: `expected an integer, got "three"`},
+		{name: "bool", key: "verbose", value: true},
+		{name: "bool as string", key: "verbose", value: "false"},
+		{name: "word as bool", key: "verbose", value: "sometimes", expected: `expected true or false, got "sometimes"`},
+		{name: "float", key: "ratio", value: 0.5},
+		{name: "map as float", key: "ratio", value: map[string]interface{}{}, expected: "expected a number, got a map"},
+		{name: "empty", key: "count", value: nil},
+		{name: "unknown key", key: "cats", value: "dogs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.CheckValue(tt.key, tt.value)
+
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+// Every key a multi-source widget reads must be known to the schema, or the validator
+// rejects the module's sources
+func Test_NewSettingsSchema_MultiSourceKeys(t *testing.T) {
+	schema := NewSettingsSchema(nil)
+	calls := 0
+
+	err := filepath.Walk(filepath.Join("..", "modules"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || selector.Sel.Name != "NewMultiSourceWidget" {
+				return true
+			}
+
+			calls++
+
+			for _, arg := range call.Args[1:] {
+				if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					key, _ := strconv.Unquote(lit.Value)
+					assert.Contains(t, schema.Keys, key, path)
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NotZero(t, calls)
+}
@@ -6,4 +6,5 @@ type Validatable interface {
 	HasError() bool
 	String() string
 	IntValue() int
+	Name() string
 }
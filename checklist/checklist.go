@@ -1,5 +1,9 @@
 package checklist
 
+import (
+	"sort"
+)
+
 // Checklist is a module for creating generic checklist implementations
 // See 'Todo' for an implementation example
 type Checklist struct {
@@ -23,14 +27,16 @@ func NewChecklist(checkedIcon, uncheckedIcon string) Checklist {
 /* -------------------- Exported Functions -------------------- */
 
 // Add creates a new checklist item and prepends it onto the existing
-// list of items. The new one is at the start of the list
+// list of items. The new one is at the start of the list. A due date and a
+// priority in the text are set on the item, as with ChecklistItem.SetText
 func (list *Checklist) Add(checked bool, text string) {
 	item := NewChecklistItem(
 		checked,
-		text,
+		"",
 		list.checkedIcon,
 		list.uncheckedIcon,
 	)
+	item.SetText(text)
 
 	list.Items = append([]*ChecklistItem{item}, list.Items...)
 }
@@ -55,6 +61,20 @@ func (list *Checklist) Delete(selectedIndex int) {
 	}
 }
 
+// Filter returns a checklist of the items that are tagged with the tag, in the same
+// order. The items are shared with this checklist. An empty tag matches every item
+func (list *Checklist) Filter(tag string) Checklist {
+	filtered := NewChecklist(list.checkedIcon, list.uncheckedIcon)
+
+	for _, item := range list.Items {
+		if tag == "" || item.HasTag(tag) {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+
+	return filtered
+}
+
 // IsSelectable returns true if the checklist has selectable items, false if it does not
 func (list *Checklist) IsSelectable() bool {
 	return list.selected >= 0 && list.selected < len(list.Items)
@@ -88,6 +108,54 @@ func (list *Checklist) IndexByItem(selectableItem *ChecklistItem) (index int, ok
 	return 0, false
 }
 
+// SortByDue orders the items by due date, soonest first. Items without a due date go
+// last, and items due on the same day keep their order
+func (list *Checklist) SortByDue() {
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		iDue, iOk := list.Items[i].DueDate()
+		jDue, jOk := list.Items[j].DueDate()
+
+		if iOk != jOk {
+			return iOk
+		}
+
+		return iOk && iDue.Before(jDue)
+	})
+}
+
+// SortByPriority orders the items by priority, highest first. Items without a priority
+// go last, and items of the same priority keep their order
+func (list *Checklist) SortByPriority() {
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		iPriority, jPriority := list.Items[i].Priority, list.Items[j].Priority
+
+		if (iPriority > 0) != (jPriority > 0) {
+			return iPriority > 0
+		}
+
+		return iPriority < jPriority
+	})
+}
+
+// Tags returns the tags of all the items, sorted and without duplicates
+func (list *Checklist) Tags() []string {
+	tags := []string{}
+	seen := map[string]bool{}
+
+	for _, item := range list.Items {
+		for _, tag := range item.AllTags() {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
 // UncheckedItems returns a slice of all the unchecked items
 func (list *Checklist) UncheckedItems() []*ChecklistItem {
 	items := []*ChecklistItem{}
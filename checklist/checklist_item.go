@@ -1,10 +1,37 @@
 package checklist
 
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DueDateFormat is the format due dates are written in, both in item text and in the
+// stored Due field
+const DueDateFormat = "2006-01-02"
+
+var (
+	// hashtagPattern matches the #tags in an item's text
+	hashtagPattern = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_-]+)`)
+
+	// priorityPattern matches a priority in an item's text, from !1, the highest, to !9
+	priorityPattern = regexp.MustCompile(`^!([1-9])$`)
+)
+
 // ChecklistItem is a module for creating generic checklist implementations
 // See 'Todo' for an implementation example
+//
+// Due, Priority and Tags are optional, and are left out of the YAML when they aren't set
+// so that lists which don't use them read and write the same as before they existed
 type ChecklistItem struct {
 	Checked       bool
 	CheckedIcon   string
+	Due           string   `yaml:"due,omitempty"`
+	Priority      int      `yaml:"priority,omitempty"`
+	Tags          []string `yaml:"tags,omitempty"`
 	Text          string
 	UncheckedIcon string
 }
@@ -20,6 +47,33 @@ func NewChecklistItem(checked bool, text string, checkedIcon, uncheckedIcon stri
 	return item
 }
 
+// AllTags returns the item's tags, from its Tags field and the #tags in its text, in
+// lowercase, sorted and without duplicates
+func (item *ChecklistItem) AllTags() []string {
+	tags := []string{}
+	seen := map[string]bool{}
+
+	add := func(tag string) {
+		tag = normalizeTag(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range item.Tags {
+		add(tag)
+	}
+
+	for _, match := range hashtagPattern.FindAllStringSubmatch(item.Text, -1) {
+		add(match[1])
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
 // CheckMark returns the string used to indicate a ChecklistItem is checked or unchecked
 func (item *ChecklistItem) CheckMark() string {
 	item.ensureItemIcons()
@@ -31,6 +85,75 @@ func (item *ChecklistItem) CheckMark() string {
 	return item.UncheckedIcon
 }
 
+// DueDate returns the date the item is due, and false if it has no due date
+func (item *ChecklistItem) DueDate() (time.Time, bool) {
+	if item.Due == "" {
+		return time.Time{}, false
+	}
+
+	due, err := time.ParseInLocation(DueDateFormat, item.Due, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return due, true
+}
+
+// EditableText returns the item's text with its due date and priority written back into
+// it, i.e.: "Pay rent !1 due:2020-08-01", so that they can be edited along with the text
+// and read back by SetText
+func (item *ChecklistItem) EditableText() string {
+	text := item.Text
+
+	if item.Priority > 0 {
+		text += fmt.Sprintf(" !%d", item.Priority)
+	}
+
+	if item.Due != "" {
+		text += " due:" + item.Due
+	}
+
+	return strings.TrimSpace(text)
+}
+
+// HasTag returns true if the item is tagged with the tag, either in its text or in its
+// Tags field. Tags are matched without regard to case or a leading #
+func (item *ChecklistItem) HasTag(tag string) bool {
+	tag = normalizeTag(tag)
+
+	for _, itemTag := range item.AllTags() {
+		if itemTag == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsOverdue returns true if the item isn't checked and its due date was before the day
+// that now falls on
+func (item *ChecklistItem) IsOverdue(now time.Time) bool {
+	if item.Checked {
+		return false
+	}
+
+	due, ok := item.DueDate()
+	if !ok {
+		return false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	return due.Before(today)
+}
+
+// SetText sets the item's text. A due date, as "due:2020-08-01", "due:today" or
+// "due:tomorrow", and a priority, as "!1" to "!9", are taken out of the text and set as
+// the item's Due and Priority. Text without them clears them. #tags are left in the text
+func (item *ChecklistItem) SetText(text string) {
+	item.Text, item.Due, item.Priority = parseText(text, time.Now())
+}
+
 // Toggle changes the checked state of the ChecklistItem
 // If checked, it is unchecked. If unchecked, it is checked
 func (item *ChecklistItem) Toggle() {
@@ -48,3 +171,54 @@ func (item *ChecklistItem) ensureItemIcons() {
 		item.UncheckedIcon = " "
 	}
 }
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// parseText splits the due date and the priority out of an item's text. Words that look
+// like them but aren't valid, i.e.: "due:someday", are left in the text
+func parseText(text string, now time.Time) (string, string, int) {
+	due := ""
+	priority := 0
+	words := []string{}
+
+	for _, word := range strings.Fields(text) {
+		if strings.HasPrefix(strings.ToLower(word), "due:") {
+			if date, ok := parseDueDate(word[len("due:"):], now); ok {
+				due = date
+				continue
+			}
+		}
+
+		if match := priorityPattern.FindStringSubmatch(word); match != nil {
+			priority, _ = strconv.Atoi(match[1])
+			continue
+		}
+
+		words = append(words, word)
+	}
+
+	if due == "" && priority == 0 {
+		return strings.TrimSpace(text), "", 0
+	}
+
+	return strings.Join(words, " "), due, priority
+}
+
+// parseDueDate returns the date a due date in an item's text refers to, in DueDateFormat
+func parseDueDate(str string, now time.Time) (string, bool) {
+	switch strings.ToLower(str) {
+	case "today":
+		return now.Format(DueDateFormat), true
+	case "tomorrow":
+		return now.AddDate(0, 0, 1).Format(DueDateFormat), true
+	}
+
+	date, err := time.Parse(DueDateFormat, str)
+	if err != nil {
+		return "", false
+	}
+
+	return date.Format(DueDateFormat), true
+}
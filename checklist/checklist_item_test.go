@@ -2,6 +2,7 @@ package checklist
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/stretchr/testify/assert"
 )
@@ -34,3 +35,101 @@ func Test_Toggle(t *testing.T) {
 	item.Toggle()
 	Equal(t, false, item.Checked)
 }
+
+func Test_parseText(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name             string
+		text             string
+		expectedText     string
+		expectedDue      string
+		expectedPriority int
+	}{
+		{
+			name:         "plain text",
+			text:         " Buy  milk #errands ",
+			expectedText: "Buy  milk #errands",
+		},
+		{
+			name:             "due date and priority",
+			text:             "Pay rent !1 due:2020-08-03 #home",
+			expectedText:     "Pay rent #home",
+			expectedDue:      "2020-08-03",
+			expectedPriority: 1,
+		},
+		{
+			name:         "due today",
+			text:         "due:today Call back",
+			expectedText: "Call back",
+			expectedDue:  "2020-08-01",
+		},
+		{
+			name:         "due tomorrow",
+			text:         "Call back DUE:Tomorrow",
+			expectedText: "Call back",
+			expectedDue:  "2020-08-02",
+		},
+		{
+			name:         "not a due date or priority",
+			text:         "Ship it due:someday !10 !",
+			expectedText: "Ship it due:someday !10 !",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, due, priority := parseText(tt.text, now)
+
+			Equal(t, tt.expectedText, text)
+			Equal(t, tt.expectedDue, due)
+			Equal(t, tt.expectedPriority, priority)
+		})
+	}
+}
+
+func Test_SetText(t *testing.T) {
+	item := testChecklistItem()
+
+	item.SetText("Pay rent !2 due:2020-08-03")
+	Equal(t, "Pay rent", item.Text)
+	Equal(t, "2020-08-03", item.Due)
+	Equal(t, 2, item.Priority)
+	Equal(t, "Pay rent !2 due:2020-08-03", item.EditableText())
+
+	item.SetText("Pay rent")
+	Equal(t, "", item.Due)
+	Equal(t, 0, item.Priority)
+	Equal(t, "Pay rent", item.EditableText())
+}
+
+func Test_Tags(t *testing.T) {
+	item := testChecklistItem()
+	item.Text = "Fix the #Build on #ci-2, not issue#4"
+	item.Tags = []string{"work", "#ci-2"}
+
+	Equal(t, []string{"build", "ci-2", "work"}, item.AllTags())
+	True(t, item.HasTag("#work"))
+	True(t, item.HasTag("BUILD"))
+	False(t, item.HasTag("4"))
+}
+
+func Test_IsOverdue(t *testing.T) {
+	now := time.Date(2020, 8, 1, 23, 59, 0, 0, time.Local)
+	item := testChecklistItem()
+
+	False(t, item.IsOverdue(now))
+
+	item.Due = "2020-08-01"
+	False(t, item.IsOverdue(now))
+
+	item.Due = "2020-07-31"
+	True(t, item.IsOverdue(now))
+
+	item.Toggle()
+	False(t, item.IsOverdue(now))
+
+	item.Toggle()
+	item.Due = "not a date"
+	False(t, item.IsOverdue(now))
+}
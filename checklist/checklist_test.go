@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 )
 
 func Test_NewCheckist(t *testing.T) {
@@ -434,3 +435,97 @@ func Test_Swap(t *testing.T) {
 		})
 	}
 }
+
+func Test_Filter(t *testing.T) {
+	cl := NewChecklist("o", "-")
+	cl.Add(false, "one #work")
+	cl.Add(false, "two")
+	cl.Add(false, "three #Work #home")
+
+	assert.Equal(t, 2, len(cl.Filter("work").Items))
+	assert.Equal(t, "three #Work #home", cl.Filter("#work").Items[0].Text)
+	assert.Equal(t, 3, len(cl.Filter("").Items))
+	assert.Equal(t, 0, len(cl.Filter("missing").Items))
+
+	// The filtered items are the same items
+	cl.Filter("home").Items[0].Toggle()
+	assert.Equal(t, true, cl.Items[0].Checked)
+}
+
+func Test_SortByDue(t *testing.T) {
+	cl := NewChecklist("o", "-")
+	cl.Items = []*ChecklistItem{
+		{Text: "none"},
+		{Text: "later", Due: "2020-09-01"},
+		{Text: "sooner", Due: "2020-08-01"},
+		{Text: "also later", Due: "2020-09-01"},
+	}
+
+	cl.SortByDue()
+
+	assert.Equal(t, []string{"sooner", "later", "also later", "none"}, itemTexts(cl))
+}
+
+func Test_SortByPriority(t *testing.T) {
+	cl := NewChecklist("o", "-")
+	cl.Items = []*ChecklistItem{
+		{Text: "none"},
+		{Text: "low", Priority: 3},
+		{Text: "high", Priority: 1},
+		{Text: "also low", Priority: 3},
+	}
+
+	cl.SortByPriority()
+
+	assert.Equal(t, []string{"high", "low", "also low", "none"}, itemTexts(cl))
+}
+
+func Test_ChecklistTags(t *testing.T) {
+	cl := NewChecklist("o", "-")
+	cl.Add(false, "one #work")
+	cl.Add(false, "two #home #work")
+	cl.Items[0].Tags = []string{"errands"}
+
+	assert.Equal(t, []string{"errands", "home", "work"}, cl.Tags())
+}
+
+func Test_YAMLCompatibility(t *testing.T) {
+	existing := `items:
+- checked: false
+  checkedicon: x
+  text: Buy milk
+  uncheckedicon: ' '
+- checked: true
+  checkedicon: x
+  text: Pay rent
+  uncheckedicon: ' '
+`
+
+	cl := NewChecklist("x", " ")
+	assert.NoError(t, yaml.Unmarshal([]byte(existing), &cl))
+	assert.Equal(t, 2, len(cl.Items))
+
+	// Lists that don't use due dates, priorities or tags are written as they were read
+	data, err := yaml.Marshal(&cl)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, string(data))
+
+	cl.Items[0].SetText("Buy milk !1 due:2020-08-01")
+	cl.Items[0].Tags = []string{"errands"}
+
+	data, err = yaml.Marshal(&cl)
+	assert.NoError(t, err)
+
+	reloaded := NewChecklist("x", " ")
+	assert.NoError(t, yaml.Unmarshal(data, &reloaded))
+	assert.Equal(t, cl.Items[0], reloaded.Items[0])
+}
+
+func itemTexts(cl Checklist) []string {
+	texts := []string{}
+	for _, item := range cl.Items {
+		texts = append(texts, item.Text)
+	}
+
+	return texts
+}
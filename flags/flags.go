@@ -5,10 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/chzyer/readline"
 	goFlags "github.com/jessevdk/go-flags"
 	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/app"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/help"
 )
@@ -17,8 +19,15 @@ import (
 type Flags struct {
 	Config  string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
 	Module  string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtfutil -m=todo'"`
+	Page    string `long:"page" optional:"yes" description:"Name of the page to display at startup, when wtf.pages is defined"`
 	Profile bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
 	Version bool   `short:"v" long:"version" description:"Show version info"`
+
+	Render struct {
+		Format  string        `long:"format" choice:"plain" choice:"ansi" choice:"json" default:"plain" description:"Output format of the render command"`
+		Timeout time.Duration `long:"timeout" default:"10s" description:"How long the render command waits for widgets to refresh, i.e.: '30s'"`
+	} `group:"Render Options"`
+
 	// Work-around go-flags misfeatures. If any sub-command is defined
 	// then `wtf` (no sub-commands, the common usage), is warned about.
 	Opt struct {
@@ -31,6 +40,16 @@ type Flags struct {
 
 var EXTRA = `
 Commands:
+  render [module...]
+    module       Name of a module to render. Renders all enabled modules if omitted.
+  Refresh each module once and print its content to stdout, without a
+  terminal. See the Render Options above for output formats and timeouts.
+
+  validate
+  Check the config file for unknown keys, values of the wrong type, and
+  overlapping or invalid positions. Exits with a non-zero status if any
+  errors are found.
+
   save-secret <service>
     service      Service URL or module name of secret.
   Save a secret into the secret store. The secret will be prompted for.
@@ -70,6 +89,37 @@ func (flags *Flags) RenderIf(version, date string, config *config.Config) {
 	}
 
 	switch cmd := flags.Opt.Cmd; cmd {
+	case "render":
+		opts := app.RenderOptions{
+			Format:  flags.Render.Format,
+			Modules: flags.Opt.Args,
+			Timeout: flags.Render.Timeout,
+		}
+
+		if err := app.RenderWidgets(config, os.Stdout, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "render: %v\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	case "validate":
+		errs, err := app.ValidateConfigFile(flags.Config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, configErr := range errs {
+			fmt.Fprintln(os.Stderr, configErr)
+		}
+
+		if len(errs) > 0 {
+			fmt.Fprintf(os.Stderr, "validate: found %d errors in %s\n", len(errs), flags.Config)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s is valid\n", flags.Config)
+		os.Exit(0)
 	case "save-secret":
 		var service, secret string
 		args := flags.Opt.Args
@@ -128,6 +178,11 @@ func (flags *Flags) HasModule() bool {
 	return len(flags.Module) > 0
 }
 
+// HasPage returns TRUE if a start page name was passed in, FALSE if one was not
+func (flags *Flags) HasPage() bool {
+	return len(flags.Page) > 0
+}
+
 // HasVersion returns TRUE if the version flag was passed in, FALSE if it was not
 func (flags *Flags) HasVersion() bool {
 	return flags.Version
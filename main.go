@@ -74,6 +74,14 @@ func main() {
 	// Build the application
 	tviewApp = tview.NewApplication()
 	wtfApp := app.NewWtfApp(tviewApp, config, flags.Config)
+
+	if flags.HasPage() {
+		if err := wtfApp.SwitchToPage(flags.Page); err != nil {
+			fmt.Printf("\n%s %v\n", aurora.Red("ERROR"), err)
+			os.Exit(1)
+		}
+	}
+
 	wtfApp.Start()
 
 	if err := tviewApp.Run(); err != nil {
@@ -23,7 +23,7 @@ type PipelineSettings struct {
 type Settings struct {
 	common    *cfg.Common
 	apiKey    string             `help:"Your Buildkite API Token"`
-	orgSlug   string             `help:"Organization Slug"`
+	orgSlug   string             `help:"Organization Slug" yaml:"organizationSlug"`
 	pipelines []PipelineSettings `help:"An array of pipelines to get data from"`
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/view"
 )
 
@@ -11,6 +12,7 @@ type Widget struct {
 	view.TextWidget
 	*Client
 
+	notifier *notify.Notifier
 	settings *Settings
 }
 
@@ -19,6 +21,7 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 		TextWidget: view.NewTextWidget(app, settings.common),
 		Client:     NewClient(settings.apiKey),
 
+		notifier: notify.NewNotifier(settings.common),
 		settings: settings,
 	}
 
@@ -47,6 +50,8 @@ func (widget *Widget) content() (string, string, bool) {
 		wrap = true
 		str = err.Error()
 	} else {
+		widget.notifier.Publish(widget.buildEvents(builds)...)
+
 		for idx, build := range builds {
 			if idx > 10 {
 				break
@@ -66,6 +71,33 @@ func (widget *Widget) content() (string, string, bool) {
 	return title, str, wrap
 }
 
+// buildEvents returns an event for each build that failed or fixed its branch
+func (widget *Widget) buildEvents(builds []*Build) []notify.Event {
+	events := []notify.Event{}
+
+	for _, build := range builds {
+		event := notify.Event{
+			Key:     fmt.Sprintf("%s/%d", build.Reponame, build.BuildNum),
+			Message: fmt.Sprintf("%s-%d (%s) %s", build.Reponame, build.BuildNum, build.Branch, build.AuthorName),
+		}
+
+		switch build.Status {
+		case "failed":
+			event.Type = notify.BuildFailed
+			event.Title = fmt.Sprintf("%s: build failed", widget.CommonSettings().Title)
+		case "fixed":
+			event.Type = notify.BuildFixed
+			event.Title = fmt.Sprintf("%s: build fixed", widget.CommonSettings().Title)
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
 func buildColor(build *Build) string {
 	switch build.Status {
 	case "failed":
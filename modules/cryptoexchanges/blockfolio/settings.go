@@ -20,7 +20,7 @@ type Settings struct {
 	colors
 	common *cfg.Common
 
-	deviceToken     string
+	deviceToken     string `yaml:"device_token"`
 	displayHoldings bool
 }
 
@@ -17,7 +17,7 @@ type Settings struct {
 
 	apiKey         string        `help:"Your Datadog API key."`
 	applicationKey string        `help:"Your Datadog Application key."`
-	tags           []interface{} `help:"Array of tags you want to query monitors by."`
+	tags           []interface{} `help:"Array of tags you want to query monitors by." yaml:"monitors"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
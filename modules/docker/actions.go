@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedContainer returns the highlighted container, or nil if none is
+func (widget *Widget) selectedContainer() *container {
+	idx := widget.GetSelected()
+	if idx < 0 || idx >= len(widget.containers) {
+		return nil
+	}
+
+	c := widget.containers[idx]
+	return &c
+}
+
+// confirmAction asks whether to go ahead with an action on the highlighted container, with
+// a button labeled with the action, and runs the action if it's chosen
+func (widget *Widget) confirmAction(verb, done string, action func(id string) error) {
+	c := widget.selectedContainer()
+	if c == nil || widget.cli == nil {
+		return
+	}
+
+	text := fmt.Sprintf("%s container %s?", verb, tview.Escape(c.name))
+
+	widget.ShowChoice(text, []string{verb, "Cancel"}, func(choice string) {
+		if choice != verb {
+			return
+		}
+
+		go func() {
+			widget.ReportAction(fmt.Sprintf("%s container %s", done, c.name), action(c.id), widget.RequestRefresh)
+		}()
+	})
+}
+
+func (widget *Widget) removeSelected() {
+	widget.confirmAction("Remove", "Removed", widget.removeContainer)
+}
+
+func (widget *Widget) restartSelected() {
+	widget.confirmAction("Restart", "Restarted", widget.restartContainer)
+}
+
+func (widget *Widget) startSelected() {
+	widget.confirmAction("Start", "Started", widget.startContainer)
+}
+
+func (widget *Widget) stopSelected() {
+	widget.confirmAction("Stop", "Stopped", widget.stopContainer)
+}
+
+// showLogs displays the last lines of the highlighted container's logs
+func (widget *Widget) showLogs() {
+	c := widget.selectedContainer()
+	if c == nil || widget.cli == nil {
+		return
+	}
+
+	go func() {
+		logs, err := widget.containerLogs(c.id, widget.settings.logLines)
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		logs = strings.TrimRight(logs, "\n")
+		if logs == "" {
+			logs = "No logs"
+		}
+
+		widget.ShowMessage(fmt.Sprintf(" [::b]%s[::-] logs\n\n%s", tview.Escape(c.name), tview.Escape(logs)))
+	}()
+}
@@ -3,10 +3,7 @@ package docker
 import (
 	"context"
 	"fmt"
-	"sort"
-	"strings"
 
-	"github.com/docker/docker/api/types"
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 )
@@ -106,58 +103,3 @@ func (widget *Widget) getSystemInfo() string {
 
 	return result
 }
-
-func (widget *Widget) getContainerStates() string {
-	cntrs, err := widget.cli.ContainerList(context.Background(), types.ContainerListOptions{All: true})
-	if err != nil {
-		return errors.Wrapf(err, " could not get container list").Error()
-	}
-
-	if len(cntrs) == 0 {
-		return " no containers"
-	}
-
-	colorMap := map[string]string{
-		"created":    "green",
-		"running":    "lime",
-		"paused":     "yellow",
-		"restarting": "yellow",
-		"removing":   "yellow",
-		"exited":     "red",
-		"dead":       "red",
-	}
-
-	containers := []struct {
-		name  string
-		state string
-	}{}
-	for _, c := range cntrs {
-		container := struct {
-			name  string
-			state string
-		}{
-			name:  c.Names[0],
-			state: c.State,
-		}
-
-		container.name = strings.Replace(container.name, "/", "", -1)
-		containers = append(containers, container)
-	}
-
-	sort.Slice(containers, func(i, j int) bool {
-		return containers[i].name < containers[j].name
-	})
-
-	padSlice(false, containers, func(i int) string {
-		return containers[i].name
-	}, func(i int, val string) {
-		containers[i].name = val
-	})
-
-	result := ""
-	for _, c := range containers {
-		result += fmt.Sprintf("[white]%s [%s]%s\n", c.name, colorMap[c.state], c.state)
-	}
-
-	return result
-}
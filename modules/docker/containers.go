@@ -0,0 +1,192 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+)
+
+// composeProjectLabel is the label Docker Compose sets to the name of the project that
+// created a container
+const composeProjectLabel = "com.docker.compose.project"
+
+// container is a row of the widget's container list
+type container struct {
+	id     string
+	name   string
+	state  string
+	status string
+
+	// stats are nil for containers that aren't running
+	stats *containerStats
+}
+
+// containerStats is a sample of a running container's resource usage, in percent
+type containerStats struct {
+	cpu    float64
+	memory float64
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// listContainers returns the containers that match the label filters and compose project
+// setting, sorted by name, with the resource usage of the ones that are running
+func (widget *Widget) listContainers() ([]container, error) {
+	args := filters.NewArgs()
+	for _, label := range widget.settings.labels {
+		args.Add("label", label)
+	}
+	if widget.settings.composeProject != "" {
+		args.Add("label", composeProjectLabel+"="+widget.settings.composeProject)
+	}
+
+	remote, err := widget.cli.ContainerList(context.Background(), types.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get container list")
+	}
+
+	containers := []container{}
+	for _, c := range remote {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.Replace(c.Names[0], "/", "", -1)
+		}
+
+		containers = append(containers, container{id: c.ID, name: name, state: c.State, status: c.Status})
+	}
+
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].name < containers[j].name
+	})
+
+	if widget.settings.showStats {
+		widget.loadStats(containers)
+	}
+
+	return containers, nil
+}
+
+// loadStats samples the resource usage of every running container at once, as sampling
+// takes about a second per container. Containers that can't be sampled get no stats
+func (widget *Widget) loadStats(containers []container) {
+	var wg sync.WaitGroup
+
+	for idx := range containers {
+		if containers[idx].state != "running" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(c *container) {
+			defer wg.Done()
+
+			stats, err := widget.containerStats(c.id)
+			if err == nil {
+				c.stats = stats
+			}
+		}(&containers[idx])
+	}
+
+	wg.Wait()
+}
+
+func (widget *Widget) containerStats(id string) (*containerStats, error) {
+	resp, err := widget.cli.ContainerStats(context.Background(), id, false)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	remote := types.StatsJSON{}
+	if err := json.NewDecoder(resp.Body).Decode(&remote); err != nil {
+		return nil, err
+	}
+
+	return &containerStats{cpu: cpuPercent(remote), memory: memoryPercent(remote)}, nil
+}
+
+// containerLogs returns the last lines of a container's output. Containers without a TTY
+// interleave stdout and stderr in a single stream that has to be split back apart
+func (widget *Widget) containerLogs(id string, lines int) (string, error) {
+	inspect, err := widget.cli.ContainerInspect(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := widget.cli.ContainerLogs(context.Background(), id, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	})
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = reader.Close() }()
+
+	var logs bytes.Buffer
+	if inspect.Config != nil && inspect.Config.Tty {
+		_, err = logs.ReadFrom(reader)
+	} else {
+		_, err = stdcopy.StdCopy(&logs, &logs, reader)
+	}
+
+	return logs.String(), err
+}
+
+func (widget *Widget) startContainer(id string) error {
+	return widget.cli.ContainerStart(context.Background(), id, types.ContainerStartOptions{})
+}
+
+func (widget *Widget) stopContainer(id string) error {
+	return widget.cli.ContainerStop(context.Background(), id, nil)
+}
+
+func (widget *Widget) restartContainer(id string) error {
+	return widget.cli.ContainerRestart(context.Background(), id, nil)
+}
+
+func (widget *Widget) removeContainer(id string) error {
+	return widget.cli.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{})
+}
+
+// cpuPercent returns how much of the host's CPU a container used between the two
+// samples of the stats, the way docker stats calculates it
+func cpuPercent(stats types.StatsJSON) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	cpus := float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	if cpus == 0 {
+		cpus = 1
+	}
+
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// memoryPercent returns how much of its memory limit a container uses, not counting the
+// page cache, the way docker stats calculates it
+func memoryPercent(stats types.StatsJSON) float64 {
+	if stats.MemoryStats.Limit == 0 {
+		return 0
+	}
+
+	usage := stats.MemoryStats.Usage
+	if cache, ok := stats.MemoryStats.Stats["cache"]; ok && cache < usage {
+		usage -= cache
+	}
+
+	return float64(usage) / float64(stats.MemoryStats.Limit) * 100
+}
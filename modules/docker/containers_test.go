@@ -0,0 +1,140 @@
+package docker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRequest is a request received by the fake Docker daemon
+type fakeRequest struct {
+	method string
+	path   string
+	query  url.Values
+}
+
+// fakeDocker starts a fake Docker daemon that replies to each method and path with the
+// matching response, and records the requests it receives. Paths without a response get
+// a 204, which is how the daemon replies to container actions
+func fakeDocker(t *testing.T, responses map[string]func(w http.ResponseWriter)) (*httptest.Server, *[]fakeRequest) {
+	requests := []fakeRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fakeRequest{method: r.Method, path: r.URL.Path, query: r.URL.Query()})
+
+		respond, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		respond(w)
+	}))
+
+	return server, &requests
+}
+
+func jsonResponse(body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func testWidget(t *testing.T, server *httptest.Server, settings *Settings) *Widget {
+	cli, err := client.NewClient("tcp://"+server.Listener.Addr().String(), "1.25", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Widget{cli: cli, settings: settings}
+}
+
+func Test_listContainers(t *testing.T) {
+	server, requests := fakeDocker(t, map[string]func(w http.ResponseWriter){
+		"GET /v1.25/containers/json": jsonResponse(`[
+			{"Id": "bbb", "Names": ["/web"], "State": "running"},
+			{"Id": "aaa", "Names": ["/db"], "State": "exited"}
+		]`),
+		"GET /v1.25/containers/bbb/stats": jsonResponse(`{
+			"cpu_stats": {"cpu_usage": {"total_usage": 300, "percpu_usage": [1, 2]}, "system_cpu_usage": 2000},
+			"precpu_stats": {"cpu_usage": {"total_usage": 100}, "system_cpu_usage": 1000},
+			"memory_stats": {"usage": 300, "limit": 1000, "stats": {"cache": 100}}
+		}`),
+	})
+	defer server.Close()
+
+	widget := testWidget(t, server, &Settings{
+		composeProject: "shop",
+		labels:         []string{"tier=frontend"},
+		showStats:      true,
+	})
+
+	containers, err := widget.listContainers()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []container{
+		{id: "aaa", name: "db", state: "exited"},
+		{id: "bbb", name: "web", state: "running", stats: &containerStats{cpu: 40, memory: 20}},
+	}, containers)
+
+	filters := (*requests)[0].query.Get("filters")
+	assert.Contains(t, filters, `"com.docker.compose.project=shop":true`)
+	assert.Contains(t, filters, `"tier=frontend":true`)
+}
+
+func Test_containerActions(t *testing.T) {
+	server, requests := fakeDocker(t, map[string]func(w http.ResponseWriter){})
+	defer server.Close()
+
+	widget := testWidget(t, server, &Settings{})
+
+	assert.NoError(t, widget.startContainer("aaa"))
+	assert.NoError(t, widget.stopContainer("aaa"))
+	assert.NoError(t, widget.restartContainer("aaa"))
+	assert.NoError(t, widget.removeContainer("aaa"))
+
+	paths := []string{}
+	for _, req := range *requests {
+		paths = append(paths, req.method+" "+req.path)
+	}
+
+	assert.Equal(t, []string{
+		"POST /v1.25/containers/aaa/start",
+		"POST /v1.25/containers/aaa/stop",
+		"POST /v1.25/containers/aaa/restart",
+		"DELETE /v1.25/containers/aaa",
+	}, paths)
+}
+
+func Test_containerLogs(t *testing.T) {
+	server, requests := fakeDocker(t, map[string]func(w http.ResponseWriter){
+		"GET /v1.25/containers/aaa/json": jsonResponse(`{"Id": "aaa", "Config": {"Tty": false}}`),
+		"GET /v1.25/containers/aaa/logs": func(w http.ResponseWriter) {
+			_, _ = stdcopy.NewStdWriter(w, stdcopy.Stdout).Write([]byte("listening\n"))
+			_, _ = stdcopy.NewStdWriter(w, stdcopy.Stderr).Write([]byte("oops\n"))
+		},
+	})
+	defer server.Close()
+
+	logs, err := testWidget(t, server, &Settings{}).containerLogs("aaa", 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "listening\noops\n", logs)
+	assert.Equal(t, "20", (*requests)[1].query.Get("tail"))
+}
+
+func Test_memoryPercent(t *testing.T) {
+	stats := types.StatsJSON{}
+	assert.Equal(t, 0.0, memoryPercent(stats))
+
+	stats.MemoryStats.Usage = 500
+	stats.MemoryStats.Limit = 1000
+	assert.Equal(t, 50.0, memoryPercent(stats))
+}
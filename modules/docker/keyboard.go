@@ -0,0 +1,19 @@
+package docker
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeCommonControls(widget.Refresh)
+
+	widget.SetKeyboardChar("j", widget.Next, "Select next container")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous container")
+	widget.SetKeyboardChar("s", widget.startSelected, "Start the selected container")
+	widget.SetKeyboardChar("x", widget.stopSelected, "Stop the selected container")
+	widget.SetKeyboardChar("R", widget.restartSelected, "Restart the selected container")
+	widget.SetKeyboardChar("d", widget.removeSelected, "Remove the selected container")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next container")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous container")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.showLogs, "Show the selected container's logs")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
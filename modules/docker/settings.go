@@ -3,10 +3,11 @@ package docker
 import (
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
 )
 
 const (
-	defaultFocusable = false
+	defaultFocusable = true
 	defaultTitle     = "docker"
 )
 
@@ -14,6 +15,13 @@ const (
 type Settings struct {
 	common     *cfg.Common
 	labelColor string
+
+	composeProject string   `help:"Only show the containers of this Docker Compose project." optional:"true"`
+	graphIcon      string   `help:"The character used to draw the stats bars." optional:"true"`
+	graphStars     int      `help:"The width of the stats bars, in characters." optional:"true"`
+	labels         []string `help:"Only show the containers that have all of these labels, each either a key or key=value." optional:"true"`
+	logLines       int      `help:"The number of lines of a container's logs to show." optional:"true"`
+	showStats      bool     `help:"Whether or not to show the CPU and memory use of running containers." values:"true or false" optional:"true"`
 }
 
 // NewSettingsFromYAML creates and returns an instance of Settings with configuration options populated
@@ -21,6 +29,13 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common:     cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 		labelColor: ymlConfig.UString("labelColor", "white"),
+
+		composeProject: ymlConfig.UString("composeProject"),
+		graphIcon:      ymlConfig.UString("graphIcon", "|"),
+		graphStars:     ymlConfig.UInt("graphStars", 20),
+		labels:         utils.ToStrs(ymlConfig.UList("labels")),
+		logLines:       ymlConfig.UInt("logLines", 50),
+		showStats:      ymlConfig.UBool("showStats", true),
 	}
 
 	return &settings
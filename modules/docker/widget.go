@@ -6,61 +6,169 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
 
+// stateColors are the colors each container state is displayed in
+var stateColors = map[string]string{
+	"created":    "green",
+	"running":    "lime",
+	"paused":     "yellow",
+	"restarting": "yellow",
+	"removing":   "yellow",
+	"exited":     "red",
+	"dead":       "red",
+}
+
 type Widget struct {
-	view.TextWidget
-	cli           *client.Client
-	settings      *Settings
-	displayBuffer string
+	view.KeyboardWidget
+	view.ScrollableWidget
+
+	cli        *client.Client
+	containers []container
+	err        error
+	settings   *Settings
+	systemInfo string
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		TextWidget: view.NewTextWidget(app, settings.common),
-		settings:   settings,
+		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
+
+		settings: settings,
 	}
 
-	widget.View.SetScrollable(true)
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
 
+	widget.KeyboardWidget.SetView(widget.View)
+
+	// The client connects to the daemon at DOCKER_HOST, with the TLS settings of
+	// DOCKER_TLS_VERIFY and DOCKER_CERT_PATH, or to the local daemon if it isn't set
 	cli, err := client.NewEnvClient()
 	if err != nil {
-		widget.displayBuffer = errors.Wrap(err, "could not create client").Error()
+		widget.err = errors.Wrap(err, "could not create client")
 	} else {
 		widget.cli = cli
 	}
 
-	widget.refreshDisplayBuffer()
-
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
-	widget.refreshDisplayBuffer()
+	if widget.cli == nil {
+		widget.Render()
+		return
+	}
+
+	widget.systemInfo = widget.getSystemInfo()
+
+	containers, err := widget.listContainers()
+	widget.err = err
+	widget.containers = containers
+
+	widget.Render()
+}
+
+func (widget *Widget) Render() {
+	widget.SetItemCount(len(widget.containers))
 	widget.Redraw(widget.display)
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() (string, string, bool) {
-	return widget.CommonSettings().Title, widget.displayBuffer, true
-}
+	title := widget.CommonSettings().Title
 
-func (widget *Widget) refreshDisplayBuffer() {
 	if widget.cli == nil {
-		return
+		return title, widget.err.Error(), true
 	}
 
-	widget.displayBuffer = ""
+	str := fmt.Sprintf("[%s] System[white]\n", widget.settings.common.Colors.Subheading)
+	str += widget.systemInfo
+	str += "\n"
 
-	widget.displayBuffer += fmt.Sprintf("[%s] System[white]\n", widget.settings.common.Colors.Subheading)
-	widget.displayBuffer += widget.getSystemInfo()
+	str += fmt.Sprintf("[%s] Containers[white]\n", widget.settings.common.Colors.Subheading)
+	str += widget.containersContent()
+
+	if widget.settings.showStats {
+		if stats := widget.statsContent(); stats != "" {
+			str += "\n"
+			str += fmt.Sprintf("[%s] Stats[white]\n", widget.settings.common.Colors.Subheading)
+			str += stats
+		}
+	}
 
-	widget.displayBuffer += "\n"
+	return title, str, false
+}
+
+func (widget *Widget) containersContent() string {
+	if widget.err != nil {
+		return " " + widget.err.Error() + "\n"
+	}
+
+	if len(widget.containers) == 0 {
+		return " no containers\n"
+	}
+
+	names := []string{}
+	for _, c := range widget.containers {
+		names = append(names, c.name)
+	}
+
+	padSlice(false, names, func(i int) string {
+		return names[i]
+	}, func(i int, val string) {
+		names[i] = val
+	})
+
+	str := ""
+	for idx, c := range widget.containers {
+		row := fmt.Sprintf("[%s]%s [%s]%s", widget.RowColor(idx), tview.Escape(names[idx]), stateColors[c.state], c.state)
+		str += utils.HighlightableHelper(widget.View, row, idx, len(names[idx])+len(c.state)+1)
+	}
+
+	return str
+}
+
+// statsContent draws a bar for the CPU use and one for the memory use of each running
+// container. It's empty if no container is running
+func (widget *Widget) statsContent() string {
+	bars := []view.Bar{}
+
+	for _, c := range widget.containers {
+		if c.stats == nil {
+			continue
+		}
+
+		bars = append(bars,
+			view.Bar{
+				Label:      c.name + " cpu",
+				Percent:    int(c.stats.cpu),
+				ValueLabel: fmt.Sprintf("%.1f%%", c.stats.cpu),
+				LabelColor: widget.settings.labelColor,
+			},
+			view.Bar{
+				Label:      c.name + " mem",
+				Percent:    int(c.stats.memory),
+				ValueLabel: fmt.Sprintf("%.1f%%", c.stats.memory),
+				LabelColor: widget.settings.labelColor,
+			},
+		)
+	}
+
+	if len(bars) == 0 {
+		return ""
+	}
 
-	widget.displayBuffer += fmt.Sprintf("[%s] Containers[white]\n", widget.settings.common.Colors.Subheading)
-	widget.displayBuffer += widget.getContainerStates()
+	return view.BuildStars(
+		bars,
+		widget.settings.graphStars,
+		widget.settings.graphIcon,
+	)
 }
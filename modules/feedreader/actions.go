@@ -0,0 +1,159 @@
+package feedreader
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// publishedFormat is how the reader displays when a story was published
+const publishedFormat = "2006-01-02 15:04"
+
+/* -------------------- Unexported Functions -------------------- */
+
+// storyDetail returns the text of the reader view of a story: where and when it was
+// published, and its content as plain text
+func storyDetail(story *FeedItem) string {
+	item := story.item
+
+	str := fmt.Sprintf(" [::b]%s[::-]\n\n", tview.Escape(strings.TrimSpace(item.Title)))
+	str += fmt.Sprintf(" [yellow]Feed:[white]      %s\n", tview.Escape(feedName(story.feedTitle, story.feedURL)))
+
+	published := item.Published
+	if item.PublishedParsed != nil {
+		published = item.PublishedParsed.Local().Format(publishedFormat)
+	}
+	if published != "" {
+		str += fmt.Sprintf(" [yellow]Published:[white] %s\n", tview.Escape(published))
+	}
+
+	if item.Author != nil && item.Author.Name != "" {
+		str += fmt.Sprintf(" [yellow]Author:[white]    %s\n", tview.Escape(item.Author.Name))
+	}
+	if item.Link != "" {
+		str += fmt.Sprintf(" [yellow]Link:[white]      %s\n", tview.Escape(item.Link))
+	}
+
+	text := articleText(item)
+	if text == "" {
+		text = "[gray]No content[white]"
+	} else {
+		text = tview.Escape(text)
+	}
+
+	return str + fmt.Sprintf("\n%s\n", text)
+}
+
+// selectedStory returns the highlighted story, or nil if there is none
+func (widget *Widget) selectedStory() *FeedItem {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.stories) {
+		return nil
+	}
+
+	return widget.stories[sel]
+}
+
+// setRead marks the stories as read or unread, and remembers that
+func (widget *Widget) setRead(read bool, stories ...*FeedItem) {
+	ids := []string{}
+
+	widget.mutex.Lock()
+	for _, story := range stories {
+		story.viewed = read
+		ids = append(ids, storyID(story.item, story.feedURL))
+	}
+	widget.mutex.Unlock()
+
+	if widget.readIDs.setRead(read, ids...) {
+		widget.saveReadState()
+	}
+
+	widget.Render()
+}
+
+// exportFeeds prompts for a path and writes the feeds to it as OPML
+func (widget *Widget) exportFeeds() {
+	widget.ShowInput("Export the feeds as OPML to", func(path string) {
+		go func() {
+			feedURLs, err := widget.feedURLs()
+			if err == nil {
+				err = writeOPML(path, widget.CommonSettings().Title, widget.opmlFeeds(feedURLs))
+			}
+
+			if err != nil {
+				widget.ShowMessage(fmt.Sprintf(" [red]%s[white]", tview.Escape(err.Error())))
+				return
+			}
+
+			widget.ShowMessage(fmt.Sprintf(" Exported %d feeds to %s", len(feedURLs), tview.Escape(path)))
+		}()
+	})
+}
+
+// markAllRead marks every story as read
+func (widget *Widget) markAllRead() {
+	widget.setRead(true, widget.currentStories()...)
+}
+
+// opmlFeeds returns the feeds to export, with the titles and sites of those that have
+// been fetched
+func (widget *Widget) opmlFeeds(feedURLs []string) []opmlFeed {
+	feeds := []opmlFeed{}
+
+	for _, feedURL := range feedURLs {
+		feed := opmlFeed{url: feedURL}
+
+		if fetched := widget.fetcher.lastFeed(feedURL); fetched != nil {
+			feed.title = fetched.Title
+			feed.siteURL = fetched.Link
+		}
+
+		feeds = append(feeds, feed)
+	}
+
+	return feeds
+}
+
+func (widget *Widget) openStory() {
+	story := widget.selectedStory()
+	if story == nil {
+		return
+	}
+
+	widget.setRead(true, story)
+
+	utils.OpenFile(story.item.Link)
+}
+
+// showStory displays the highlighted story in the reader, and marks it as read
+func (widget *Widget) showStory() {
+	story := widget.selectedStory()
+	if story == nil {
+		return
+	}
+
+	widget.setRead(true, story)
+
+	widget.ShowMessage(storyDetail(story))
+}
+
+// toggleRead marks the highlighted story as unread if it has been read, and as read if
+// it hasn't
+func (widget *Widget) toggleRead() {
+	story := widget.selectedStory()
+	if story == nil {
+		return
+	}
+
+	widget.mutex.Lock()
+	read := !story.viewed
+	widget.mutex.Unlock()
+
+	widget.setRead(read, story)
+}
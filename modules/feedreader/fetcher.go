@@ -0,0 +1,144 @@
+package feedreader
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// maxConcurrentFetches is the most feeds that are fetched at the same time
+const maxConcurrentFetches = 8
+
+// fetchTimeout is how long fetching a single feed may take
+const fetchTimeout = 30 * time.Second
+
+// fetchedFeed is the last version of a feed that was fetched, with the validators the
+// server sent with it
+type fetchedFeed struct {
+	etag         string
+	feed         *gofeed.Feed
+	lastModified string
+}
+
+// fetchResult is the outcome of fetching one feed
+type fetchResult struct {
+	url  string
+	feed *gofeed.Feed
+	err  error
+}
+
+// feedFetcher fetches feeds with conditional GETs: it sends back the ETag and
+// Last-Modified validators of the last version of a feed it fetched, and reuses that
+// version when the server answers that the feed hasn't changed
+type feedFetcher struct {
+	client *http.Client
+	feeds  map[string]*fetchedFeed
+	mutex  *sync.Mutex
+}
+
+func newFeedFetcher() *feedFetcher {
+	return &feedFetcher{
+		client: &http.Client{Timeout: fetchTimeout},
+		feeds:  map[string]*fetchedFeed{},
+		mutex:  &sync.Mutex{},
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// fetchAll fetches the feeds concurrently. The results are in the same order as the URLs
+func (fetcher *feedFetcher) fetchAll(feedURLs []string) []fetchResult {
+	results := make([]fetchResult, len(feedURLs))
+	slots := make(chan struct{}, maxConcurrentFetches)
+
+	var wg sync.WaitGroup
+
+	for idx, feedURL := range feedURLs {
+		wg.Add(1)
+
+		go func(idx int, feedURL string) {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			feed, err := fetcher.fetch(feedURL)
+			results[idx] = fetchResult{url: feedURL, feed: feed, err: err}
+		}(idx, feedURL)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// fetch fetches a single feed, or returns the last version of it if it hasn't changed
+func (fetcher *feedFetcher) fetch(feedURL string) (*gofeed.Feed, error) {
+	fetcher.mutex.Lock()
+	previous := fetcher.feeds[feedURL]
+	fetcher.mutex.Unlock()
+
+	req, err := http.NewRequest("GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "Gofeed/1.0")
+
+	if previous != nil {
+		if previous.etag != "" {
+			req.Header.Set("If-None-Match", previous.etag)
+		}
+		if previous.lastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.lastModified)
+		}
+	}
+
+	resp, err := fetcher.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && previous != nil {
+		return previous.feed, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	// Parsers keep the state of the feed they're parsing, so each fetch needs its own
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher.mutex.Lock()
+	fetcher.feeds[feedURL] = &fetchedFeed{
+		etag:         resp.Header.Get("ETag"),
+		feed:         feed,
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	fetcher.mutex.Unlock()
+
+	return feed, nil
+}
+
+// lastFeed returns the last version of the feed at the URL that was fetched, or nil if it
+// hasn't been fetched
+func (fetcher *feedFetcher) lastFeed(feedURL string) *gofeed.Feed {
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
+
+	if fetched, ok := fetcher.feeds[feedURL]; ok {
+		return fetched.feed
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package feedreader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Example</title>
+    <link>https://example.com/</link>
+    <item>
+      <title>First</title>
+      <link>https://example.com/first</link>
+      <guid>https://example.com/first</guid>
+      <description>&lt;p&gt;Hello &lt;b&gt;world&lt;/b&gt;&lt;/p&gt;</description>
+    </item>
+  </channel>
+</rss>
+`
+
+func Test_feedFetcher_ConditionalGet(t *testing.T) {
+	var downloads int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == "Sat, 01 Aug 2020 12:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		atomic.AddInt32(&downloads, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Sat, 01 Aug 2020 12:00:00 GMT")
+		_, _ = w.Write([]byte(rssFeed))
+	}))
+	defer server.Close()
+
+	fetcher := newFeedFetcher()
+
+	first, err := fetcher.fetch(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "Example", first.Title)
+
+	second, err := fetcher.fetch(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&downloads))
+}
+
+func Test_feedFetcher_fetchAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(rssFeed))
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/missing", server.URL + "/b"}
+	results := newFeedFetcher().fetchAll(urls)
+
+	assert.Len(t, results, 3)
+	for idx, result := range results {
+		assert.Equal(t, urls[idx], result.url)
+	}
+
+	assert.NoError(t, results[0].err)
+	assert.Equal(t, gofeed.HTTPError{StatusCode: 404, Status: "404 Not Found"}, results[1].err)
+	assert.NoError(t, results[2].err)
+	assert.Equal(t, "First", results[2].feed.Items[0].Title)
+}
@@ -7,8 +7,12 @@ func (widget *Widget) initializeKeyboardControls() {
 
 	widget.SetKeyboardChar("j", widget.Next, "Select next item")
 	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
+	widget.SetKeyboardChar("m", widget.toggleRead, "Mark story as read/unread")
+	widget.SetKeyboardChar("M", widget.markAllRead, "Mark all stories as read")
 	widget.SetKeyboardChar("o", widget.openStory, "Open story in browser")
 	widget.SetKeyboardChar("t", widget.toggleDisplayText, "Toggle display between title, link and title+content")
+	widget.SetKeyboardChar("v", widget.showStory, "View story in the reader")
+	widget.SetKeyboardChar("x", widget.exportFeeds, "Export feeds as OPML")
 
 	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
 	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
@@ -0,0 +1,123 @@
+package feedreader
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"time"
+
+	"github.com/wtfutil/wtf/utils"
+)
+
+// opmlDocument is an OPML file, the format feed readers import and export their
+// subscriptions in. Outlines can be nested into folders
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Title   string        `xml:"head>title"`
+	Created string        `xml:"head>dateCreated,omitempty"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+type opmlOutline struct {
+	Type     string        `xml:"type,attr,omitempty"`
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlFeed is a feed to export: its URL, and its title and site if they are known
+type opmlFeed struct {
+	title   string
+	url     string
+	siteURL string
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// readOPML returns the URLs of the feeds in the OPML file at path
+func readOPML(path string) ([]string, error) {
+	path, err := utils.ExpandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseOPML(data)
+}
+
+// parseOPML returns the URLs of the feeds in an OPML document, including those in folders,
+// once each
+func parseOPML(data []byte) ([]string, error) {
+	doc := opmlDocument{}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	feedURLs := []string{}
+
+	var walk func(outlines []opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, outline := range outlines {
+			if outline.XMLURL != "" && utils.DoesNotInclude(feedURLs, outline.XMLURL) {
+				feedURLs = append(feedURLs, outline.XMLURL)
+			}
+
+			walk(outline.Outlines)
+		}
+	}
+	walk(doc.Body)
+
+	return feedURLs, nil
+}
+
+// buildOPML returns an OPML document that lists the feeds
+func buildOPML(title string, feeds []opmlFeed, now time.Time) ([]byte, error) {
+	doc := opmlDocument{
+		Version: "2.0",
+		Title:   title,
+		Created: now.Format(time.RFC1123Z),
+	}
+
+	for _, feed := range feeds {
+		text := feed.title
+		if text == "" {
+			text = feed.url
+		}
+
+		doc.Body = append(doc.Body, opmlOutline{
+			Type:    "rss",
+			Text:    text,
+			Title:   feed.title,
+			XMLURL:  feed.url,
+			HTMLURL: feed.siteURL,
+		})
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// writeOPML writes an OPML document that lists the feeds to the file at path
+func writeOPML(path, title string, feeds []opmlFeed) error {
+	path, err := utils.ExpandHomeDir(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := buildOPML(title, feeds, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,63 @@
+package feedreader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const opmlExport = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="1.0">
+  <head>
+    <title>Subscriptions</title>
+  </head>
+  <body>
+    <outline text="Hacker News" type="rss" xmlUrl="https://news.ycombinator.com/rss" htmlUrl="https://news.ycombinator.com/"/>
+    <outline text="Go" title="Go">
+      <outline text="The Go Blog" type="rss" xmlUrl="https://blog.golang.org/feed.atom"/>
+      <outline text="Hacker News again" type="rss" xmlUrl="https://news.ycombinator.com/rss"/>
+    </outline>
+    <outline text="Empty folder"/>
+  </body>
+</opml>
+`
+
+func Test_parseOPML(t *testing.T) {
+	feedURLs, err := parseOPML([]byte(opmlExport))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://news.ycombinator.com/rss", "https://blog.golang.org/feed.atom"}, feedURLs)
+
+	_, err = parseOPML([]byte("not opml"))
+	assert.Error(t, err)
+}
+
+func Test_buildOPML(t *testing.T) {
+	feeds := []opmlFeed{
+		{title: "Hacker News", url: "https://news.ycombinator.com/rss", siteURL: "https://news.ycombinator.com/"},
+		{url: "https://example.com/feed?a=1&b=2"},
+	}
+
+	data, err := buildOPML("Feed Reader", feeds, time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Feed Reader</title>
+    <dateCreated>Sat, 01 Aug 2020 12:00:00 +0000</dateCreated>
+  </head>
+  <body>
+    <outline type="rss" text="Hacker News" title="Hacker News" xmlUrl="https://news.ycombinator.com/rss" htmlUrl="https://news.ycombinator.com/"></outline>
+    <outline type="rss" text="https://example.com/feed?a=1&amp;b=2" xmlUrl="https://example.com/feed?a=1&amp;b=2"></outline>
+  </body>
+</opml>
+`
+	assert.Equal(t, expected, string(data))
+
+	// What is exported can be imported again
+	feedURLs, err := parseOPML(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://news.ycombinator.com/rss", "https://example.com/feed?a=1&b=2"}, feedURLs)
+}
@@ -17,6 +17,7 @@ type Settings struct {
 
 	feeds     []string `help:"An array of RSS and Atom feed URLs"`
 	feedLimit int      `help:"The maximum number of stories to display for each feed"`
+	opml      string   `help:"The path to an OPML file to read more feeds from, i.e.: one exported from another feed reader" optional:"true"`
 }
 
 // NewSettingsFromYAML creates a new settings instance from a YAML config block
@@ -26,6 +27,7 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 
 		feeds:     utils.ToStrs(ymlConfig.UList("feeds")),
 		feedLimit: ymlConfig.UInt("feedLimit", -1),
+		opml:      ymlConfig.UString("opml"),
 	}
 
 	return settings
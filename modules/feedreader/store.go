@@ -0,0 +1,133 @@
+package feedreader
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/wtfutil/wtf/utils"
+)
+
+// storeDirName is the directory, inside the config directory, that the read stores are
+// written to
+const storeDirName = "feedreader"
+
+// readRetention is how long a read story is remembered after it was last seen in a feed.
+// Stories that have dropped out of their feed for longer than that are forgotten
+const readRetention = 30 * 24 * time.Hour
+
+// unsafeFileChars matches the characters in a module name that are replaced when the name
+// is used as a file name
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// readStore remembers which stories have been read. It maps each read story's ID to when
+// the story was last seen in a feed, and is written to disk as JSON so that the read state
+// survives restarts. A store without a path only remembers for as long as WTF runs
+type readStore struct {
+	path  string
+	read  map[string]time.Time
+	mutex *sync.Mutex
+}
+
+// newReadStore loads the store at path. A missing or unreadable file is an empty store
+func newReadStore(path string) *readStore {
+	store := &readStore{
+		path:  path,
+		read:  map[string]time.Time{},
+		mutex: &sync.Mutex{},
+	}
+
+	if path == "" {
+		return store
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return store
+	}
+
+	if err := json.Unmarshal(data, &store.read); err != nil {
+		store.read = map[string]time.Time{}
+	}
+
+	return store
+}
+
+// readStorePath returns the path of the read store of the module with the given name
+func readStorePath(configDir, name string) string {
+	return filepath.Join(configDir, storeDirName, unsafeFileChars.ReplaceAllString(name, "_")+".json")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// isRead returns TRUE if the story with the ID has been read
+func (store *readStore) isRead(id string) bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	_, ok := store.read[id]
+	return ok
+}
+
+// setRead marks the stories with the IDs as read or unread. It returns TRUE if that
+// changed any of them
+func (store *readStore) setRead(read bool, ids ...string) bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	changed := false
+
+	for _, id := range ids {
+		_, wasRead := store.read[id]
+
+		switch {
+		case read && !wasRead:
+			store.read[id] = time.Now()
+			changed = true
+		case !read && wasRead:
+			delete(store.read, id)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// seen records that the read stories with the IDs are still in their feeds, and forgets
+// the read stories that haven't been seen for longer than readRetention
+func (store *readStore) seen(ids []string, now time.Time) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, id := range ids {
+		if _, ok := store.read[id]; ok {
+			store.read[id] = now
+		}
+	}
+
+	for id, lastSeen := range store.read {
+		if now.Sub(lastSeen) > readRetention {
+			delete(store.read, id)
+		}
+	}
+}
+
+// save writes the store to disk
+func (store *readStore) save() error {
+	if store.path == "" {
+		return nil
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	contents, err := json.Marshal(store.read)
+	if err != nil {
+		return err
+	}
+
+	return utils.WriteFileAtomic(store.path, contents)
+}
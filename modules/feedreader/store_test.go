@@ -0,0 +1,64 @@
+package feedreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-feedreader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := readStorePath(dir, "feed reader/1")
+	assert.Equal(t, filepath.Join(dir, "feedreader", "feed_reader_1.json"), path)
+
+	store := newReadStore(path)
+	assert.False(t, store.isRead("a"))
+
+	assert.True(t, store.setRead(true, "a", "b"))
+	assert.False(t, store.setRead(true, "a"))
+	assert.True(t, store.setRead(false, "b"))
+	assert.NoError(t, store.save())
+
+	reloaded := newReadStore(path)
+	assert.True(t, reloaded.isRead("a"))
+	assert.False(t, reloaded.isRead("b"))
+}
+
+func Test_readStore_seen(t *testing.T) {
+	store := newReadStore("")
+	store.setRead(true, "kept", "dropped", "old")
+
+	now := time.Now()
+	store.read["dropped"] = now.Add(-readRetention - time.Hour)
+	store.read["old"] = now.Add(-readRetention - time.Hour)
+
+	// "old" is still in its feed, so it is remembered however long ago it was read
+	store.seen([]string{"kept", "old", "unread"}, now)
+
+	assert.True(t, store.isRead("kept"))
+	assert.True(t, store.isRead("old"))
+	assert.False(t, store.isRead("dropped"))
+	assert.False(t, store.isRead("unread"))
+}
+
+func Test_newReadStore_Corrupt(t *testing.T) {
+	file, err := ioutil.TempFile("", "wtf-feedreader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, _ = file.WriteString("{not json")
+	_ = file.Close()
+
+	assert.False(t, newReadStore(file.Name()).isRead("a"))
+}
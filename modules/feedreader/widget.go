@@ -2,11 +2,17 @@ package feedreader
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mmcdole/gofeed"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cache"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 	"jaytaylor.com/html2text"
@@ -20,10 +26,23 @@ const (
 	SHOW_CONTENT
 )
 
+// storiesCacheKey is the key the feed items are cached under
+const storiesCacheKey = "feedStories"
+
 // FeedItem represents an item returned from an RSS or Atom feed
 type FeedItem struct {
-	item   *gofeed.Item
-	viewed bool
+	item      *gofeed.Item
+	feedTitle string
+	feedURL   string
+	viewed    bool
+}
+
+// cachedStory is a feed item as it is cached on disk. Whether it has been read is kept
+// in the read store instead
+type cachedStory struct {
+	FeedTitle string       `json:"feedTitle"`
+	FeedURL   string       `json:"feedURL"`
+	Item      *gofeed.Item `json:"item"`
 }
 
 // Widget is the container for RSS and Atom data
@@ -31,8 +50,11 @@ type Widget struct {
 	view.KeyboardWidget
 	view.ScrollableWidget
 
+	cache    *cache.Cache
+	fetcher  *feedFetcher
+	mutex    *sync.Mutex
+	readIDs  *readStore
 	stories  []*FeedItem
-	parser   *gofeed.Parser
 	settings *Settings
 	err      error
 	showType ShowType
@@ -57,23 +79,71 @@ func getShowText(feedItem *FeedItem, showType ShowType) string {
 	case SHOW_LINK:
 		returnValue = feedItem.item.Link
 	case SHOW_CONTENT:
-		text, _ := html2text.FromString(feedItem.item.Content, html2text.Options{PrettyTables: true})
-		returnValue = strings.TrimSpace(feedItem.item.Title + "\n" + strings.TrimSpace(text))
+		returnValue = strings.TrimSpace(feedItem.item.Title + "\n" + articleText(feedItem.item))
 	}
 	return returnValue
 }
 
+// articleText returns the content of a story as plain text, or its summary if the feed
+// doesn't include the content
+func articleText(item *gofeed.Item) string {
+	html := item.Content
+	if strings.TrimSpace(html) == "" {
+		html = item.Description
+	}
+
+	text, err := html2text.FromString(html, html2text.Options{PrettyTables: true})
+	if err != nil {
+		return strings.TrimSpace(html)
+	}
+
+	return strings.TrimSpace(text)
+}
+
+// storyID returns the ID that a story's read state is stored under: its GUID, or failing
+// that its link, or failing that its title within its feed
+func storyID(item *gofeed.Item, feedURL string) string {
+	switch {
+	case item.GUID != "":
+		return item.GUID
+	case item.Link != "":
+		return item.Link
+	default:
+		return feedURL + "#" + item.Title
+	}
+}
+
+// feedName returns the name to display for a feed: its title, or the host it is on
+func feedName(feedTitle, feedURL string) string {
+	if feedTitle != "" {
+		return feedTitle
+	}
+
+	if parsed, err := url.Parse(feedURL); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+
+	return feedURL
+}
+
 // NewWidget creates a new instance of a widget
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := &Widget{
 		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
 		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
 
-		parser:   gofeed.NewParser(),
+		cache:    cache.NewCache(settings.common),
+		fetcher:  newFeedFetcher(),
+		mutex:    &sync.Mutex{},
+		readIDs:  newReadStore(""),
 		settings: settings,
 		showType: SHOW_TITLE,
 	}
 
+	if configDir, err := cfg.WtfConfigDir(); err == nil {
+		widget.readIDs = newReadStore(readStorePath(configDir, settings.common.Name))
+	}
+
 	widget.SetRenderFunction(widget.Render)
 	widget.initializeKeyboardControls()
 	widget.View.SetInputCapture(widget.InputCapture)
@@ -85,27 +155,53 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 
 /* -------------------- Exported Functions -------------------- */
 
-// Fetch retrieves RSS and Atom feed data
+// Fetch retrieves RSS and Atom feed data. The feeds are fetched concurrently, and a feed
+// that hasn't changed since it was last fetched isn't downloaded again
 func (widget *Widget) Fetch(feedURLs []string) ([]*FeedItem, error) {
 	data := []*FeedItem{}
 
-	for _, feedURL := range feedURLs {
-		feedItems, err := widget.fetchForFeed(feedURL)
-		if err != nil {
-			return nil, err
+	for _, result := range widget.fetcher.fetchAll(feedURLs) {
+		if result.err != nil {
+			return nil, result.err
 		}
 
-		data = append(data, feedItems...)
+		data = append(data, widget.feedItems(result.url, result.feed)...)
 	}
 
 	data = widget.sort(data)
 
+	ids := []string{}
+	for _, feedItem := range data {
+		ids = append(ids, storyID(feedItem.item, feedItem.feedURL))
+	}
+	widget.readIDs.seen(ids, time.Now())
+	widget.saveReadState()
+
 	return data, nil
 }
 
 // Refresh updates the data in the widget
 func (widget *Widget) Refresh() {
-	feedItems, err := widget.Fetch(widget.settings.feeds)
+	if widget.currentStories() == nil && widget.loadCachedStories() {
+		// Display the cached stories while the feeds are fetched
+		widget.Render()
+	}
+
+	feedURLs, err := widget.feedURLs()
+
+	var feedItems []*FeedItem
+	if err == nil {
+		feedItems, err = widget.Fetch(feedURLs)
+	}
+
+	if err != nil && widget.loadCachedStories() {
+		// Keep displaying the last stories fetched until the feeds can be fetched again
+		widget.SetRefreshError(err)
+		widget.Render()
+		return
+	}
+
+	widget.mutex.Lock()
 	if err != nil {
 		widget.err = err
 		widget.stories = nil
@@ -115,7 +211,13 @@ func (widget *Widget) Refresh() {
 		widget.stories = feedItems
 		widget.SetItemCount(len(feedItems))
 	}
+	widget.mutex.Unlock()
+
+	if err == nil {
+		widget.storeCachedStories()
+	}
 
+	widget.SetRefreshError(err)
 	widget.Render()
 }
 
@@ -126,12 +228,35 @@ func (widget *Widget) Render() {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) fetchForFeed(feedURL string) ([]*FeedItem, error) {
-	feed, err := widget.parser.ParseURL(feedURL)
+// feedURLs returns the URLs of the feeds in the config and in the OPML file, once each
+func (widget *Widget) feedURLs() ([]string, error) {
+	feedURLs := []string{}
+
+	for _, feedURL := range widget.settings.feeds {
+		if utils.DoesNotInclude(feedURLs, feedURL) {
+			feedURLs = append(feedURLs, feedURL)
+		}
+	}
+
+	if widget.settings.opml == "" {
+		return feedURLs, nil
+	}
+
+	opmlURLs, err := readOPML(widget.settings.opml)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not read %s: %s", widget.settings.opml, err)
 	}
 
+	for _, feedURL := range opmlURLs {
+		if utils.DoesNotInclude(feedURLs, feedURL) {
+			feedURLs = append(feedURLs, feedURL)
+		}
+	}
+
+	return feedURLs, nil
+}
+
+func (widget *Widget) feedItems(feedURL string, feed *gofeed.Feed) []*FeedItem {
 	feedItems := []*FeedItem{}
 
 	for idx, gofeedItem := range feed.Items {
@@ -142,18 +267,119 @@ func (widget *Widget) fetchForFeed(feedURL string) ([]*FeedItem, error) {
 		}
 
 		feedItem := &FeedItem{
-			item:   gofeedItem,
-			viewed: false,
+			item:      gofeedItem,
+			feedTitle: feed.Title,
+			feedURL:   feedURL,
+			viewed:    widget.readIDs.isRead(storyID(gofeedItem, feedURL)),
 		}
 
 		feedItems = append(feedItems, feedItem)
 	}
 
-	return feedItems, nil
+	return feedItems
+}
+
+// currentStories returns the stories that are displayed
+func (widget *Widget) currentStories() []*FeedItem {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	return widget.stories
+}
+
+// loadCachedStories loads the stories fetched by the last successful refresh, if they are
+// cached, marked as stale. It returns TRUE if there were cached stories to load
+func (widget *Widget) loadCachedStories() bool {
+	stories := []cachedStory{}
+
+	storedAt, ok := widget.cache.Load(storiesCacheKey, &stories)
+	if !ok {
+		return false
+	}
+
+	feedItems := []*FeedItem{}
+	for _, story := range stories {
+		if story.Item == nil {
+			continue
+		}
+
+		feedItems = append(feedItems, &FeedItem{
+			item:      story.Item,
+			feedTitle: story.FeedTitle,
+			feedURL:   story.FeedURL,
+			viewed:    widget.readIDs.isRead(storyID(story.Item, story.FeedURL)),
+		})
+	}
+
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	widget.err = nil
+	widget.stories = feedItems
+	widget.SetItemCount(len(feedItems))
+	widget.SetStaleSince(storedAt)
+
+	return true
+}
+
+func (widget *Widget) storeCachedStories() {
+	stories := []cachedStory{}
+	for _, feedItem := range widget.currentStories() {
+		stories = append(stories, cachedStory{
+			FeedTitle: feedItem.feedTitle,
+			FeedURL:   feedItem.feedURL,
+			Item:      feedItem.item,
+		})
+	}
+
+	widget.cache.Store(storiesCacheKey, stories)
+}
+
+// saveReadState writes the read store to disk. Failures are logged, as the read state is
+// still kept for as long as WTF runs
+func (widget *Widget) saveReadState() {
+	if err := widget.readIDs.save(); err != nil {
+		logger.Log(fmt.Sprintf("Storing the %s read state failed: %s", widget.CommonSettings().Name, err.Error()))
+	}
+}
+
+// unreadCounts returns the number of unread stories in each feed, as i.e.: "Hacker News 3",
+// in the order the feeds first appear in the stories
+func unreadCounts(stories []*FeedItem) []string {
+	feedURLs := []string{}
+	names := map[string]string{}
+	counts := map[string]int{}
+
+	for _, story := range stories {
+		if _, ok := names[story.feedURL]; !ok {
+			feedURLs = append(feedURLs, story.feedURL)
+			names[story.feedURL] = feedName(story.feedTitle, story.feedURL)
+		}
+
+		if !story.viewed {
+			counts[story.feedURL]++
+		}
+	}
+
+	unread := []string{}
+	for _, feedURL := range feedURLs {
+		if counts[feedURL] > 0 {
+			unread = append(unread, fmt.Sprintf("%s %d", names[feedURL], counts[feedURL]))
+		}
+	}
+
+	return unread
 }
 
 func (widget *Widget) content() (string, string, bool) {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
 	title := widget.CommonSettings().Title
+	if unread := unreadCounts(widget.stories); len(unread) > 0 {
+		title = fmt.Sprintf("%s (%s)", title, tview.Escape(strings.Join(unread, ", ")))
+	}
+
 	if widget.err != nil {
 		return title, widget.err.Error(), true
 	}
@@ -200,17 +426,6 @@ func (widget *Widget) sort(feedItems []*FeedItem) []*FeedItem {
 	return feedItems
 }
 
-func (widget *Widget) openStory() {
-	sel := widget.GetSelected()
-
-	if sel >= 0 && widget.stories != nil && sel < len(widget.stories) {
-		story := widget.stories[sel]
-		story.viewed = true
-
-		utils.OpenFile(story.item.Link)
-	}
-}
-
 func (widget *Widget) toggleDisplayText() {
 	widget.showType = rotateShowType(widget.showType)
 	widget.Render()
@@ -0,0 +1,59 @@
+package feedreader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_storyID(t *testing.T) {
+	assert.Equal(t, "guid-1", storyID(&gofeed.Item{GUID: "guid-1", Link: "https://example.com/1"}, "https://example.com/feed"))
+	assert.Equal(t, "https://example.com/1", storyID(&gofeed.Item{Link: "https://example.com/1"}, "https://example.com/feed"))
+	assert.Equal(t, "https://example.com/feed#First", storyID(&gofeed.Item{Title: "First"}, "https://example.com/feed"))
+}
+
+func Test_unreadCounts(t *testing.T) {
+	stories := []*FeedItem{
+		{item: &gofeed.Item{}, feedTitle: "Hacker News", feedURL: "https://news.ycombinator.com/rss"},
+		{item: &gofeed.Item{}, feedURL: "https://example.com/feed"},
+		{item: &gofeed.Item{}, feedTitle: "Hacker News", feedURL: "https://news.ycombinator.com/rss"},
+		{item: &gofeed.Item{}, feedTitle: "Read", feedURL: "https://read.example.com/feed", viewed: true},
+		{item: &gofeed.Item{}, feedURL: "https://example.com/feed", viewed: true},
+	}
+
+	assert.Equal(t, []string{"Hacker News 2", "example.com 1"}, unreadCounts(stories))
+	assert.Equal(t, []string{}, unreadCounts(nil))
+}
+
+func Test_storyDetail(t *testing.T) {
+	published := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+
+	story := &FeedItem{
+		item: &gofeed.Item{
+			Title:           "First [draft]",
+			Link:            "https://example.com/first",
+			Content:         "<p>Hello <b>world</b></p><ul><li>one</li></ul>",
+			PublishedParsed: &published,
+		},
+		feedTitle: "Example",
+		feedURL:   "https://example.com/feed",
+	}
+
+	expected := ` [::b]First [draft[][::-]
+
+ [yellow]Feed:[white]      Example
+ [yellow]Published:[white] 2020-08-01 12:00
+ [yellow]Link:[white]      https://example.com/first
+
+Hello *world*
+
+* one
+`
+	assert.Equal(t, expected, storyDetail(story))
+
+	story.item.Content = ""
+	story.item.Description = "Just a summary"
+	assert.Contains(t, storyDetail(story), "\nJust a summary\n")
+}
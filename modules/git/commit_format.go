@@ -0,0 +1,181 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/rivo/tview"
+)
+
+// strftimeLayouts maps the strftime directives that dateFormat is written with to the
+// equivalent Go layouts
+var strftimeLayouts = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'B': "January",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "03",
+	'm': "01",
+	'M': "04",
+	'p': "PM",
+	'S': "05",
+	'y': "06",
+	'Y': "2006",
+	'z': "-0700",
+	'Z': "MST",
+	'%': "%",
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// formatCommit formats a commit with the subset of the `git log --pretty=format:`
+// placeholders that make sense on one line:
+//
+//    %h  abbreviated hash       %H  hash
+//    %s  subject
+//    %d  ref names, like " (HEAD -> main, tag: v1.0)"
+//    %an author name            %ae author email       %ad author date
+//    %cn committer name         %ce committer email    %cd committer date
+//    %ar author date, relative  %cr committer date, relative
+//    %%  a literal %
+//
+// refNames are the names of the refs that point at the commit. Dates are formatted with
+// dateFormat, and relative dates are relative to now. Anything else is copied as is, so
+// the format can contain color tags
+func formatCommit(commit *object.Commit, refNames []string, format, dateFormat string, now time.Time) string {
+	subject := strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0]
+
+	placeholders := map[string]string{
+		"h":  commit.Hash.String()[:7],
+		"H":  commit.Hash.String(),
+		"s":  subject,
+		"d":  "",
+		"an": commit.Author.Name,
+		"ae": commit.Author.Email,
+		"ad": strftime(commit.Author.When, dateFormat),
+		"ar": relativeDate(commit.Author.When, now),
+		"cn": commit.Committer.Name,
+		"ce": commit.Committer.Email,
+		"cd": strftime(commit.Committer.When, dateFormat),
+		"cr": relativeDate(commit.Committer.When, now),
+	}
+
+	if len(refNames) > 0 {
+		placeholders["d"] = fmt.Sprintf(" (%s)", strings.Join(refNames, ", "))
+	}
+
+	var str strings.Builder
+
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] != '%' || idx == len(format)-1 {
+			str.WriteByte(format[idx])
+			continue
+		}
+
+		if format[idx+1] == '%' {
+			str.WriteByte('%')
+			idx++
+			continue
+		}
+
+		matched := false
+		for _, length := range []int{2, 1} {
+			if idx+1+length > len(format) {
+				continue
+			}
+
+			if value, ok := placeholders[format[idx+1:idx+1+length]]; ok {
+				// Commit messages and names are not color tags
+				str.WriteString(tview.Escape(value))
+				idx += length
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			str.WriteByte('%')
+		}
+	}
+
+	return str.String()
+}
+
+// strftime formats the time with a strftime format such as "%b %d, %Y". Each directive
+// is formatted on its own, so the rest of the format is never mistaken for a Go layout.
+// Directives it doesn't know are copied as is
+func strftime(when time.Time, format string) string {
+	var str strings.Builder
+
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] == '%' && idx < len(format)-1 {
+			if layout, ok := strftimeLayouts[format[idx+1]]; ok {
+				str.WriteString(when.Format(layout))
+				idx++
+				continue
+			}
+		}
+
+		str.WriteByte(format[idx])
+	}
+
+	return str.String()
+}
+
+// relativeDate describes how long before now a date was, the way git does, i.e.: "3 hours
+// ago". The unit is the largest one that the time since is at least one and a half of
+func relativeDate(when, now time.Time) string {
+	seconds := int64(now.Sub(when) / time.Second)
+	if seconds < 0 {
+		return "in the future"
+	}
+
+	plural := func(count int64, unit string) string {
+		if count == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", count, unit)
+	}
+
+	if seconds < 90 {
+		return plural(seconds, "second") + " ago"
+	}
+
+	minutes := (seconds + 30) / 60
+	if minutes < 90 {
+		return plural(minutes, "minute") + " ago"
+	}
+
+	hours := (minutes + 30) / 60
+	if hours < 36 {
+		return plural(hours, "hour") + " ago"
+	}
+
+	days := (hours + 12) / 24
+	switch {
+	case days < 14:
+		return plural(days, "day") + " ago"
+	case days < 70:
+		return plural((days+3)/7, "week") + " ago"
+	case days < 365:
+		return plural((days+15)/30, "month") + " ago"
+	}
+
+	// Under five years, the months are given along with the years
+	if days < 5*365 {
+		totalMonths := (days*12*2 + 365) / (365 * 2)
+		years, months := totalMonths/12, totalMonths%12
+
+		if months == 0 {
+			return plural(years, "year") + " ago"
+		}
+		return fmt.Sprintf("%s, %s ago", plural(years, "year"), plural(months, "month"))
+	}
+
+	return plural((days+183)/365, "year") + " ago"
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_formatCommit(t *testing.T) {
+	commit := &object.Commit{
+		Hash:    plumbing.NewHash("0123456789abcdef0123456789abcdef01234567"),
+		Message: "Fix the [widget]\n\nWith a body",
+		Author: object.Signature{
+			Name:  "Chris Cummer",
+			Email: "chris@example.com",
+			When:  time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Committer: object.Signature{
+			Name:  "Bot",
+			Email: "bot@example.com",
+			When:  time.Date(2020, 8, 2, 9, 30, 0, 0, time.UTC),
+		},
+	}
+
+	now := time.Date(2020, 8, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format:   "[forestgreen]%h [white]%s [grey]%an on %cd[white]",
+			expected: "[forestgreen]0123456 [white]Fix the [widget[] [grey]Chris Cummer on Aug 02, 2020[white]",
+		},
+		{
+			format:   "%H",
+			expected: "0123456789abcdef0123456789abcdef01234567",
+		},
+		{
+			format:   "%ae %ad %cn %ce",
+			expected: "chris@example.com Aug 01, 2020 Bot bot@example.com",
+		},
+		{
+			format:   "%h%d %ar, %cr",
+			expected: "0123456 (HEAD -> master, tag: v1.0) 24 hours ago, 3 hours ago",
+		},
+		{
+			format:   "100%% %x %",
+			expected: "100% %x %",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatCommit(commit, []string{"HEAD -> master", "tag: v1.0"}, tt.format, "%b %d, %Y", now))
+		})
+	}
+}
+
+func Test_strftime(t *testing.T) {
+	when := time.Date(2020, 8, 1, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: "%b %d, %Y", expected: "Aug 01, 2020"},
+		{format: "%Y-%m-%d %H:%M:%S", expected: "2020-08-01 15:04:05"},
+		{format: "%A %I%p", expected: "Saturday 03PM"},
+		{format: "Mon 2 Jan %% %q", expected: "Mon 2 Jan % %q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, strftime(when, tt.format))
+		})
+	}
+}
+
+func Test_relativeDate(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{ago: -time.Minute, expected: "in the future"},
+		{ago: time.Second, expected: "1 second ago"},
+		{ago: 89 * time.Second, expected: "89 seconds ago"},
+		{ago: 90 * time.Second, expected: "2 minutes ago"},
+		{ago: 2 * time.Hour, expected: "2 hours ago"},
+		{ago: 36 * time.Hour, expected: "2 days ago"},
+		{ago: 20 * 24 * time.Hour, expected: "3 weeks ago"},
+		{ago: 100 * 24 * time.Hour, expected: "3 months ago"},
+		{ago: 365 * 24 * time.Hour, expected: "1 year ago"},
+		{ago: 430 * 24 * time.Hour, expected: "1 year, 2 months ago"},
+		{ago: 2000 * 24 * time.Hour, expected: "5 years ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, relativeDate(now.Add(-tt.ago), now))
+		})
+	}
+}
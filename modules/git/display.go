@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"unicode/utf8"
+
+	"github.com/rivo/tview"
 )
 
 func (widget *Widget) display() {
@@ -24,8 +26,13 @@ func (widget *Widget) content() (string, string, bool) {
 
 	_, _, width, _ := widget.View.GetRect()
 	str := widget.settings.common.SigilStr(len(widget.GitRepos), widget.Idx, width) + "\n"
-	str += fmt.Sprintf(" [%s]Branch[white]\n", widget.settings.common.Colors.Subheading)
-	str += fmt.Sprintf(" %s", repoData.Branch)
+
+	if repoData.Err != nil {
+		str += fmt.Sprintf(" [red]%s[white]\n", tview.Escape(repoData.Err.Error()))
+		return title, str, true
+	}
+
+	str += widget.formatBranch(repoData)
 	str += "\n"
 	str += widget.formatChanges(repoData.ChangedFiles)
 	str += "\n"
@@ -34,10 +41,39 @@ func (widget *Widget) content() (string, string, bool) {
 	return title, str, false
 }
 
+// formatBranch shows the branch, how far it is ahead of and behind its upstream, the tag
+// on the current commit, and the number of stashes
+func (widget *Widget) formatBranch(repoData *GitRepo) string {
+	str := fmt.Sprintf(" [%s]Branch[white]\n", widget.settings.common.Colors.Subheading)
+	str += fmt.Sprintf(" %s", repoData.Branch)
+
+	if repoData.Upstream != "" {
+		str += fmt.Sprintf(" [grey]%s[white]", repoData.Upstream)
+
+		if repoData.AheadCount > 0 {
+			str += fmt.Sprintf(" [green]↑%d[white]", repoData.AheadCount)
+		}
+		if repoData.BehindCount > 0 {
+			str += fmt.Sprintf(" [red]↓%d[white]", repoData.BehindCount)
+		}
+	}
+	str += "\n"
+
+	if repoData.Tag != "" {
+		str += fmt.Sprintf(" [grey]tag[white] %s\n", repoData.Tag)
+	}
+
+	if repoData.StashCount > 0 {
+		str += fmt.Sprintf(" [grey]stashes[white] %d\n", repoData.StashCount)
+	}
+
+	return str
+}
+
 func (widget *Widget) formatChanges(data []string) string {
 	str := fmt.Sprintf(" [%s]Changed Files[white]\n", widget.settings.common.Colors.Subheading)
 
-	if len(data) == 1 {
+	if len(data) == 0 {
 		str += " [grey]none[white]\n"
 	} else {
 		for _, line := range data {
@@ -53,7 +89,7 @@ func (widget *Widget) formatChange(line string) string {
 		return ""
 	}
 
-	line = strings.TrimSpace(line)
+	line = tview.Escape(strings.TrimSpace(line))
 	firstChar, _ := utf8.DecodeRuneInString(line)
 
 	// Revisit this and kill the ugly duplication
@@ -68,7 +104,7 @@ func (widget *Widget) formatChange(line string) string {
 		line = strings.Replace(line, "R", "[purple]R[white]", 1)
 	}
 
-	return fmt.Sprintf(" %s\n", strings.Replace(line, "\"", "", -1))
+	return fmt.Sprintf(" %s\n", line)
 }
 
 func (widget *Widget) formatCommits(data []string) string {
@@ -82,5 +118,5 @@ func (widget *Widget) formatCommits(data []string) string {
 }
 
 func (widget *Widget) formatCommit(line string) string {
-	return fmt.Sprintf(" %s\n", strings.Replace(line, "\"", "", -1))
+	return fmt.Sprintf(" %s\n", line)
 }
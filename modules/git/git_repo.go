@@ -1,94 +1,514 @@
 package git
 
 import (
+	"bufio"
+	"container/heap"
 	"fmt"
+	"os"
 	"os/exec"
+	"sort"
 	"strings"
+	"time"
 
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 	"github.com/wtfutil/wtf/utils"
 )
 
+// stashLog is the path, relative to the .git directory, of the reflog that holds one
+// line for each entry in the stash
+const stashLog = "logs/refs/stash"
+
+// GitRepo is the state of a local git repository, as read from disk
 type GitRepo struct {
+	AheadCount   int
+	BehindCount  int
 	Branch       string
 	ChangedFiles []string
 	Commits      []string
-	Repository   string
+	Err          error
 	Path         string
+	Repository   string
+	StashCount   int
+	Tag          string
+	Upstream     string
 }
 
+// NewGitRepo reads the repository at repoPath, or at the closest of its parent directories
+// that is a repository. If it can't be read, Err is set and the rest is left empty
 func NewGitRepo(repoPath string, commitCount int, commitFormat, dateFormat string) *GitRepo {
-	repo := GitRepo{Path: repoPath}
+	repo := GitRepo{
+		Path:       repoPath,
+		Repository: repoPath,
+	}
 
-	repo.Branch = repo.branch()
-	repo.ChangedFiles = repo.changedFiles()
-	repo.Commits = repo.commits(commitCount, commitFormat, dateFormat)
-	repo.Repository = strings.TrimSpace(repo.repository())
+	gitRepo, err := openRepository(repoPath)
+	if err != nil {
+		repo.Err = err
+		return &repo
+	}
+
+	if err := repo.load(gitRepo, commitCount, commitFormat, dateFormat); err != nil {
+		repo.Err = err
+	}
 
 	return &repo
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (repo *GitRepo) branch() string {
-	arg := []string{repo.gitDir(), repo.workTree(), "rev-parse", "--abbrev-ref", "HEAD"}
+func openRepository(repoPath string) (*gogit.Repository, error) {
+	return gogit.PlainOpenWithOptions(repoPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+}
+
+func (repo *GitRepo) load(gitRepo *gogit.Repository, commitCount int, commitFormat, dateFormat string) error {
+	worktree, err := gitRepo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	repo.Repository = worktree.Filesystem.Root()
+
+	headRef, err := gitRepo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return err
+	}
+	repo.Branch = branchName(headRef)
+
+	repo.ChangedFiles, err = changedFiles(worktree)
+	if err != nil {
+		return err
+	}
+
+	repo.StashCount = stashCount(gitRepo)
+
+	head, err := gitRepo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		// The current branch has no commits yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
 
-	cmd := exec.Command("git", arg...)
-	str := utils.ExecuteCommand(cmd)
+	repo.Commits, err = commits(gitRepo, headRef, head.Hash(), commitCount, commitFormat, dateFormat)
+	if err != nil {
+		return err
+	}
 
-	return str
+	repo.Tag, err = currentTag(gitRepo, head.Hash())
+	if err != nil {
+		return err
+	}
+
+	if head.Name().IsBranch() {
+		return repo.loadUpstream(gitRepo, head)
+	}
+
+	return nil
 }
 
-func (repo *GitRepo) changedFiles() []string {
-	arg := []string{repo.gitDir(), repo.workTree(), "status", "--porcelain"}
+// loadUpstream sets how many commits the branch is ahead of and behind the branch it
+// tracks. Branches that don't track another branch are neither ahead nor behind
+func (repo *GitRepo) loadUpstream(gitRepo *gogit.Repository, head *plumbing.Reference) error {
+	upstreamName, ok := upstream(gitRepo, head.Name().Short())
+	if !ok {
+		return nil
+	}
+
+	upstreamRef, err := gitRepo.Reference(upstreamName, true)
+	if err == plumbing.ErrReferenceNotFound {
+		// The upstream has not been fetched, or has been deleted
+		return nil
+	}
+	if err != nil {
+		return err
+	}
 
-	cmd := exec.Command("git", arg...)
-	str := utils.ExecuteCommand(cmd)
+	repo.Upstream = upstreamName.Short()
+	repo.AheadCount, repo.BehindCount, err = aheadBehind(gitRepo, head.Hash(), upstreamRef.Hash())
 
-	data := strings.Split(str, "\n")
+	return err
+}
+
+// branchName returns the name of the branch HEAD points to, or "HEAD" if it is detached
+func branchName(headRef *plumbing.Reference) string {
+	if headRef.Type() != plumbing.SymbolicReference {
+		return "HEAD"
+	}
 
-	return data
+	return headRef.Target().Short()
 }
 
-func (repo *GitRepo) commits(commitCount int, commitFormat, dateFormat string) []string {
-	dateStr := fmt.Sprintf("--date=format:\"%s\"", dateFormat)
-	numStr := fmt.Sprintf("-n %d", commitCount)
-	commitStr := fmt.Sprintf("--pretty=format:\"%s\"", commitFormat)
+// changedFiles returns the changed files in the same form as `git status --porcelain`: a
+// staging code, a worktree code, and the path
+func changedFiles(worktree *gogit.Worktree) ([]string, error) {
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, err
+	}
 
-	arg := []string{repo.gitDir(), repo.workTree(), "log", dateStr, numStr, commitStr}
+	files := []string{}
+	for path, fileStatus := range status {
+		if fileStatus.Staging == gogit.Unmodified && fileStatus.Worktree == gogit.Unmodified {
+			continue
+		}
 
-	cmd := exec.Command("git", arg...)
-	str := utils.ExecuteCommand(cmd)
+		files = append(files, fmt.Sprintf("%c%c %s", fileStatus.Staging, fileStatus.Worktree, path))
+	}
 
-	data := strings.Split(str, "\n")
+	sort.Strings(files)
 
-	return data
+	return files, nil
 }
 
-func (repo *GitRepo) repository() string {
-	arg := []string{repo.gitDir(), repo.workTree(), "rev-parse", "--show-toplevel"}
-	cmd := exec.Command("git", arg...)
-	str := utils.ExecuteCommand(cmd)
+// commits returns the most recent commits reachable from the given commit, newest first,
+// formatted with commitFormat
+func commits(gitRepo *gogit.Repository, headRef *plumbing.Reference, from plumbing.Hash, commitCount int, commitFormat, dateFormat string) ([]string, error) {
+	names, err := refNames(gitRepo, headRef, from)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := gitRepo.Log(&gogit.LogOptions{From: from, Order: gogit.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
 
-	return str
+	formatted := []string{}
+	now := time.Now()
+
+	for len(formatted) < commitCount {
+		commit, err := iter.Next()
+		if err != nil {
+			// The iterator returns io.EOF once the first commit has been reached
+			break
+		}
+
+		formatted = append(formatted, formatCommit(commit, names[commit.Hash], commitFormat, dateFormat, now))
+	}
+
+	return formatted, nil
 }
-func (repo *GitRepo) pull() string {
-	arg := []string{repo.gitDir(), repo.workTree(), "pull"}
-	cmd := exec.Command("git", arg...)
-	str := utils.ExecuteCommand(cmd)
-	return str
+
+// refNames returns the names of the refs that point at each commit, the way `git log
+// --decorate` shows them: HEAD, then the local branches, the remote branches and the tags.
+// head is the commit HEAD points to
+func refNames(gitRepo *gogit.Repository, headRef *plumbing.Reference, head plumbing.Hash) (map[plumbing.Hash][]string, error) {
+	branches := map[plumbing.Hash][]string{}
+	remotes := map[plumbing.Hash][]string{}
+	tags := map[plumbing.Hash][]string{}
+
+	iter, err := gitRepo.References()
+	if err != nil {
+		return nil, err
+	}
+
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		switch {
+		case ref.Name().IsBranch():
+			// HEAD's branch is shown as HEAD -> branch, rather than on its own
+			if headRef.Type() != plumbing.SymbolicReference || headRef.Target() != ref.Name() {
+				branches[ref.Hash()] = append(branches[ref.Hash()], ref.Name().Short())
+			}
+		case ref.Name().IsRemote():
+			remotes[ref.Hash()] = append(remotes[ref.Hash()], ref.Name().Short())
+		case ref.Name().IsTag():
+			target := ref.Hash()
+			if tag, err := gitRepo.TagObject(ref.Hash()); err == nil && tag.TargetType == plumbing.CommitObject {
+				target = tag.Target
+			}
+
+			tags[target] = append(tags[target], "tag: "+ref.Name().Short())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[plumbing.Hash][]string{}
+	names[head] = []string{"HEAD"}
+	if headRef.Type() == plumbing.SymbolicReference {
+		names[head] = []string{"HEAD -> " + headRef.Target().Short()}
+	}
+
+	for _, group := range []map[plumbing.Hash][]string{branches, remotes, tags} {
+		for hash, groupNames := range group {
+			sort.Strings(groupNames)
+			names[hash] = append(names[hash], groupNames...)
+		}
+	}
+
+	return names, nil
+}
+
+// currentTag returns the names of the tags, lightweight or annotated, that point at the
+// given commit
+func currentTag(gitRepo *gogit.Repository, commitHash plumbing.Hash) (string, error) {
+	iter, err := gitRepo.Tags()
+	if err != nil {
+		return "", err
+	}
+
+	names := []string{}
+
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Hash() == commitHash {
+			names = append(names, ref.Name().Short())
+			return nil
+		}
+
+		tag, err := gitRepo.TagObject(ref.Hash())
+		if err != nil {
+			// Lightweight tags point straight at a commit, so there's no tag object
+			return nil
+		}
+
+		if tag.TargetType == plumbing.CommitObject && tag.Target == commitHash {
+			names = append(names, ref.Name().Short())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", "), nil
+}
+
+// stashCount returns the number of entries in the stash. go-git doesn't read reflogs, so
+// this counts the lines of the stash's reflog directly
+func stashCount(gitRepo *gogit.Repository) int {
+	storage, ok := gitRepo.Storer.(*filesystem.Storage)
+	if !ok {
+		return 0
+	}
+
+	file, err := storage.Filesystem().Open(stashLog)
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = file.Close() }()
+
+	count := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) != "" {
+			count++
+		}
+	}
+
+	return count
 }
 
-func (repo *GitRepo) checkout(branch string) string {
-	arg := []string{repo.gitDir(), repo.workTree(), "checkout", branch}
-	cmd := exec.Command("git", arg...)
-	str := utils.ExecuteCommand(cmd)
-	return str
+// upstream returns the name of the remote branch that the branch tracks, as set by
+// `git branch --set-upstream-to` or `git push -u`
+func upstream(gitRepo *gogit.Repository, branch string) (plumbing.ReferenceName, bool) {
+	cfg, err := gitRepo.Config()
+	if err != nil {
+		return "", false
+	}
+
+	branchCfg, ok := cfg.Branches[branch]
+	if !ok || branchCfg.Remote == "" || branchCfg.Merge == "" {
+		return "", false
+	}
+
+	// A remote of "." means that the branch tracks another local branch
+	if branchCfg.Remote == "." {
+		return branchCfg.Merge, true
+	}
+
+	return plumbing.NewRemoteReferenceName(branchCfg.Remote, branchCfg.Merge.Short()), true
+}
+
+/* -------------------- Ahead and Behind -------------------- */
+
+const (
+	fromLocal    = 1
+	fromUpstream = 2
+	fromBoth     = fromLocal | fromUpstream
+)
+
+// commitQueue is a priority queue of commits that returns the most recently committed first
+type commitQueue []*object.Commit
+
+func (queue commitQueue) Len() int { return len(queue) }
+func (queue commitQueue) Less(i, j int) bool {
+	return queue[i].Committer.When.After(queue[j].Committer.When)
 }
+func (queue commitQueue) Swap(i, j int) { queue[i], queue[j] = queue[j], queue[i] }
 
-func (repo *GitRepo) gitDir() string {
-	return fmt.Sprintf("--git-dir=%s/.git", repo.Path)
+func (queue *commitQueue) Push(item interface{}) { *queue = append(*queue, item.(*object.Commit)) }
+func (queue *commitQueue) Pop() interface{} {
+	old := *queue
+	item := old[len(old)-1]
+	*queue = old[:len(old)-1]
+
+	return item
 }
 
-func (repo *GitRepo) workTree() string {
-	return fmt.Sprintf("--work-tree=%s", repo.Path)
+// aheadBehind returns the number of commits that are reachable from local but not from
+// upstream, and the number that are reachable from upstream but not from local.
+//
+// Like git, it walks both histories at once, newest commit first, marking each commit with
+// the side it is reachable from. It stops once every commit left to walk is reachable
+// from both, so only the commits since the two sides diverged are read
+func aheadBehind(gitRepo *gogit.Repository, local, upstream plumbing.Hash) (int, int, error) {
+	if local == upstream {
+		return 0, 0, nil
+	}
+
+	flags := map[plumbing.Hash]int{}
+	walked := map[plumbing.Hash]int{}
+	queue := &commitQueue{}
+
+	for hash, flag := range map[plumbing.Hash]int{local: fromLocal, upstream: fromUpstream} {
+		commit, err := gitRepo.CommitObject(hash)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		flags[hash] = flag
+		heap.Push(queue, commit)
+	}
+
+	for queue.Len() > 0 && !allFrom(*queue, flags, fromBoth) {
+		commit := heap.Pop(queue).(*object.Commit)
+
+		flag := flags[commit.Hash]
+		if walked[commit.Hash] == flag {
+			continue
+		}
+		walked[commit.Hash] = flag
+
+		for _, parentHash := range commit.ParentHashes {
+			if flags[parentHash]|flag == flags[parentHash] {
+				continue
+			}
+
+			parent, err := gitRepo.CommitObject(parentHash)
+			if err != nil {
+				return 0, 0, err
+			}
+
+			flags[parentHash] |= flag
+			heap.Push(queue, parent)
+		}
+	}
+
+	ahead, behind := 0, 0
+	for _, flag := range walked {
+		switch flag {
+		case fromLocal:
+			ahead++
+		case fromUpstream:
+			behind++
+		}
+	}
+
+	return ahead, behind, nil
+}
+
+func allFrom(queue commitQueue, flags map[plumbing.Hash]int, flag int) bool {
+	for _, commit := range queue {
+		if flags[commit.Hash] != flag {
+			return false
+		}
+	}
+
+	return true
+}
+
+/* -------------------- Actions -------------------- */
+
+// checkout switches the worktree to the branch. A branch that only exists on a remote is
+// created locally, tracking the remote one, the way `git checkout` does. It refuses to
+// switch if the worktree has changes that would be lost
+func (repo *GitRepo) checkout(branch string) error {
+	gitRepo, err := openRepository(repo.Path)
+	if err != nil {
+		return err
+	}
+
+	worktree, err := gitRepo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	branchRef := plumbing.NewBranchReferenceName(branch)
+
+	_, err = gitRepo.Reference(branchRef, true)
+	if err == nil {
+		return worktree.Checkout(&gogit.CheckoutOptions{Branch: branchRef})
+	}
+	if err != plumbing.ErrReferenceNotFound {
+		return err
+	}
+
+	remoteRef, err := remoteBranch(gitRepo, branch)
+	if err != nil {
+		return err
+	}
+
+	err = worktree.Checkout(&gogit.CheckoutOptions{Branch: branchRef, Hash: remoteRef.Hash(), Create: true})
+	if err != nil {
+		return err
+	}
+
+	return gitRepo.CreateBranch(&config.Branch{
+		Name:   branch,
+		Remote: strings.SplitN(remoteRef.Name().Short(), "/", 2)[0],
+		Merge:  branchRef,
+	})
+}
+
+// remoteBranch returns the reference of the remote branch with the given name. It is an
+// error for no remote, or more than one, to have the branch
+func remoteBranch(gitRepo *gogit.Repository, branch string) (*plumbing.Reference, error) {
+	remotes, err := gitRepo.Remotes()
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*plumbing.Reference{}
+
+	for _, remote := range remotes {
+		ref, err := gitRepo.Reference(plumbing.NewRemoteReferenceName(remote.Config().Name, branch), true)
+		if err == nil {
+			found = append(found, ref)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("branch %q does not exist", branch)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("branch %q exists on more than one remote", branch)
+	}
+}
+
+// pull runs `git pull`. Unlike reading, this still uses the git command: go-git can only
+// fast-forward, and doesn't use the credential helpers and SSH config that git does
+func (repo *GitRepo) pull() string {
+	cmd := exec.Command("git", "-C", repo.Repository, "pull")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	return utils.ExecuteCommand(cmd)
 }
@@ -0,0 +1,328 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var commitTime = time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+// testRepo is a repository created in a temporary directory for a test
+type testRepo struct {
+	t        *testing.T
+	dir      string
+	repo     *gogit.Repository
+	worktree *gogit.Worktree
+	commits  int
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	dir, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testRepo{t: t, dir: dir, repo: repo, worktree: worktree}
+}
+
+func (tr *testRepo) write(name, content string) {
+	if err := ioutil.WriteFile(filepath.Join(tr.dir, name), []byte(content), 0644); err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+func (tr *testRepo) add(name string) {
+	if _, err := tr.worktree.Add(name); err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+// commit commits a change to a file, a minute after the previous commit
+func (tr *testRepo) commit(message string) plumbing.Hash {
+	tr.commits++
+
+	tr.write("file.txt", message)
+	tr.add("file.txt")
+
+	signature := &object.Signature{
+		Name:  "Chris Cummer",
+		Email: "chris@example.com",
+		When:  commitTime.Add(time.Duration(tr.commits) * time.Minute),
+	}
+
+	hash, err := tr.worktree.Commit(message, &gogit.CommitOptions{Author: signature, Committer: signature})
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+
+	return hash
+}
+
+func (tr *testRepo) checkout(opts *gogit.CheckoutOptions) {
+	if err := tr.worktree.Checkout(opts); err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+func (tr *testRepo) setReference(name plumbing.ReferenceName, hash plumbing.Hash) {
+	if err := tr.repo.Storer.SetReference(plumbing.NewHashReference(name, hash)); err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+// track makes master track origin/master, as if it had been cloned
+func (tr *testRepo) track() {
+	_, err := tr.repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://example.com/wtf.git"}})
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+
+	err = tr.repo.CreateBranch(&config.Branch{Name: "master", Remote: "origin", Merge: plumbing.Master})
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+func (tr *testRepo) gitRepo() *GitRepo {
+	return NewGitRepo(tr.dir, 10, "%h %s", "%Y-%m-%d")
+}
+
+func Test_NewGitRepo(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("First commit")
+	second := tr.commit("Second commit")
+
+	tr.write("file.txt", "changed")
+	tr.write("new.txt", "untracked")
+	tr.write("staged.txt", "staged")
+	tr.add("staged.txt")
+
+	repo := tr.gitRepo()
+
+	assert.NoError(t, repo.Err)
+	assert.Equal(t, tr.dir, repo.Repository)
+	assert.Equal(t, "master", repo.Branch)
+	assert.Equal(t, []string{" M file.txt", "?? new.txt", "A  staged.txt"}, repo.ChangedFiles)
+	assert.Equal(
+		t,
+		[]string{second.String()[:7] + " Second commit", first.String()[:7] + " First commit"},
+		repo.Commits,
+	)
+	assert.Equal(t, "", repo.Upstream)
+	assert.Equal(t, 0, repo.StashCount)
+	assert.Equal(t, "", repo.Tag)
+}
+
+func Test_NewGitRepo_Subdirectory(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("First commit")
+
+	sub := filepath.Join(tr.dir, "sub")
+	assert.NoError(t, os.Mkdir(sub, 0755))
+
+	repo := NewGitRepo(sub, 10, "%s", "")
+
+	assert.NoError(t, repo.Err)
+	assert.Equal(t, tr.dir, repo.Repository)
+}
+
+func Test_NewGitRepo_NoCommits(t *testing.T) {
+	tr := newTestRepo(t)
+
+	repo := tr.gitRepo()
+
+	assert.NoError(t, repo.Err)
+	assert.Equal(t, "master", repo.Branch)
+	assert.Empty(t, repo.Commits)
+}
+
+func Test_NewGitRepo_NotARepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	repo := NewGitRepo(dir, 10, "%s", "")
+
+	assert.Equal(t, gogit.ErrRepositoryNotExists, repo.Err)
+	assert.Equal(t, dir, repo.Repository)
+}
+
+func Test_NewGitRepo_Detached(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("First commit")
+	tr.commit("Second commit")
+
+	tr.checkout(&gogit.CheckoutOptions{Hash: first})
+
+	repo := tr.gitRepo()
+
+	assert.NoError(t, repo.Err)
+	assert.Equal(t, "HEAD", repo.Branch)
+	assert.Len(t, repo.Commits, 1)
+}
+
+func Test_NewGitRepo_AheadBehind(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.track()
+
+	base := tr.commit("Base")
+	tr.commit("Local")
+
+	// Nothing has been fetched yet
+	repo := tr.gitRepo()
+	assert.NoError(t, repo.Err)
+	assert.Equal(t, "", repo.Upstream)
+
+	tr.setReference("refs/remotes/origin/master", base)
+
+	repo = tr.gitRepo()
+	assert.Equal(t, "origin/master", repo.Upstream)
+	assert.Equal(t, 1, repo.AheadCount)
+	assert.Equal(t, 0, repo.BehindCount)
+
+	// Someone else pushes two commits on top of the base
+	tr.checkout(&gogit.CheckoutOptions{Hash: base, Branch: "refs/heads/other", Create: true})
+	tr.commit("Remote 1")
+	remote := tr.commit("Remote 2")
+	tr.setReference("refs/remotes/origin/master", remote)
+	tr.checkout(&gogit.CheckoutOptions{Branch: plumbing.Master})
+
+	repo = tr.gitRepo()
+	assert.Equal(t, 1, repo.AheadCount)
+	assert.Equal(t, 2, repo.BehindCount)
+
+	// And the local branch is merged into it
+	tr.checkout(&gogit.CheckoutOptions{Branch: "refs/heads/other"})
+	local, _ := tr.repo.Reference(plumbing.Master, true)
+	tr.write("file.txt", "merged")
+	tr.add("file.txt")
+	merge, err := tr.worktree.Commit("Merge", &gogit.CommitOptions{
+		Author:  &object.Signature{Name: "Chris Cummer", When: commitTime.Add(time.Hour)},
+		Parents: []plumbing.Hash{remote, local.Hash()},
+	})
+	assert.NoError(t, err)
+	tr.setReference("refs/remotes/origin/master", merge)
+	tr.checkout(&gogit.CheckoutOptions{Branch: plumbing.Master})
+
+	repo = tr.gitRepo()
+	assert.Equal(t, 0, repo.AheadCount)
+	assert.Equal(t, 3, repo.BehindCount)
+}
+
+func Test_NewGitRepo_Tag(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("First commit")
+
+	_, err := tr.repo.CreateTag("v1.0.0", first, nil)
+	assert.NoError(t, err)
+
+	second := tr.commit("Second commit")
+
+	assert.Equal(t, "", tr.gitRepo().Tag)
+
+	_, err = tr.repo.CreateTag("v1.1.0", second, &gogit.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "Chris Cummer", When: commitTime},
+		Message: "Release",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "v1.1.0", tr.gitRepo().Tag)
+
+	_, err = tr.repo.CreateTag("latest", second, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "latest, v1.1.0", tr.gitRepo().Tag)
+}
+
+func Test_NewGitRepo_RefNames(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("First commit")
+
+	_, err := tr.repo.CreateTag("v1.0.0", first, &gogit.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "Chris Cummer", When: commitTime},
+		Message: "Release",
+	})
+	assert.NoError(t, err)
+
+	second := tr.commit("Second commit")
+	tr.setReference(plumbing.NewRemoteReferenceName("origin", "master"), first)
+	tr.setReference(plumbing.NewBranchReferenceName("feature"), second)
+
+	repo := NewGitRepo(tr.dir, 10, "%s%d", "%Y-%m-%d")
+
+	assert.Equal(
+		t,
+		[]string{"Second commit (HEAD -> master, feature)", "First commit (origin/master, tag: v1.0.0)"},
+		repo.Commits,
+	)
+}
+
+func Test_NewGitRepo_StashCount(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commit("First commit")
+
+	stashLines := "0000000000000000000000000000000000000000 1111111111111111111111111111111111111111 Chris <chris@example.com> 1596283200 +0000\tWIP on master: first\n" +
+		"1111111111111111111111111111111111111111 2222222222222222222222222222222222222222 Chris <chris@example.com> 1596283260 +0000\tWIP on master: second\n"
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(tr.dir, ".git", "logs", "refs"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tr.dir, ".git", stashLog), []byte(stashLines), 0644))
+
+	assert.Equal(t, 2, tr.gitRepo().StashCount)
+}
+
+func Test_checkout(t *testing.T) {
+	tr := newTestRepo(t)
+	first := tr.commit("First commit")
+	tr.setReference("refs/heads/feature", first)
+	tr.commit("Second commit")
+
+	repo := tr.gitRepo()
+
+	assert.NoError(t, repo.checkout("feature"))
+	assert.Equal(t, "feature", tr.gitRepo().Branch)
+
+	assert.EqualError(t, repo.checkout("missing"), `branch "missing" does not exist`)
+
+	tr.write("file.txt", "unstaged")
+	assert.Equal(t, gogit.ErrUnstagedChanges, repo.checkout("master"))
+}
+
+func Test_checkout_RemoteBranch(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.track()
+	first := tr.commit("First commit")
+	tr.setReference("refs/remotes/origin/feature", first)
+	tr.commit("Second commit")
+
+	repo := tr.gitRepo()
+
+	assert.NoError(t, repo.checkout("feature"))
+
+	repo = tr.gitRepo()
+	assert.Equal(t, "feature", repo.Branch)
+	assert.Equal(t, "origin/feature", repo.Upstream)
+	assert.Equal(t, 0, repo.AheadCount)
+	assert.Equal(t, 0, repo.BehindCount)
+}
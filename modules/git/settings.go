@@ -15,8 +15,8 @@ type Settings struct {
 	common *cfg.Common
 
 	commitCount  int           `help:"The number of past commits to display." values:"A positive integer, 0..n." optional:"true"`
-	commitFormat string        `help:"The string format for the commit message." optional:"true"`
-	dateFormat   string        `help:"The string format for the date/time in the commit message." optional:"true"`
+	commitFormat string        `help:"The string format for the commit message. Supports the %h, %H, %s, %d, %an, %ae, %ad, %ar, %cn, %ce, %cd and %cr placeholders of git log." optional:"true"`
+	dateFormat   string        `help:"The strftime format for the date/time in the commit message." optional:"true"`
 	repositories []interface{} `help:"Defines which git repositories to watch." values:"A list of zero or more local file paths pointing to valid git repositories."`
 }
 
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
@@ -58,10 +59,14 @@ func (widget *Widget) Checkout() {
 	checkoutFctn := func() {
 		text := form.GetFormItem(0).(*tview.InputField).GetText()
 		repoToCheckout := widget.GitRepos[widget.Idx]
-		repoToCheckout.checkout(text)
 		widget.pages.RemovePage("modal")
 		widget.app.SetFocus(widget.View)
-		widget.display()
+
+		if err := repoToCheckout.checkout(text); err != nil {
+			widget.ShowMessage(fmt.Sprintf("[red]Could not checkout %s: %s", tview.Escape(text), tview.Escape(err.Error())))
+			return
+		}
+
 		widget.Refresh()
 	}
 
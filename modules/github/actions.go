@@ -0,0 +1,106 @@
+package github
+
+import (
+	"fmt"
+
+	ghb "github.com/google/go-github/v32/github"
+	"github.com/rivo/tview"
+)
+
+// mergeMethods are the ways a pull request can be merged, in the order they are offered
+var mergeMethods = []string{"merge", "squash", "rebase"}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedPr returns the repo being displayed and the highlighted pull request. It
+// returns a nil pull request if nothing is highlighted, or if the highlighted item is
+// an issue from a custom query
+func (widget *Widget) selectedPr() (*Repo, *ghb.PullRequest) {
+	repo := widget.currentGithubRepo()
+	if repo == nil || widget.Selected < 0 || widget.Selected >= len(widget.Items) {
+		return nil, nil
+	}
+
+	return repo, repo.pullRequest(widget.Items[widget.Selected])
+}
+
+func (widget *Widget) approvePr() {
+	repo, pr := widget.selectedPr()
+	if pr == nil {
+		return
+	}
+
+	number := pr.GetNumber()
+
+	go func() {
+		widget.ReportAction(fmt.Sprintf("Approved #%d", number), repo.Approve(number), widget.RequestRefresh)
+	}()
+}
+
+func (widget *Widget) commentPr() {
+	repo, pr := widget.selectedPr()
+	if pr == nil {
+		return
+	}
+
+	number := pr.GetNumber()
+
+	widget.ShowInput(fmt.Sprintf("Comment on #%d", number), func(text string) {
+		go func() {
+			widget.ReportAction(fmt.Sprintf("Commented on #%d", number), repo.Comment(number, text), widget.RequestRefresh)
+		}()
+	})
+}
+
+// mergePr merges the highlighted pull request, with a method chosen from a modal, if
+// GitHub reports that it can be merged cleanly. The mergeability status is only fetched
+// when enableStatus is on, so merging requires it too
+func (widget *Widget) mergePr() {
+	repo, pr := widget.selectedPr()
+	if pr == nil {
+		return
+	}
+
+	if !widget.settings.enableStatus {
+		widget.ShowMessage(" Merging requires [::b]enableStatus[::-] to be set in the config")
+		return
+	}
+
+	number := pr.GetNumber()
+
+	go func() {
+		state, err := repo.MergeableState(number)
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		if state != "clean" {
+			widget.ShowMessage(fmt.Sprintf(" #%d cannot be merged, its status is ‘%s’", number, tview.Escape(state)))
+			return
+		}
+
+		text := fmt.Sprintf("Merge #%d %s?", number, tview.Escape(pr.GetTitle()))
+
+		widget.ShowChoice(text, mergeMethods, func(method string) {
+			go func() {
+				widget.ReportAction(fmt.Sprintf("Merged #%d with %s", number, method), repo.Merge(number, method), widget.RequestRefresh)
+			}()
+		})
+	}()
+}
+
+func (widget *Widget) requestChangesPr() {
+	repo, pr := widget.selectedPr()
+	if pr == nil {
+		return
+	}
+
+	number := pr.GetNumber()
+
+	widget.ShowInput(fmt.Sprintf("Request changes on #%d", number), func(text string) {
+		go func() {
+			widget.ReportAction(fmt.Sprintf("Requested changes on #%d", number), repo.RequestChanges(number, text), widget.RequestRefresh)
+		}()
+	})
+}
@@ -27,7 +27,7 @@ func (widget *Widget) content() (string, string, bool) {
 	widget.Items = make([]int, 0)
 	widget.SetItemCount(len(repo.myReviewRequests((username))))
 
-	title := fmt.Sprintf("%s - %s", widget.CommonSettings().Title, widget.title(repo))
+	title := fmt.Sprintf("%s - %s%s", widget.CommonSettings().Title, widget.title(repo), widget.rateLimitString())
 	if repo == nil {
 		return title, " GitHub repo data is unavailable ", false
 	} else if repo.Err != nil {
@@ -50,14 +50,14 @@ func (widget *Widget) content() (string, string, bool) {
 	}
 	for _, customQuery := range widget.settings.customQueries {
 		str += fmt.Sprintf("\n [%s]%s[white]\n", widget.settings.common.Colors.Subheading, customQuery.title)
-		str += widget.displayCustomQuery(repo, customQuery.filter, customQuery.perPage)
+		str += widget.displayCustomQuery(repo, customQuery.filter)
 	}
 
 	return title, str, false
 }
 
 func (widget *Widget) displayMyPullRequests(repo *Repo, username string) string {
-	prs := repo.myPullRequests(username, widget.settings.enableStatus)
+	prs := repo.myPullRequests(username)
 
 	prLength := len(prs)
 
@@ -69,7 +69,7 @@ func (widget *Widget) displayMyPullRequests(repo *Repo, username string) string
 
 	str := ""
 	for idx, pr := range prs {
-		str += fmt.Sprintf(` %s[green]["%d"]%4d[""][white] %s`, widget.mergeString(pr), maxItems+idx, *pr.Number, *pr.Title)
+		str += fmt.Sprintf(` %s%s[green]["%d"]%4d[""][white] %s`, widget.mergeString(pr), widget.checkString(repo, pr), maxItems+idx, *pr.Number, *pr.Title)
 		str += "\n"
 		widget.Items = append(widget.Items, *pr.Number)
 	}
@@ -79,8 +79,8 @@ func (widget *Widget) displayMyPullRequests(repo *Repo, username string) string
 	return str
 }
 
-func (widget *Widget) displayCustomQuery(repo *Repo, filter string, perPage int) string {
-	res := repo.customIssueQuery(filter, perPage)
+func (widget *Widget) displayCustomQuery(repo *Repo, filter string) string {
+	res := repo.CustomQueryResults[filter]
 
 	if res == nil {
 		return " [grey]Invalid Query[white]\n"
@@ -115,7 +115,7 @@ func (widget *Widget) displayMyReviewRequests(repo *Repo, username string) strin
 
 	str := ""
 	for idx, pr := range prs {
-		str += fmt.Sprintf(` [green]["%d"]%4d[""][white] %s`, idx, *pr.Number, *pr.Title)
+		str += fmt.Sprintf(` %s[green]["%d"]%4d[""][white] %s`, widget.checkString(repo, pr), idx, *pr.Number, *pr.Title)
 		str += "\n"
 		widget.Items = append(widget.Items, *pr.Number)
 	}
@@ -145,6 +145,40 @@ func (widget *Widget) title(repo *Repo) string {
 	)
 }
 
+// rateLimitString returns the number of GraphQL API points left, and whether refreshes
+// are being slowed down to make them last until the limit resets
+func (widget *Widget) rateLimitString() string {
+	rate := widget.rateLimit
+	if rate.Limit == 0 {
+		return ""
+	}
+
+	prntr := message.NewPrinter(language.English)
+	str := prntr.Sprintf(" [gray]%d/%d[white]", rate.Remaining, rate.Limit)
+
+	if widget.refreshDelay() > 0 {
+		str += " [yellow]slowed[white]"
+	}
+
+	return str
+}
+
+var checkIcons = map[string]string{
+	checkFailure: "[red]\u25cf[white] ",
+	checkPending: "[yellow]\u25cf[white] ",
+	checkSuccess: "[green]\u25cf[white] ",
+}
+
+func (widget *Widget) checkString(repo *Repo, pr *ghb.PullRequest) string {
+	if !widget.settings.showChecks {
+		return ""
+	}
+	if str, ok := checkIcons[repo.CheckStatuses[pr.GetNumber()]]; ok {
+		return str
+	}
+	return "  "
+}
+
 var mergeIcons = map[string]string{
 	"dirty":    "[red]\u0021[white] ",
 	"clean":    "[green]\u2713[white] ",
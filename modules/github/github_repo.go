@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	ghb "github.com/google/go-github/v32/github"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/utils"
 	"golang.org/x/oauth2"
 )
@@ -15,24 +17,43 @@ const (
 	issuesPath       = "/issues"
 )
 
+// The aggregate statuses of the check runs of a pull request's head commit
+const (
+	checkFailure = "failure"
+	checkPending = "pending"
+	checkSuccess = "success"
+)
+
 // Repo defines a new GitHub Repo structure
 type Repo struct {
 	apiKey    string
 	baseURL   string
 	uploadURL string
 
-	Name         string
-	Owner        string
-	PullRequests []*ghb.PullRequest
-	RemoteRepo   *ghb.Repository
-	Err          error
+	Name               string
+	Owner              string
+	PullRequests       []*ghb.PullRequest
+	RemoteRepo         *ghb.Repository
+	CheckStatuses      map[int]string
+	CustomQueryResults map[string]*ghb.IssuesSearchResult
+	Err                error
+}
+
+// cachedRepo is the data of a repo that is cached between runs
+type cachedRepo struct {
+	PullRequests       []*ghb.PullRequest
+	RemoteRepo         *ghb.Repository
+	CheckStatuses      map[int]string
+	CustomQueryResults map[string]*ghb.IssuesSearchResult
 }
 
 // NewGithubRepo returns a new Github Repo with a name, owner, apiKey, baseURL and uploadURL
 func NewGithubRepo(name, owner, apiKey, baseURL, uploadURL string) *Repo {
 	repo := Repo{
-		Name:  name,
-		Owner: owner,
+		Name:               name,
+		Owner:              owner,
+		CheckStatuses:      map[int]string{},
+		CustomQueryResults: map[string]*ghb.IssuesSearchResult{},
 
 		apiKey:    apiKey,
 		baseURL:   baseURL,
@@ -57,17 +78,98 @@ func (repo *Repo) OpenIssues() {
 	utils.OpenFile(*repo.RemoteRepo.HTMLURL + issuesPath)
 }
 
-// Refresh reloads the github data via the Github API
-func (repo *Repo) Refresh() {
-	prs, err := repo.loadPullRequests()
-	repo.Err = err
-	repo.PullRequests = prs
+// LoadCache replaces the repo's data with the data cached by the last successful refresh.
+// It returns when that data was fetched, and FALSE if nothing was cached
+func (repo *Repo) LoadCache(cache *cache.Cache) (time.Time, bool) {
+	cached := cachedRepo{}
+
+	storedAt, ok := cache.Load(repo.cacheKey(), &cached)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	repo.Err = nil
+	repo.PullRequests = cached.PullRequests
+	repo.RemoteRepo = cached.RemoteRepo
+	if cached.CheckStatuses != nil {
+		repo.CheckStatuses = cached.CheckStatuses
+	}
+	if cached.CustomQueryResults != nil {
+		repo.CustomQueryResults = cached.CustomQueryResults
+	}
+
+	return storedAt, true
+}
+
+// StoreCache caches the repo's data so that it can be displayed before the first refresh
+// the next time WTF starts
+func (repo *Repo) StoreCache(cache *cache.Cache) {
+	cache.Store(repo.cacheKey(), cachedRepo{
+		PullRequests:       repo.PullRequests,
+		RemoteRepo:         repo.RemoteRepo,
+		CheckStatuses:      repo.CheckStatuses,
+		CustomQueryResults: repo.CustomQueryResults,
+	})
+}
+
+/* -------------------- Actions -------------------- */
+
+// Approve submits an approving review of the pull request
+func (repo *Repo) Approve(number int) error {
+	return repo.submitReview(number, "APPROVE", "")
+}
+
+// Comment adds a comment to the conversation of the pull request
+func (repo *Repo) Comment(number int, body string) error {
+	github, err := repo.githubClient()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = github.Issues.CreateComment(context.Background(), repo.Owner, repo.Name, number, &ghb.IssueComment{Body: &body})
+
+	return err
+}
+
+// MergeableState fetches the pull request and returns its mergeability status
+// (‘dirty’, ‘clean’, ‘unstable’, ‘blocked’)
+func (repo *Repo) MergeableState(number int) (string, error) {
+	github, err := repo.githubClient()
 	if err != nil {
-		return
+		return "", err
 	}
-	remote, err := repo.loadRemoteRepository()
-	repo.Err = err
-	repo.RemoteRepo = remote
+
+	pr, _, err := github.PullRequests.Get(context.Background(), repo.Owner, repo.Name, number)
+	if err != nil {
+		return "", err
+	}
+
+	return pr.GetMergeableState(), nil
+}
+
+// Merge merges the pull request using the method, which is one of ‘merge’, ‘squash’ or ‘rebase’
+func (repo *Repo) Merge(number int, method string) error {
+	github, err := repo.githubClient()
+	if err != nil {
+		return err
+	}
+
+	result, _, err := github.PullRequests.Merge(context.Background(), repo.Owner, repo.Name, number, "", &ghb.PullRequestOptions{MergeMethod: method})
+	if err != nil {
+		return err
+	}
+
+	if !result.GetMerged() {
+		return fmt.Errorf("pull request #%d was not merged: %s", number, result.GetMessage())
+	}
+
+	return nil
+}
+
+// RequestChanges submits a review of the pull request that requests changes. GitHub
+// requires the review to explain what needs to change, so body cannot be empty
+func (repo *Repo) RequestChanges(number int, body string) error {
+	return repo.submitReview(number, "REQUEST_CHANGES", body)
 }
 
 /* -------------------- Counts -------------------- */
@@ -99,6 +201,21 @@ func (repo *Repo) StarCount() int {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// pullRequest returns the loaded pull request with the number, or nil if there is none
+func (repo *Repo) pullRequest(number int) *ghb.PullRequest {
+	for _, pr := range repo.PullRequests {
+		if pr.GetNumber() == number {
+			return pr
+		}
+	}
+
+	return nil
+}
+
+func (repo *Repo) cacheKey() string {
+	return repo.Owner + "/" + repo.Name
+}
+
 func (repo *Repo) isGitHubEnterprise() bool {
 	if len(repo.baseURL) > 0 {
 		if len(repo.uploadURL) == 0 {
@@ -128,46 +245,18 @@ func (repo *Repo) githubClient() (*ghb.Client, error) {
 }
 
 // myPullRequests returns a list of pull requests created by username on this repo
-func (repo *Repo) myPullRequests(username string, showStatus bool) []*ghb.PullRequest {
+func (repo *Repo) myPullRequests(username string) []*ghb.PullRequest {
 	prs := []*ghb.PullRequest{}
 
 	for _, pr := range repo.PullRequests {
-		user := *pr.User
-
-		if *user.Login == username {
+		if pr.GetUser().GetLogin() == username {
 			prs = append(prs, pr)
 		}
 	}
 
-	if showStatus {
-		prs = repo.individualPRs(prs)
-	}
-
 	return prs
 }
 
-// individualPRs takes a list of pull requests (presumably returned from
-// github.PullRequests.List) and fetches them individually to get more detailed
-// status info on each. see: https://developer.github.com/v3/git/#checking-mergeability-of-pull-requests
-func (repo *Repo) individualPRs(prs []*ghb.PullRequest) []*ghb.PullRequest {
-	github, err := repo.githubClient()
-	if err != nil {
-		return prs
-	}
-
-	var ret []*ghb.PullRequest
-	for i := range prs {
-		pr, _, err := github.PullRequests.Get(context.Background(), repo.Owner, repo.Name, prs[i].GetNumber())
-		if err != nil {
-			// worst case, just keep the original one
-			ret = append(ret, prs[i])
-		} else {
-			ret = append(ret, pr)
-		}
-	}
-	return ret
-}
-
 // myReviewRequests returns a list of pull requests for which username has been
 // requested to do a code review
 func (repo *Repo) myReviewRequests(username string) []*ghb.PullRequest {
@@ -175,7 +264,7 @@ func (repo *Repo) myReviewRequests(username string) []*ghb.PullRequest {
 
 	for _, pr := range repo.PullRequests {
 		for _, reviewer := range pr.RequestedReviewers {
-			if *reviewer.Login == username {
+			if reviewer.GetLogin() == username {
 				prs = append(prs, pr)
 			}
 		}
@@ -184,51 +273,18 @@ func (repo *Repo) myReviewRequests(username string) []*ghb.PullRequest {
 	return prs
 }
 
-func (repo *Repo) customIssueQuery(filter string, perPage int) *ghb.IssuesSearchResult {
-	github, err := repo.githubClient()
-	if err != nil {
-		return nil
-	}
-
-	opts := &ghb.SearchOptions{}
-	if perPage != 0 {
-		opts.ListOptions.PerPage = perPage
-	}
-
-	prs, _, _ := github.Search.Issues(context.Background(), fmt.Sprintf("%s repo:%s/%s", filter, repo.Owner, repo.Name), opts)
-	return prs
-}
-
-func (repo *Repo) loadPullRequests() ([]*ghb.PullRequest, error) {
+func (repo *Repo) submitReview(number int, event, body string) error {
 	github, err := repo.githubClient()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	opts := &ghb.PullRequestListOptions{}
-	opts.ListOptions.PerPage = 100
-
-	prs, _, err := github.PullRequests.List(context.Background(), repo.Owner, repo.Name, opts)
-
-	if err != nil {
-		return nil, err
+	review := &ghb.PullRequestReviewRequest{Event: &event}
+	if body != "" {
+		review.Body = &body
 	}
 
-	return prs, nil
-}
-
-func (repo *Repo) loadRemoteRepository() (*ghb.Repository, error) {
-	github, err := repo.githubClient()
-
-	if err != nil {
-		return nil, err
-	}
-
-	repository, _, err := github.Repositories.Get(context.Background(), repo.Owner, repo.Name)
-
-	if err != nil {
-		return nil, err
-	}
+	_, _, err = github.PullRequests.CreateReview(context.Background(), repo.Owner, repo.Name, number, review)
 
-	return repository, nil
+	return err
 }
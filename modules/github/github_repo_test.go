@@ -0,0 +1,124 @@
+package github
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRequest is a request received by the fake GitHub Enterprise API
+type fakeRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+// fakeGitHub starts a fake GitHub Enterprise API that replies to each path with the
+// matching response, and records the requests it receives
+func fakeGitHub(t *testing.T, responses map[string]string) (*httptest.Server, *[]fakeRequest) {
+	requests := []fakeRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := fakeRequest{method: r.Method, path: r.URL.Path}
+
+		raw, _ := ioutil.ReadAll(r.Body)
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &req.body); err != nil {
+				t.Errorf("invalid request body: %s", raw)
+			}
+		}
+
+		requests = append(requests, req)
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+func testRepo(server *httptest.Server) *Repo {
+	return NewGithubRepo("wtf", "wtfutil", "token", server.URL, "")
+}
+
+func Test_Approve(t *testing.T) {
+	server, requests := fakeGitHub(t, map[string]string{
+		"POST /api/v3/repos/wtfutil/wtf/pulls/7/reviews": `{"id": 1, "state": "APPROVED"}`,
+	})
+	defer server.Close()
+
+	err := testRepo(server).Approve(7)
+
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "APPROVE", (*requests)[0].body["event"])
+	assert.NotContains(t, (*requests)[0].body, "body")
+}
+
+func Test_RequestChanges(t *testing.T) {
+	server, requests := fakeGitHub(t, map[string]string{
+		"POST /api/v3/repos/wtfutil/wtf/pulls/7/reviews": `{"id": 1, "state": "CHANGES_REQUESTED"}`,
+	})
+	defer server.Close()
+
+	err := testRepo(server).RequestChanges(7, "Needs tests")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "REQUEST_CHANGES", (*requests)[0].body["event"])
+	assert.Equal(t, "Needs tests", (*requests)[0].body["body"])
+}
+
+func Test_Comment(t *testing.T) {
+	server, requests := fakeGitHub(t, map[string]string{
+		"POST /api/v3/repos/wtfutil/wtf/issues/7/comments": `{"id": 1}`,
+	})
+	defer server.Close()
+
+	err := testRepo(server).Comment(7, "LGTM")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "LGTM", (*requests)[0].body["body"])
+
+	// A pull request that doesn't exist
+	err = testRepo(server).Comment(8, "LGTM")
+	assert.Error(t, err)
+}
+
+func Test_MergeableState(t *testing.T) {
+	server, _ := fakeGitHub(t, map[string]string{
+		"GET /api/v3/repos/wtfutil/wtf/pulls/7": `{"number": 7, "mergeable_state": "clean"}`,
+	})
+	defer server.Close()
+
+	state, err := testRepo(server).MergeableState(7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "clean", state)
+}
+
+func Test_Merge(t *testing.T) {
+	server, requests := fakeGitHub(t, map[string]string{
+		"PUT /api/v3/repos/wtfutil/wtf/pulls/7/merge": `{"merged": true, "message": "Pull Request successfully merged"}`,
+		"PUT /api/v3/repos/wtfutil/wtf/pulls/8/merge": `{"merged": false, "message": "Base branch was modified"}`,
+	})
+	defer server.Close()
+
+	repo := testRepo(server)
+
+	assert.NoError(t, repo.Merge(7, "squash"))
+	assert.Equal(t, "PUT", (*requests)[0].method)
+	assert.Equal(t, "squash", (*requests)[0].body["merge_method"])
+
+	err := repo.Merge(8, "merge")
+	assert.EqualError(t, err, "pull request #8 was not merged: Base branch was modified")
+}
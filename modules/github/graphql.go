@@ -0,0 +1,385 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ghb "github.com/google/go-github/v32/github"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// graphQLBatchSize is the number of repos fetched by each GraphQL query. Bigger batches
+	// make fewer requests, but GitHub gives up on queries that take too long to resolve
+	graphQLBatchSize = 5
+
+	// mergeInfoPreview is the media type that enables the mergeStateStatus field
+	mergeInfoPreview = "application/vnd.github.merge-info-preview+json"
+)
+
+// graphQLClient fetches repos in batches from the GitHub GraphQL API
+type graphQLClient struct {
+	httpClient *http.Client
+	url        string
+}
+
+// graphQLOptions defines what is fetched for each repo
+type graphQLOptions struct {
+	customQueries []customQuery
+	enableStatus  bool
+	showChecks    bool
+}
+
+type graphQLError struct {
+	Message string
+	Path    []interface{}
+}
+
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage
+	Errors []graphQLError
+}
+
+type graphQLPullRequest struct {
+	Number           int
+	Title            string
+	URL              string
+	HeadRefOid       string
+	MergeStateStatus string
+	Author           *struct {
+		Login string
+	}
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *struct {
+				Login string
+			}
+		}
+	}
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string
+				}
+			}
+		}
+	}
+}
+
+type graphQLRepo struct {
+	URL        string
+	Stargazers struct {
+		TotalCount int
+	}
+	Issues struct {
+		TotalCount int
+	}
+	PullRequests struct {
+		TotalCount int
+		Nodes      []graphQLPullRequest
+	}
+}
+
+type graphQLSearch struct {
+	IssueCount int
+	Nodes      []struct {
+		Number int
+		Title  string
+	}
+}
+
+// checkStates maps the state of a commit's status check rollup to the check status
+// displayed next to its pull request
+var checkStates = map[string]string{
+	"ERROR":    checkFailure,
+	"EXPECTED": checkPending,
+	"FAILURE":  checkFailure,
+	"PENDING":  checkPending,
+	"SUCCESS":  checkSuccess,
+}
+
+func newGraphQLClient(apiKey, baseURL string) *graphQLClient {
+	tokenService := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: apiKey},
+	)
+
+	return &graphQLClient{
+		httpClient: oauth2.NewClient(context.Background(), tokenService),
+		url:        graphQLURL(baseURL),
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// graphQLURL returns the GraphQL endpoint of github.com, or of the GitHub Enterprise
+// instance whose REST API is at baseURL
+func graphQLURL(baseURL string) string {
+	if baseURL == "" {
+		return "https://api.github.com/graphql"
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	baseURL = strings.TrimSuffix(baseURL, "/api/v3")
+
+	return baseURL + "/api/graphql"
+}
+
+// graphQLString quotes and escapes a string for use as an argument in a query
+func graphQLString(str string) string {
+	quoted, _ := json.Marshal(str)
+	return string(quoted)
+}
+
+// fetchRepos fetches the repos, and the results of their custom queries, with a single
+// query. Each repo's data, or the error that stopped it from loading, is set on the repo.
+// It returns the state of the rate limit after the query
+func (client *graphQLClient) fetchRepos(repos []*Repo, opts graphQLOptions) (rateLimit, error) {
+	response, err := client.query(buildReposQuery(repos, opts), opts.enableStatus)
+	if err != nil {
+		return rateLimit{}, err
+	}
+
+	// Errors are reported against the alias of the field that failed, so a repo that
+	// doesn't exist doesn't stop the others from loading
+	failed := map[string]error{}
+	for _, gqlErr := range response.Errors {
+		if len(gqlErr.Path) == 0 {
+			return rateLimit{}, fmt.Errorf("GitHub GraphQL API: %s", gqlErr.Message)
+		}
+
+		if alias, ok := gqlErr.Path[0].(string); ok {
+			failed[alias] = fmt.Errorf("GitHub GraphQL API: %s", gqlErr.Message)
+		}
+	}
+
+	for idx, repo := range repos {
+		alias := fmt.Sprintf("repo%d", idx)
+
+		if err, ok := failed[alias]; ok {
+			repo.Err = err
+			continue
+		}
+
+		remote := graphQLRepo{}
+		if err := json.Unmarshal(response.Data[alias], &remote); err != nil {
+			repo.Err = err
+			continue
+		}
+
+		repo.Err = nil
+		repo.loadGraphQLRepo(remote)
+
+		repo.CustomQueryResults = map[string]*ghb.IssuesSearchResult{}
+		for queryIdx, customQuery := range opts.customQueries {
+			queryAlias := fmt.Sprintf("%sQuery%d", alias, queryIdx)
+
+			if _, ok := failed[queryAlias]; ok {
+				continue
+			}
+
+			search := graphQLSearch{}
+			if err := json.Unmarshal(response.Data[queryAlias], &search); err != nil {
+				continue
+			}
+
+			repo.CustomQueryResults[customQuery.filter] = search.issuesSearchResult()
+		}
+	}
+
+	rate := rateLimit{}
+	if raw, ok := response.Data["rateLimit"]; ok {
+		_ = json.Unmarshal(raw, &rate)
+	}
+
+	return rate, nil
+}
+
+func (client *graphQLClient) query(query string, enableStatus bool) (*graphQLResponse, error) {
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", client.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if enableStatus {
+		req.Header.Set("Accept", mergeInfoPreview)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub GraphQL API returned %s", resp.Status)
+	}
+
+	response := &graphQLResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// buildReposQuery builds a query for the rate limit, and for each repo and its custom
+// queries under the aliases repo0, repo0Query0, repo0Query1, repo1, and so on
+func buildReposQuery(repos []*Repo, opts graphQLOptions) string {
+	prFields := "number title url headRefOid author { login } " +
+		"reviewRequests(first: 20) { nodes { requestedReviewer { ... on User { login } } } }"
+	if opts.enableStatus {
+		prFields += " mergeStateStatus"
+	}
+	if opts.showChecks {
+		prFields += " commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }"
+	}
+
+	var query strings.Builder
+	query.WriteString("query {\n  rateLimit { cost limit remaining resetAt }\n")
+
+	for idx, repo := range repos {
+		fmt.Fprintf(
+			&query,
+			"  repo%d: repository(owner: %s, name: %s) { url stargazers { totalCount } issues(states: OPEN) { totalCount } "+
+				"pullRequests(states: OPEN, first: 100, orderBy: {field: CREATED_AT, direction: DESC}) { totalCount nodes { %s } } }\n",
+			idx,
+			graphQLString(repo.Owner),
+			graphQLString(repo.Name),
+			prFields,
+		)
+
+		for queryIdx, customQuery := range opts.customQueries {
+			perPage := customQuery.perPage
+			if perPage == 0 {
+				perPage = 30
+			}
+
+			fmt.Fprintf(
+				&query,
+				"  repo%dQuery%d: search(type: ISSUE, first: %d, query: %s) { issueCount nodes { ... on Issue { number title } ... on PullRequest { number title } } }\n",
+				idx,
+				queryIdx,
+				perPage,
+				graphQLString(fmt.Sprintf("%s repo:%s/%s", customQuery.filter, repo.Owner, repo.Name)),
+			)
+		}
+	}
+
+	query.WriteString("}")
+
+	return query.String()
+}
+
+// loadGraphQLRepo replaces the repo's data with what was fetched from the GraphQL API, in
+// the shape the REST API returns it in
+func (repo *Repo) loadGraphQLRepo(remote graphQLRepo) {
+	// Like the REST API, the count of open issues includes the open pull requests
+	openIssues := remote.Issues.TotalCount + remote.PullRequests.TotalCount
+
+	repo.RemoteRepo = &ghb.Repository{
+		HTMLURL:         ghb.String(remote.URL),
+		OpenIssuesCount: ghb.Int(openIssues),
+		StargazersCount: ghb.Int(remote.Stargazers.TotalCount),
+	}
+
+	repo.PullRequests = []*ghb.PullRequest{}
+	repo.CheckStatuses = map[int]string{}
+
+	for _, node := range remote.PullRequests.Nodes {
+		repo.PullRequests = append(repo.PullRequests, node.pullRequest())
+
+		for _, commit := range node.Commits.Nodes {
+			if rollup := commit.Commit.StatusCheckRollup; rollup != nil {
+				if status, ok := checkStates[rollup.State]; ok {
+					repo.CheckStatuses[node.Number] = status
+				}
+			}
+		}
+	}
+}
+
+func (node graphQLPullRequest) pullRequest() *ghb.PullRequest {
+	pr := &ghb.PullRequest{
+		Number:  ghb.Int(node.Number),
+		Title:   ghb.String(node.Title),
+		HTMLURL: ghb.String(node.URL),
+		Head:    &ghb.PullRequestBranch{SHA: ghb.String(node.HeadRefOid)},
+		User:    &ghb.User{Login: ghb.String("ghost")},
+	}
+
+	// The author of a pull request is null once their account has been deleted
+	if node.Author != nil {
+		pr.User.Login = ghb.String(node.Author.Login)
+	}
+
+	if node.MergeStateStatus != "" {
+		pr.MergeableState = ghb.String(strings.ToLower(node.MergeStateStatus))
+	}
+
+	// Teams can be requested to review too, but they have no login
+	for _, request := range node.ReviewRequests.Nodes {
+		if request.RequestedReviewer != nil && request.RequestedReviewer.Login != "" {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, &ghb.User{Login: ghb.String(request.RequestedReviewer.Login)})
+		}
+	}
+
+	return pr
+}
+
+func (search graphQLSearch) issuesSearchResult() *ghb.IssuesSearchResult {
+	result := &ghb.IssuesSearchResult{
+		Total:  ghb.Int(search.IssueCount),
+		Issues: []*ghb.Issue{},
+	}
+
+	for _, node := range search.Nodes {
+		result.Issues = append(result.Issues, &ghb.Issue{Number: ghb.Int(node.Number), Title: ghb.String(node.Title)})
+	}
+
+	return result
+}
+
+// rateLimit is the state of the GraphQL API rate limit, as of the last query
+type rateLimit struct {
+	Cost      int
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// refreshDelay returns how long to wait between refreshes that each cost the given number
+// of points so that the points that remain last until the limit resets. There is no delay
+// while more than threshold points remain
+func (rate rateLimit) refreshDelay(now time.Time, threshold, cost int) time.Duration {
+	if rate.Limit == 0 || rate.Remaining > threshold {
+		return 0
+	}
+
+	untilReset := rate.ResetAt.Sub(now)
+	if untilReset <= 0 {
+		return 0
+	}
+
+	if cost < 1 {
+		cost = 1
+	}
+
+	refreshes := rate.Remaining / cost
+	if refreshes < 1 {
+		return untilReset
+	}
+
+	return untilReset / time.Duration(refreshes)
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const graphQLReposResponse = `{
+	"data": {
+		"rateLimit": {"cost": 1, "limit": 5000, "remaining": 4321, "resetAt": "2020-08-01T12:00:00Z"},
+		"repo0": {
+			"url": "https://github.com/wtfutil/wtf",
+			"stargazers": {"totalCount": 9000},
+			"issues": {"totalCount": 40},
+			"pullRequests": {
+				"totalCount": 2,
+				"nodes": [
+					{
+						"number": 7,
+						"title": "Add a widget",
+						"url": "https://github.com/wtfutil/wtf/pull/7",
+						"headRefOid": "aaa",
+						"author": {"login": "senorprogrammer"},
+						"mergeStateStatus": "CLEAN",
+						"reviewRequests": {"nodes": [{"requestedReviewer": {"login": "me"}}, {"requestedReviewer": {}}]},
+						"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}
+					},
+					{
+						"number": 8,
+						"title": "Fix a widget",
+						"url": "https://github.com/wtfutil/wtf/pull/8",
+						"headRefOid": "bbb",
+						"author": null,
+						"mergeStateStatus": "BLOCKED",
+						"reviewRequests": {"nodes": []},
+						"commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}
+					}
+				]
+			}
+		},
+		"repo0Query0": {"issueCount": 1, "nodes": [{"number": 3, "title": "A bug"}]},
+		"repo1": null,
+		"repo1Query0": null
+	},
+	"errors": [
+		{"message": "Could not resolve to a Repository with the name 'wtfutil/missing'.", "path": ["repo1"]}
+	]
+}`
+
+func Test_fetchRepos(t *testing.T) {
+	server, requests := fakeGitHub(t, map[string]string{
+		"POST /api/graphql": graphQLReposResponse,
+	})
+	defer server.Close()
+
+	repos := []*Repo{
+		testRepo(server),
+		NewGithubRepo("missing", "wtfutil", "token", server.URL, ""),
+	}
+	opts := graphQLOptions{
+		customQueries: []customQuery{{title: "Bugs", filter: "is:open label:bug", perPage: 5}},
+		enableStatus:  true,
+		showChecks:    true,
+	}
+
+	rate, err := newGraphQLClient("token", server.URL).fetchRepos(repos, opts)
+
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 1)
+
+	assert.Equal(t, 4321, rate.Remaining)
+	assert.Equal(t, 5000, rate.Limit)
+	assert.Equal(t, time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC), rate.ResetAt)
+
+	query := (*requests)[0].body["query"].(string)
+	assert.Contains(t, query, `repo0: repository(owner: "wtfutil", name: "wtf")`)
+	assert.Contains(t, query, `repo1: repository(owner: "wtfutil", name: "missing")`)
+	assert.Contains(t, query, `repo0Query0: search(type: ISSUE, first: 5, query: "is:open label:bug repo:wtfutil/wtf")`)
+	assert.Contains(t, query, "mergeStateStatus")
+
+	repo := repos[0]
+	assert.NoError(t, repo.Err)
+	assert.Equal(t, 9000, repo.StarCount())
+	assert.Equal(t, 40, repo.IssueCount())
+	assert.Equal(t, 2, repo.PullRequestCount())
+	assert.Equal(t, "clean", repo.PullRequests[0].GetMergeableState())
+	assert.Equal(t, "ghost", repo.PullRequests[1].GetUser().GetLogin())
+	assert.Len(t, repo.myReviewRequests("me"), 1)
+	assert.Len(t, repo.myPullRequests("senorprogrammer"), 1)
+	assert.Equal(t, map[int]string{7: checkFailure}, repo.CheckStatuses)
+	assert.Equal(t, 3, repo.CustomQueryResults["is:open label:bug"].Issues[0].GetNumber())
+
+	assert.EqualError(t, repos[1].Err, "GitHub GraphQL API: Could not resolve to a Repository with the name 'wtfutil/missing'.")
+}
+
+func Test_fetchRepos_Unauthorized(t *testing.T) {
+	server, _ := fakeGitHub(t, map[string]string{})
+	defer server.Close()
+
+	_, err := newGraphQLClient("token", server.URL).fetchRepos([]*Repo{testRepo(server)}, graphQLOptions{})
+
+	assert.Error(t, err)
+}
+
+func Test_buildReposQuery(t *testing.T) {
+	query := buildReposQuery([]*Repo{NewGithubRepo(`w"tf`, "wtfutil", "", "", "")}, graphQLOptions{})
+
+	assert.Contains(t, query, `repository(owner: "wtfutil", name: "w\"tf")`)
+	assert.NotContains(t, query, "mergeStateStatus")
+	assert.NotContains(t, query, "statusCheckRollup")
+	assert.NotContains(t, query, "search(")
+}
+
+func Test_graphQLURL(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		expected string
+	}{
+		{baseURL: "", expected: "https://api.github.com/graphql"},
+		{baseURL: "https://github.example.com/api/v3/", expected: "https://github.example.com/api/graphql"},
+		{baseURL: "https://github.example.com/api/v3", expected: "https://github.example.com/api/graphql"},
+		{baseURL: "https://github.example.com/", expected: "https://github.example.com/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseURL, func(t *testing.T) {
+			assert.Equal(t, tt.expected, graphQLURL(tt.baseURL))
+		})
+	}
+}
+
+func Test_refreshDelay(t *testing.T) {
+	now := time.Date(2020, 8, 1, 11, 0, 0, 0, time.UTC)
+	resetAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		rate     rateLimit
+		cost     int
+		expected time.Duration
+	}{
+		{
+			name:     "before the first query",
+			rate:     rateLimit{},
+			cost:     0,
+			expected: 0,
+		},
+		{
+			name:     "with plenty of points left",
+			rate:     rateLimit{Limit: 5000, Remaining: 4000, ResetAt: resetAt},
+			cost:     3,
+			expected: 0,
+		},
+		{
+			name:     "with few points left",
+			rate:     rateLimit{Limit: 5000, Remaining: 30, ResetAt: resetAt},
+			cost:     3,
+			expected: 6 * time.Minute,
+		},
+		{
+			name:     "with too few points left for another refresh",
+			rate:     rateLimit{Limit: 5000, Remaining: 2, ResetAt: resetAt},
+			cost:     3,
+			expected: time.Hour,
+		},
+		{
+			name:     "after the limit has reset",
+			rate:     rateLimit{Limit: 5000, Remaining: 2, ResetAt: now.Add(-time.Minute)},
+			cost:     3,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rate.refreshDelay(now, 500, tt.cost))
+		})
+	}
+}
@@ -14,6 +14,10 @@ func (widget *Widget) initializeKeyboardControls() {
 	widget.SetKeyboardChar("o", widget.openRepo, "Open item in browser")
 	widget.SetKeyboardChar("p", widget.openPulls, "Open pull requests in browser")
 	widget.SetKeyboardChar("i", widget.openIssues, "Open issues in browser")
+	widget.SetKeyboardChar("a", widget.approvePr, "Approve the selected PR")
+	widget.SetKeyboardChar("x", widget.requestChangesPr, "Request changes on the selected PR")
+	widget.SetKeyboardChar("c", widget.commentPr, "Comment on the selected PR")
+	widget.SetKeyboardChar("m", widget.mergePr, "Merge the selected PR")
 
 	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
 	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
@@ -8,8 +8,9 @@ import (
 )
 
 const (
-	defaultFocusable = true
-	defaultTitle     = "GitHub"
+	defaultFocusable          = true
+	defaultRateLimitThreshold = 500
+	defaultTitle              = "GitHub"
 )
 
 // Settings defines the configuration properties for this module
@@ -20,7 +21,9 @@ type Settings struct {
 	baseURL                string        `help:"Your GitHub Enterprise API URL." optional:"true"`
 	customQueries          []customQuery `help:"Custom queries allow you to filter pull requests and issues however you like. Give the query a title and a filter. Filters can be copied directly from GitHub’s UI." optional:"true"`
 	enableStatus           bool          `help:"Display pull request mergeability status (‘dirty’, ‘clean’, ‘unstable’, ‘blocked’)." optional:"true"`
+	rateLimitThreshold     int           `help:"When fewer GraphQL API points than this remain, refreshes slow down so that the rest last until the rate limit resets." values:"A positive integer, 0..n." optional:"true"`
 	repositories           []string      `help:"A list of github repositories." values:"Example: wtfutil/wtf"`
+	showChecks             bool          `help:"Show the status of the CI check runs of each pull request" optional:"true"`
 	showMyPullRequests     bool          `help:"Show my pull requests section" optional:"true"`
 	showOpenReviewRequests bool          `help:"Show open review requests section" optional:"true"`
 	showStats              bool          `help:"Show repository stats section" optional:"true"`
@@ -42,6 +45,8 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 		apiKey:                 ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_GITHUB_TOKEN"))),
 		baseURL:                ymlConfig.UString("baseURL", os.Getenv("WTF_GITHUB_BASE_URL")),
 		enableStatus:           ymlConfig.UBool("enableStatus", false),
+		rateLimitThreshold:     ymlConfig.UInt("rateLimitThreshold", defaultRateLimitThreshold),
+		showChecks:             ymlConfig.UBool("showChecks", true),
 		showMyPullRequests:     ymlConfig.UBool("showMyPullRequests", true),
 		showOpenReviewRequests: ymlConfig.UBool("showOpenReviewRequests", true),
 		showStats:              ymlConfig.UBool("showStats", true),
@@ -3,8 +3,10 @@ package github
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -17,10 +19,14 @@ type Widget struct {
 
 	GithubRepos []*Repo
 
-	settings *Settings
-	Selected int
-	maxItems int
-	Items    []int
+	cache       *cache.Cache
+	client      *graphQLClient
+	rateLimit   rateLimit
+	refreshCost int
+	settings    *Settings
+	Selected    int
+	maxItems    int
+	Items       []int
 }
 
 // NewWidget creates a new instance of the widget
@@ -30,6 +36,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		MultiSourceWidget: view.NewMultiSourceWidget(settings.common, "repository", "repositories"),
 		TextWidget:        view.NewTextWidget(app, settings.common),
 
+		cache:    cache.NewCache(settings.common),
+		client:   newGraphQLClient(settings.apiKey, settings.baseURL),
 		settings: settings,
 	}
 
@@ -96,13 +104,47 @@ func (widget *Widget) Unselect() {
 
 // Refresh reloads the github data via the Github API and reruns the display
 func (widget *Widget) Refresh() {
+	if widget.loadCachedRepos() {
+		// Display the cached repos while they refresh
+		widget.display()
+	}
+
+	widget.fetchRepos()
+
+	var err error
+	var staleSince time.Time
+
 	for _, repo := range widget.GithubRepos {
-		repo.Refresh()
+		if repo.Err == nil {
+			repo.StoreCache(widget.cache)
+			continue
+		}
+
+		if err == nil {
+			err = repo.Err
+		}
+
+		// Keep displaying the repo's last data until it refreshes successfully
+		if storedAt, ok := repo.LoadCache(widget.cache); ok && (staleSince.IsZero() || storedAt.Before(staleSince)) {
+			staleSince = storedAt
+		}
+	}
+
+	widget.SetRefreshError(err)
+	if !staleSince.IsZero() {
+		widget.SetStaleSince(staleSince)
 	}
 
 	widget.display()
 }
 
+// RefreshDelay returns how long the scheduler waits before the next refresh, if that is
+// longer than the refresh interval. Refreshing less often when the rate limit is running
+// low keeps the widget working until it resets, instead of failing for the rest of the hour
+func (widget *Widget) RefreshDelay() time.Duration {
+	return widget.refreshDelay()
+}
+
 // HelpText displays the widgets controls
 func (widget *Widget) HelpText() string {
 	return widget.KeyboardWidget.HelpText()
@@ -130,6 +172,70 @@ func (widget *Widget) buildRepoCollection(repoData []string) []*Repo {
 	return githubRepos
 }
 
+// fetchRepos fetches the data of every repo from the GraphQL API, a batch of repos
+// per query
+func (widget *Widget) fetchRepos() {
+	opts := graphQLOptions{
+		customQueries: widget.settings.customQueries,
+		enableStatus:  widget.settings.enableStatus,
+		showChecks:    widget.settings.showChecks,
+	}
+
+	cost := 0
+
+	for start := 0; start < len(widget.GithubRepos); start += graphQLBatchSize {
+		end := start + graphQLBatchSize
+		if end > len(widget.GithubRepos) {
+			end = len(widget.GithubRepos)
+		}
+
+		batch := widget.GithubRepos[start:end]
+
+		rate, err := widget.client.fetchRepos(batch, opts)
+		if err != nil {
+			for _, repo := range batch {
+				repo.Err = err
+			}
+			continue
+		}
+
+		widget.rateLimit = rate
+		cost += rate.Cost
+	}
+
+	widget.refreshCost = cost
+}
+
+// refreshDelay returns how long to wait between refreshes to make the rate limit last
+// until it resets. It is zero until the rate limit runs low
+func (widget *Widget) refreshDelay() time.Duration {
+	return widget.rateLimit.refreshDelay(time.Now(), widget.settings.rateLimitThreshold, widget.refreshCost)
+}
+
+// loadCachedRepos loads the cached data of the repos that have not been refreshed yet,
+// marking the widget as stale since the oldest of them. It returns TRUE if any were loaded
+func (widget *Widget) loadCachedRepos() bool {
+	var staleSince time.Time
+
+	for _, repo := range widget.GithubRepos {
+		if repo.RemoteRepo != nil {
+			continue
+		}
+
+		if storedAt, ok := repo.LoadCache(widget.cache); ok && (staleSince.IsZero() || storedAt.Before(staleSince)) {
+			staleSince = storedAt
+		}
+	}
+
+	if staleSince.IsZero() {
+		return false
+	}
+
+	widget.SetStaleSince(staleSince)
+
+	return true
+}
+
 func (widget *Widget) currentGithubRepo() *Repo {
 	if len(widget.GithubRepos) == 0 {
 		return nil
@@ -0,0 +1,66 @@
+package gitlab
+
+import "fmt"
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedMergeRequest returns the project being displayed and the IID of the highlighted
+// merge request. It returns FALSE if no merge request is highlighted
+func (widget *Widget) selectedMergeRequest() (*GitlabProject, int, bool) {
+	project := widget.currentGitlabProject()
+	if project == nil || project.context == nil || widget.Selected < 0 || widget.Selected >= len(widget.Items) {
+		return nil, 0, false
+	}
+
+	item := widget.Items[widget.Selected]
+	if item.Type != "MR" {
+		return nil, 0, false
+	}
+
+	return project, item.ID, true
+}
+
+func (widget *Widget) approveMergeRequest() {
+	project, iid, ok := widget.selectedMergeRequest()
+	if !ok {
+		return
+	}
+
+	go func() {
+		widget.ReportAction(fmt.Sprintf("Approved !%d", iid), project.Approve(iid), widget.RequestRefresh)
+	}()
+}
+
+func (widget *Widget) unapproveMergeRequest() {
+	project, iid, ok := widget.selectedMergeRequest()
+	if !ok {
+		return
+	}
+
+	go func() {
+		widget.ReportAction(fmt.Sprintf("Withdrew approval of !%d", iid), project.Unapprove(iid), widget.RequestRefresh)
+	}()
+}
+
+// retryPipeline retries the failed pipeline of the highlighted merge request or, if no
+// merge request is highlighted, of the project's default branch
+func (widget *Widget) retryPipeline() {
+	project := widget.currentGitlabProject()
+	if project == nil || project.context == nil {
+		return
+	}
+
+	pipeline := project.DefaultBranchPipeline
+	if _, iid, ok := widget.selectedMergeRequest(); ok {
+		pipeline = project.MergeRequestPipelines[iid]
+	}
+
+	if !pipeline.Failed() {
+		widget.ShowMessage(" There is no failed pipeline to retry")
+		return
+	}
+
+	go func() {
+		widget.ReportAction(fmt.Sprintf("Retrying pipeline #%d", pipeline.ID), project.RetryPipeline(pipeline), widget.RequestRefresh)
+	}()
+}
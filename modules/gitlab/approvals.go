@@ -0,0 +1,74 @@
+package gitlab
+
+import (
+	glb "github.com/xanzy/go-gitlab"
+)
+
+// Approve approves the merge request as the current user
+func (project *GitlabProject) Approve(iid int) error {
+	_, _, err := project.context.client.MergeRequestApprovals.ApproveMergeRequest(project.path, iid, &glb.ApproveMergeRequestOptions{})
+
+	return err
+}
+
+// Unapprove withdraws the current user's approval of the merge request
+func (project *GitlabProject) Unapprove(iid int) error {
+	_, err := project.context.client.MergeRequestApprovals.UnapproveMergeRequest(project.path, iid)
+
+	return err
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// approvedBy returns TRUE if username has approved the merge request
+func approvedBy(approvals *glb.MergeRequestApprovals, username string) bool {
+	for _, approver := range approvals.ApprovedBy {
+		if approver.User != nil && approver.User.Username == username {
+			return true
+		}
+	}
+
+	return false
+}
+
+// awaitsApprovalFrom returns TRUE if the merge request needs more approvals, username is
+// one of the people who can approve it, and they haven't yet
+func awaitsApprovalFrom(approvals *glb.MergeRequestApprovals, username string) bool {
+	if approvals.ApprovalsLeft == 0 || approvedBy(approvals, username) {
+		return false
+	}
+
+	for _, approver := range approvals.Approvers {
+		if approver.User != nil && approver.User.Username == username {
+			return true
+		}
+	}
+
+	for _, approver := range approvals.SuggestedApprovers {
+		if approver.Username == username {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadApprovals loads the approvals of each open merge request, and finds those that are
+// waiting for username to approve them
+func (project *GitlabProject) loadApprovals(username string) {
+	project.Approvals = map[int]*glb.MergeRequestApprovals{}
+	project.AwaitingApprovalMergeRequests = []*glb.MergeRequest{}
+
+	for _, mr := range project.MergeRequests {
+		approvals, _, err := project.context.client.MergeRequestApprovals.GetConfiguration(project.path, mr.IID)
+		if err != nil {
+			continue
+		}
+
+		project.Approvals[mr.IID] = approvals
+
+		if awaitsApprovalFrom(approvals, username) {
+			project.AwaitingApprovalMergeRequests = append(project.AwaitingApprovalMergeRequests, mr)
+		}
+	}
+}
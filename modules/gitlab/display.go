@@ -3,6 +3,7 @@ package gitlab
 import (
 	"fmt"
 
+	"github.com/rivo/tview"
 	"github.com/xanzy/go-gitlab"
 )
 
@@ -43,6 +44,9 @@ func (widget *Widget) content() (string, string, bool) {
 	str += fmt.Sprintf(" [%s]Stats[white]\n", widget.settings.common.Colors.Subheading)
 	str += widget.displayStats(project)
 	str += "\n"
+	str += fmt.Sprintf(" [%s]Awaiting My Approval[white]\n", widget.settings.common.Colors.Subheading)
+	str += widget.displayAwaitingApprovalMergeRequests(project, widget.approverName())
+	str += "\n"
 	str += fmt.Sprintf(" [%s]Open Assigned Merge Requests[white]\n", widget.settings.common.Colors.Subheading)
 	str += widget.displayMyAssignedMergeRequests(project, widget.settings.username)
 	str += "\n"
@@ -58,14 +62,19 @@ func (widget *Widget) content() (string, string, bool) {
 	return title, str, false
 }
 
+func (widget *Widget) displayAwaitingApprovalMergeRequests(project *GitlabProject, username string) string {
+	mrs := project.awaitingApprovalMergeRequests()
+	return widget.renderMergeRequests(project, mrs, username)
+}
+
 func (widget *Widget) displayMyMergeRequests(project *GitlabProject, username string) string {
 	mrs := project.myMergeRequests(username)
-	return widget.renderMergeRequests(mrs, username)
+	return widget.renderMergeRequests(project, mrs, username)
 }
 
 func (widget *Widget) displayMyAssignedMergeRequests(project *GitlabProject, username string) string {
 	mrs := project.myAssignedMergeRequests(username)
-	return widget.renderMergeRequests(mrs, username)
+	return widget.renderMergeRequests(project, mrs, username)
 }
 
 func (widget *Widget) displayMyAssignedIssues(project *GitlabProject, username string) string {
@@ -78,7 +87,7 @@ func (widget *Widget) displayMyIssues(project *GitlabProject, username string) s
 	return widget.renderIssues(issues, username)
 }
 
-func (widget *Widget) renderMergeRequests(mrs []*gitlab.MergeRequest, username string) string {
+func (widget *Widget) renderMergeRequests(project *GitlabProject, mrs []*gitlab.MergeRequest, username string) string {
 
 	length := len(mrs)
 
@@ -88,10 +97,20 @@ func (widget *Widget) renderMergeRequests(mrs []*gitlab.MergeRequest, username s
 	maxItems := widget.GetItemCount()
 
 	str := ""
-	for idx, issue := range mrs {
-		str += fmt.Sprintf(` [green]["%d"]%4d[""][white] %s`, maxItems+idx, issue.IID, issue.Title)
+	for idx, mr := range mrs {
+		pipeline := project.MergeRequestPipelines[mr.IID]
+
+		str += fmt.Sprintf(
+			` %s[green]["%d"]%4d[""][white] %s%s`,
+			pipelineIcon(pipeline),
+			maxItems+idx,
+			mr.IID,
+			tview.Escape(mr.Title),
+			approvalsString(project.Approvals[mr.IID]),
+		)
 		str += "\n"
-		widget.Items = append(widget.Items, ContentItem{Type: "MR", ID: issue.IID})
+		str += failedJobsString(pipeline)
+		widget.Items = append(widget.Items, ContentItem{Type: "MR", ID: mr.IID})
 	}
 	widget.SetItemCount(maxItems + length)
 
@@ -126,9 +145,76 @@ func (widget *Widget) displayStats(project *GitlabProject) string {
 		project.StarCount(),
 	)
 
+	if pipeline := project.DefaultBranchPipeline; pipeline != nil {
+		str += fmt.Sprintf(
+			" Pipeline: %s%s [%s]%s[white]\n",
+			pipelineIcon(pipeline),
+			tview.Escape(project.RemoteProject.DefaultBranch),
+			pipelineColor(pipeline.Status),
+			pipeline.Status,
+		)
+		str += failedJobsString(pipeline)
+	}
+
 	return str
 }
 
+// pipelineColors are the colors pipeline statuses are displayed in. Other statuses,
+// such as skipped and canceled, are displayed in gray
+var pipelineColors = map[string]string{
+	"created": "yellow",
+	"failed":  "red",
+	"pending": "yellow",
+	"running": "yellow",
+	"success": "green",
+}
+
+// approvalsString returns the number of approvals a merge request has, out of the number
+// it needs, if it needs any
+func approvalsString(approvals *gitlab.MergeRequestApprovals) string {
+	if approvals == nil || approvals.ApprovalsRequired == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		" [gray](%d/%d approvals)[white]",
+		approvals.ApprovalsRequired-approvals.ApprovalsLeft,
+		approvals.ApprovalsRequired,
+	)
+}
+
+// failedJobsString lists the failed jobs of a pipeline, one per line
+func failedJobsString(pipeline *Pipeline) string {
+	if !pipeline.Failed() {
+		return ""
+	}
+
+	str := ""
+	for _, job := range pipeline.FailedJobs {
+		str += fmt.Sprintf("        [red]\u2717 %s[white]\n", tview.Escape(job))
+	}
+
+	return str
+}
+
+// pipelineIcon returns a colored dot for the status of a pipeline, or blank space if there
+// is no pipeline
+func pipelineIcon(pipeline *Pipeline) string {
+	if pipeline == nil {
+		return "  "
+	}
+
+	return fmt.Sprintf("[%s]\u25cf[white] ", pipelineColor(pipeline.Status))
+}
+
+func pipelineColor(status string) string {
+	if color, ok := pipelineColors[status]; ok {
+		return color
+	}
+
+	return "gray"
+}
+
 func (widget *Widget) title(project *GitlabProject) string {
 	return fmt.Sprintf("[green]%s [white]", project.path)
 }
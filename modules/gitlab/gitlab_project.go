@@ -1,6 +1,9 @@
 package gitlab
 
 import (
+	"time"
+
+	"github.com/wtfutil/wtf/cache"
 	glb "github.com/xanzy/go-gitlab"
 )
 
@@ -31,31 +34,105 @@ type GitlabProject struct {
 	context *context
 	path    string
 
-	MergeRequests         []*glb.MergeRequest
-	AssignedMergeRequests []*glb.MergeRequest
-	AuthoredMergeRequests []*glb.MergeRequest
-	AssignedIssues        []*glb.Issue
-	AuthoredIssues        []*glb.Issue
-	RemoteProject         *glb.Project
+	MergeRequests                 []*glb.MergeRequest
+	AssignedMergeRequests         []*glb.MergeRequest
+	AuthoredMergeRequests         []*glb.MergeRequest
+	AwaitingApprovalMergeRequests []*glb.MergeRequest
+	AssignedIssues                []*glb.Issue
+	AuthoredIssues                []*glb.Issue
+	RemoteProject                 *glb.Project
+
+	Approvals             map[int]*glb.MergeRequestApprovals
+	DefaultBranchPipeline *Pipeline
+	MergeRequestPipelines map[int]*Pipeline
+}
+
+// cachedProject is the data of a project that is cached between runs
+type cachedProject struct {
+	MergeRequests                 []*glb.MergeRequest
+	AssignedMergeRequests         []*glb.MergeRequest
+	AuthoredMergeRequests         []*glb.MergeRequest
+	AwaitingApprovalMergeRequests []*glb.MergeRequest
+	AssignedIssues                []*glb.Issue
+	AuthoredIssues                []*glb.Issue
+	RemoteProject                 *glb.Project
+
+	Approvals             map[int]*glb.MergeRequestApprovals
+	DefaultBranchPipeline *Pipeline
+	MergeRequestPipelines map[int]*Pipeline
 }
 
 func NewGitlabProject(context *context, projectPath string) *GitlabProject {
 	project := GitlabProject{
 		context: context,
 		path:    projectPath,
+
+		Approvals:             map[int]*glb.MergeRequestApprovals{},
+		MergeRequestPipelines: map[int]*Pipeline{},
 	}
 
 	return &project
 }
 
-// Refresh reloads the gitlab data via the Gitlab API
-func (project *GitlabProject) Refresh() {
+// Refresh reloads the gitlab data via the Gitlab API. username is the user whose approval
+// merge requests can be waiting for
+func (project *GitlabProject) Refresh(username string) {
 	project.MergeRequests, _ = project.loadMergeRequests()
 	project.AssignedMergeRequests, _ = project.loadAssignedMergeRequests()
 	project.AuthoredMergeRequests, _ = project.loadAuthoredMergeRequests()
 	project.AssignedIssues, _ = project.loadAssignedIssues()
 	project.AuthoredIssues, _ = project.loadAuthoredIssues()
 	project.RemoteProject, _ = project.loadRemoteProject()
+
+	project.loadApprovals(username)
+	project.loadPipelines(project.MergeRequests)
+}
+
+// LoadCache replaces the project's data with the data cached by the last successful
+// refresh. It returns when that data was fetched, and FALSE if nothing was cached
+func (project *GitlabProject) LoadCache(cache *cache.Cache) (time.Time, bool) {
+	cached := cachedProject{}
+
+	storedAt, ok := cache.Load(project.path, &cached)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	project.MergeRequests = cached.MergeRequests
+	project.AssignedMergeRequests = cached.AssignedMergeRequests
+	project.AuthoredMergeRequests = cached.AuthoredMergeRequests
+	project.AssignedIssues = cached.AssignedIssues
+	project.AuthoredIssues = cached.AuthoredIssues
+	project.RemoteProject = cached.RemoteProject
+
+	project.AwaitingApprovalMergeRequests = cached.AwaitingApprovalMergeRequests
+	project.DefaultBranchPipeline = cached.DefaultBranchPipeline
+	if cached.Approvals != nil {
+		project.Approvals = cached.Approvals
+	}
+	if cached.MergeRequestPipelines != nil {
+		project.MergeRequestPipelines = cached.MergeRequestPipelines
+	}
+
+	return storedAt, true
+}
+
+// StoreCache caches the project's data so that it can be displayed before the first
+// refresh the next time WTF starts
+func (project *GitlabProject) StoreCache(cache *cache.Cache) {
+	cache.Store(project.path, cachedProject{
+		MergeRequests:         project.MergeRequests,
+		AssignedMergeRequests: project.AssignedMergeRequests,
+		AuthoredMergeRequests: project.AuthoredMergeRequests,
+		AssignedIssues:        project.AssignedIssues,
+		AuthoredIssues:        project.AuthoredIssues,
+		RemoteProject:         project.RemoteProject,
+
+		AwaitingApprovalMergeRequests: project.AwaitingApprovalMergeRequests,
+		Approvals:                     project.Approvals,
+		DefaultBranchPipeline:         project.DefaultBranchPipeline,
+		MergeRequestPipelines:         project.MergeRequestPipelines,
+	})
 }
 
 /* -------------------- Counts -------------------- */
@@ -93,6 +170,12 @@ func (project *GitlabProject) myAssignedMergeRequests(username string) []*glb.Me
 	return project.AssignedMergeRequests
 }
 
+// awaitingApprovalMergeRequests returns a list of open merge requests that are waiting
+// for the approval of the user the project was refreshed for
+func (project *GitlabProject) awaitingApprovalMergeRequests() []*glb.MergeRequest {
+	return project.AwaitingApprovalMergeRequests
+}
+
 // myAssignedIssues returns a list of issues for which username has been assigned
 func (project *GitlabProject) myAssignedIssues(username string) []*glb.Issue {
 	return project.AssignedIssues
@@ -0,0 +1,123 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitLab starts a fake self-hosted GitLab that replies to each method and path with the
+// matching response, and records the requests it receives
+func fakeGitLab(t *testing.T, responses map[string]string) (*httptest.Server, *[]string) {
+	requests := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.Path
+		requests = append(requests, request)
+
+		response, ok := responses[request]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+func testProject(t *testing.T, server *httptest.Server) *GitlabProject {
+	ctx, err := newContext(&Settings{apiKey: "token", domain: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewGitlabProject(ctx, "wtfutil/wtf")
+}
+
+func Test_Refresh(t *testing.T) {
+	server, _ := fakeGitLab(t, map[string]string{
+		"GET /api/v4/user":                                    `{"id": 1, "username": "me"}`,
+		"GET /api/v4/projects/wtfutil/wtf":                    `{"id": 42, "default_branch": "master", "web_url": "https://gitlab.example.com/wtfutil/wtf"}`,
+		"GET /api/v4/projects/wtfutil/wtf/issues":             `[]`,
+		"GET /api/v4/projects/wtfutil/wtf/merge_requests":     `[{"iid": 7, "title": "Add a widget"}, {"iid": 8, "title": "Fix a widget"}]`,
+		"GET /api/v4/projects/wtfutil/wtf/pipelines":          `[{"id": 100, "status": "failed", "ref": "master"}]`,
+		"GET /api/v4/projects/wtfutil/wtf/pipelines/100/jobs": `[{"id": 1, "name": "rspec", "stage": "test", "status": "failed"}]`,
+
+		"GET /api/v4/projects/wtfutil/wtf/merge_requests/7/approvals": `{
+			"approvals_required": 2, "approvals_left": 1,
+			"approved_by": [{"user": {"username": "sam"}}],
+			"suggested_approvers": [{"username": "me"}]
+		}`,
+		"GET /api/v4/projects/wtfutil/wtf/merge_requests/8/approvals": `{
+			"approvals_required": 1, "approvals_left": 0,
+			"approved_by": [{"user": {"username": "me"}}],
+			"suggested_approvers": [{"username": "me"}]
+		}`,
+		"GET /api/v4/projects/wtfutil/wtf/merge_requests/7/pipelines": `[{"id": 102, "status": "running"}, {"id": 101, "status": "failed"}]`,
+		"GET /api/v4/projects/wtfutil/wtf/merge_requests/8/pipelines": `[{"id": 103, "status": "success"}]`,
+	})
+	defer server.Close()
+
+	project := testProject(t, server)
+	project.Refresh("me")
+
+	assert.Equal(t, "master", project.RemoteProject.DefaultBranch)
+
+	// Only the merge request that still needs an approval, and not from someone else, waits for me
+	assert.Len(t, project.AwaitingApprovalMergeRequests, 1)
+	assert.Equal(t, 7, project.AwaitingApprovalMergeRequests[0].IID)
+	assert.Equal(t, " [gray](1/2 approvals)[white]", approvalsString(project.Approvals[7]))
+
+	assert.True(t, project.DefaultBranchPipeline.Failed())
+	assert.Equal(t, []string{"test: rspec"}, project.DefaultBranchPipeline.FailedJobs)
+
+	// The latest pipeline of a merge request is the one that counts
+	assert.Equal(t, 102, project.MergeRequestPipelines[7].ID)
+	assert.Equal(t, "running", project.MergeRequestPipelines[7].Status)
+	assert.Equal(t, "success", project.MergeRequestPipelines[8].Status)
+}
+
+func Test_Approve(t *testing.T) {
+	server, requests := fakeGitLab(t, map[string]string{
+		"GET /api/v4/user": `{"id": 1, "username": "me"}`,
+		"POST /api/v4/projects/wtfutil/wtf/merge_requests/7/approve":   `{"approvals_left": 0}`,
+		"POST /api/v4/projects/wtfutil/wtf/merge_requests/7/unapprove": `{}`,
+	})
+	defer server.Close()
+
+	project := testProject(t, server)
+	*requests = nil
+
+	assert.NoError(t, project.Approve(7))
+	assert.NoError(t, project.Unapprove(7))
+	assert.Error(t, project.Approve(8))
+
+	assert.Equal(t, []string{
+		"POST /api/v4/projects/wtfutil/wtf/merge_requests/7/approve",
+		"POST /api/v4/projects/wtfutil/wtf/merge_requests/7/unapprove",
+		"POST /api/v4/projects/wtfutil/wtf/merge_requests/8/approve",
+	}, *requests)
+}
+
+func Test_RetryPipeline(t *testing.T) {
+	server, requests := fakeGitLab(t, map[string]string{
+		"GET /api/v4/user": `{"id": 1, "username": "me"}`,
+		"POST /api/v4/projects/wtfutil/wtf/pipelines/100/retry": `{"id": 100, "status": "pending"}`,
+	})
+	defer server.Close()
+
+	project := testProject(t, server)
+	*requests = nil
+
+	assert.NoError(t, project.RetryPipeline(&Pipeline{ID: 100, Status: "failed"}))
+	assert.Error(t, project.RetryPipeline(&Pipeline{ID: 101, Status: "success"}))
+
+	assert.Equal(t, []string{
+		"POST /api/v4/projects/wtfutil/wtf/pipelines/100/retry",
+	}, *requests)
+}
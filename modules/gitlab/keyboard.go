@@ -14,6 +14,9 @@ func (widget *Widget) initializeKeyboardControls() {
 	widget.SetKeyboardChar("o", widget.openRepo, "Open item in browser")
 	widget.SetKeyboardChar("p", widget.openPulls, "Open merge requests in browser")
 	widget.SetKeyboardChar("i", widget.openIssues, "Open issues in browser")
+	widget.SetKeyboardChar("a", widget.approveMergeRequest, "Approve the selected merge request")
+	widget.SetKeyboardChar("u", widget.unapproveMergeRequest, "Unapprove the selected merge request")
+	widget.SetKeyboardChar("t", widget.retryPipeline, "Retry the failed pipeline")
 
 	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
 	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
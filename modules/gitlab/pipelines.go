@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"fmt"
+
+	glb "github.com/xanzy/go-gitlab"
+)
+
+// Pipeline is the latest CI pipeline of a branch or merge request
+type Pipeline struct {
+	ID         int
+	Status     string
+	WebURL     string
+	FailedJobs []string
+}
+
+// Failed returns TRUE if the pipeline failed, and so can be retried
+func (pipeline *Pipeline) Failed() bool {
+	return pipeline != nil && pipeline.Status == string(glb.Failed)
+}
+
+// RetryPipeline retries the failed jobs of the pipeline
+func (project *GitlabProject) RetryPipeline(pipeline *Pipeline) error {
+	if !pipeline.Failed() {
+		return fmt.Errorf("pipeline #%d has not failed", pipeline.ID)
+	}
+
+	_, _, err := project.context.client.Pipelines.RetryPipelineBuild(project.path, pipeline.ID)
+
+	return err
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loadPipelines loads the latest pipeline of the default branch and of each of the merge
+// requests
+func (project *GitlabProject) loadPipelines(mrs []*glb.MergeRequest) {
+	project.DefaultBranchPipeline = nil
+	project.MergeRequestPipelines = map[int]*Pipeline{}
+
+	if project.RemoteProject != nil && project.RemoteProject.DefaultBranch != "" {
+		opts := &glb.ListProjectPipelinesOptions{
+			ListOptions: glb.ListOptions{PerPage: 1},
+			Ref:         glb.String(project.RemoteProject.DefaultBranch),
+		}
+
+		pipelines, _, err := project.context.client.Pipelines.ListProjectPipelines(project.path, opts)
+		if err == nil && len(pipelines) > 0 {
+			project.DefaultBranchPipeline = project.loadPipeline(pipelines[0])
+		}
+	}
+
+	for _, mr := range mrs {
+		// Merge request pipelines are listed newest first
+		pipelines, _, err := project.context.client.MergeRequests.ListMergeRequestPipelines(project.path, mr.IID)
+		if err != nil || len(pipelines) == 0 {
+			continue
+		}
+
+		project.MergeRequestPipelines[mr.IID] = project.loadPipeline(pipelines[0])
+	}
+}
+
+// loadPipeline loads the failed jobs of a pipeline, if it failed
+func (project *GitlabProject) loadPipeline(info *glb.PipelineInfo) *Pipeline {
+	pipeline := &Pipeline{
+		ID:         info.ID,
+		Status:     info.Status,
+		WebURL:     info.WebURL,
+		FailedJobs: []string{},
+	}
+
+	if !pipeline.Failed() {
+		return pipeline
+	}
+
+	opts := &glb.ListJobsOptions{Scope: []glb.BuildStateValue{glb.Failed}}
+
+	jobs, _, err := project.context.client.Jobs.ListPipelineJobs(project.path, info.ID, opts)
+	if err != nil {
+		return pipeline
+	}
+
+	for _, job := range jobs {
+		pipeline.FailedJobs = append(pipeline.FailedJobs, fmt.Sprintf("%s: %s", job.Stage, job.Name))
+	}
+
+	return pipeline
+}
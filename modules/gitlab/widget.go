@@ -2,8 +2,10 @@ package gitlab
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -20,6 +22,7 @@ type Widget struct {
 
 	GitlabProjects []*GitlabProject
 
+	cache    *cache.Cache
 	context  *context
 	settings *Settings
 	Selected int
@@ -38,6 +41,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		MultiSourceWidget: view.NewMultiSourceWidget(settings.common, "repository", "repositories"),
 		TextWidget:        view.NewTextWidget(app, settings.common),
 
+		cache:    cache.NewCache(settings.common),
 		context:  context,
 		settings: settings,
 
@@ -61,13 +65,39 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
+	if widget.loadCachedProjects() {
+		// Display the cached projects while they refresh
+		widget.display()
+	}
+
 	if widget.context == nil || widget.configError != nil {
-		widget.displayError()
+		// Without a connection to GitLab the cached projects are the best there is
+		if widget.StaleSince().IsZero() {
+			widget.displayError()
+		}
+
 		return
 	}
 
+	var staleSince time.Time
+
 	for _, project := range widget.GitlabProjects {
-		project.Refresh()
+		project.Refresh(widget.approverName())
+
+		// Loading a project's details only fails if GitLab can't be reached
+		if project.RemoteProject != nil {
+			project.StoreCache(widget.cache)
+			continue
+		}
+
+		if storedAt, ok := project.LoadCache(widget.cache); ok && (staleSince.IsZero() || storedAt.Before(staleSince)) {
+			staleSince = storedAt
+		}
+	}
+
+	widget.SetRefreshError(nil)
+	if !staleSince.IsZero() {
+		widget.SetStaleSince(staleSince)
 	}
 
 	widget.display()
@@ -122,6 +152,20 @@ func (widget *Widget) Unselect() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// approverName returns the username of the user whose approval merge requests can be
+// waiting for: the configured username, or else the owner of the API token
+func (widget *Widget) approverName() string {
+	if widget.settings.username != "" {
+		return widget.settings.username
+	}
+
+	if widget.context != nil && widget.context.user != nil {
+		return widget.context.user.Username
+	}
+
+	return ""
+}
+
 func (widget *Widget) buildProjectCollection(context *context, projectData []string) []*GitlabProject {
 	gitlabProjects := []*GitlabProject{}
 
@@ -133,6 +177,30 @@ func (widget *Widget) buildProjectCollection(context *context, projectData []str
 	return gitlabProjects
 }
 
+// loadCachedProjects loads the cached data of the projects that have not been refreshed
+// yet, marking the widget as stale since the oldest of them. It returns TRUE if any were loaded
+func (widget *Widget) loadCachedProjects() bool {
+	var staleSince time.Time
+
+	for _, project := range widget.GitlabProjects {
+		if project.RemoteProject != nil {
+			continue
+		}
+
+		if storedAt, ok := project.LoadCache(widget.cache); ok && (staleSince.IsZero() || storedAt.Before(staleSince)) {
+			staleSince = storedAt
+		}
+	}
+
+	if staleSince.IsZero() {
+		return false
+	}
+
+	widget.SetStaleSince(staleSince)
+
+	return true
+}
+
 func (widget *Widget) currentGitlabProject() *GitlabProject {
 	if len(widget.GitlabProjects) == 0 {
 		return nil
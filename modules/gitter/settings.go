@@ -17,7 +17,7 @@ type Settings struct {
 
 	apiToken         string `help:"Your Gitter Personal Access Token."`
 	numberOfMessages int    `help:"Maximum number of (newest) messages to be displayed. Default is 10" optional:"true"`
-	roomURI          string `help:"The room you want to display." values:"Example: wtfutil/Lobby"`
+	roomURI          string `help:"The room you want to display." values:"Example: wtfutil/Lobby" yaml:"roomUri"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
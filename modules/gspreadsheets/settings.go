@@ -18,10 +18,10 @@ type Settings struct {
 	colors
 	common *cfg.Common
 
-	cellAddresses []interface{}
-	cellNames     []interface{}
+	cellAddresses []interface{} `yaml:"cells"`
+	cellNames     []interface{} `yaml:"cells"`
 	secretFile    string
-	sheetID       string
+	sheetID       string `yaml:"sheetId"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
@@ -3,8 +3,10 @@ package jenkins
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -13,9 +15,13 @@ type Widget struct {
 	view.KeyboardWidget
 	view.ScrollableWidget
 
+	notifier *notify.Notifier
 	settings *Settings
 	view     *View
 	err      error
+
+	// results is the result of each job's last build, as of the last refresh
+	results map[string]string
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
@@ -23,6 +29,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
 		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
 
+		notifier: notify.NewNotifier(settings.common),
 		settings: settings,
 	}
 
@@ -54,6 +61,7 @@ func (widget *Widget) Refresh() {
 		widget.SetItemCount(0)
 	} else {
 		widget.SetItemCount(len(widget.view.Jobs))
+		widget.notifier.Publish(widget.jobEvents(widget.view.Jobs)...)
 	}
 
 	widget.Render()
@@ -92,6 +100,40 @@ func (widget *Widget) content() (string, string, bool) {
 	return title, str, false
 }
 
+// jobEvents returns an event for each job whose last build failed, and for each job whose
+// last build passed after the one before it failed. Jobs that are building have the result
+// of the build before, and jobs that are disabled or have never been built have no event
+func (widget *Widget) jobEvents(jobs []Job) []notify.Event {
+	events := []notify.Event{}
+	results := map[string]string{}
+
+	for _, job := range jobs {
+		jobName, _ := url.QueryUnescape(job.Name)
+		event := notify.Event{Key: job.Name, Message: job.Url}
+
+		// A job that is building has the color of its last build, animated
+		result := strings.TrimSuffix(job.Color, "_anime")
+		results[job.Name] = result
+
+		switch {
+		case result == "red":
+			event.Type = notify.BuildFailed
+			event.Title = fmt.Sprintf("%s: %s failed", widget.CommonSettings().Title, jobName)
+		case result == "blue" && widget.results[job.Name] == "red":
+			event.Type = notify.BuildFixed
+			event.Title = fmt.Sprintf("%s: %s passed", widget.CommonSettings().Title, jobName)
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	widget.results = results
+
+	return events
+}
+
 func (widget *Widget) jobColor(job Job) string {
 	switch job.Color {
 	case "blue":
@@ -0,0 +1,40 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/notify"
+	"github.com/wtfutil/wtf/view"
+)
+
+func Test_jobEvents(t *testing.T) {
+	widget := &Widget{
+		ScrollableWidget: view.NewScrollableWidget(nil, &cfg.Common{Title: "Jenkins"}),
+	}
+
+	// refresh returns the events for a refresh that finds the jobs with the given colors
+	refresh := func(colors ...string) map[string]notify.EventType {
+		jobs := []Job{}
+		for idx, color := range colors {
+			jobs = append(jobs, Job{Name: string(rune('a' + idx)), Color: color})
+		}
+
+		events := map[string]notify.EventType{}
+		for _, event := range widget.jobEvents(jobs) {
+			events[event.Key] = event.Type
+		}
+
+		return events
+	}
+
+	assert.Equal(t, map[string]notify.EventType{"b": notify.BuildFailed}, refresh("blue", "red", "disabled"))
+
+	// Building jobs keep the result of their last build
+	assert.Equal(t, map[string]notify.EventType{"b": notify.BuildFailed}, refresh("blue_anime", "red_anime", "disabled"))
+
+	// Only a job that goes from failing to passing is fixed
+	assert.Equal(t, map[string]notify.EventType{"b": notify.BuildFixed}, refresh("blue", "blue", "blue"))
+	assert.Equal(t, map[string]notify.EventType{}, refresh("blue", "blue", "blue"))
+}
@@ -0,0 +1,160 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// detailTimeLength is the length of the date and time at the start of Jira's timestamps,
+// i.e.: 2020-08-01T12:00 of 2020-08-01T12:00:00.000+0000
+const detailTimeLength = len("2006-01-02T15:04")
+
+/* -------------------- Unexported Functions -------------------- */
+
+// displayName returns the name to display for a user, or "Unassigned" if there is none
+func displayName(user *User) string {
+	if user == nil {
+		return "Unassigned"
+	}
+
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+
+	return user.Name
+}
+
+// issueDetail returns the text of the detail view of an issue: its status, people,
+// description and comments
+func issueDetail(issue *Issue) string {
+	fields := issue.IssueFields
+	if fields == nil {
+		return fmt.Sprintf(" [::b]%s[::-]\n", issue.Key)
+	}
+
+	str := fmt.Sprintf(" [::b]%s[::-] %s\n\n", issue.Key, tview.Escape(fields.Summary))
+
+	if fields.IssueStatus != nil {
+		str += fmt.Sprintf(" [yellow]Status:[white]   %s\n", tview.Escape(fields.IssueStatus.IName))
+	}
+	if fields.Project != nil {
+		str += fmt.Sprintf(" [yellow]Project:[white]  %s\n", tview.Escape(fields.Project.Name))
+	}
+	str += fmt.Sprintf(" [yellow]Assignee:[white] %s\n", tview.Escape(displayName(fields.Assignee)))
+	if fields.Reporter != nil {
+		str += fmt.Sprintf(" [yellow]Reporter:[white] %s\n", tview.Escape(displayName(fields.Reporter)))
+	}
+
+	description := strings.TrimSpace(fields.Description)
+	if description == "" {
+		description = "[gray]No description[white]"
+	} else {
+		description = tview.Escape(description)
+	}
+	str += fmt.Sprintf("\n%s\n", description)
+
+	if fields.Comment == nil || len(fields.Comment.Comments) == 0 {
+		return str
+	}
+
+	str += fmt.Sprintf("\n [yellow]Comments (%d)[white]\n", len(fields.Comment.Comments))
+	for _, comment := range fields.Comment.Comments {
+		created := comment.Created
+		if len(created) > detailTimeLength {
+			created = strings.Replace(created[:detailTimeLength], "T", " ", 1)
+		}
+
+		str += fmt.Sprintf("\n [green]%s[white] [gray]%s[white]\n%s\n", tview.Escape(displayName(comment.Author)), created, tview.Escape(strings.TrimSpace(comment.Body)))
+	}
+
+	return str
+}
+
+func (widget *Widget) assignItem() {
+	issue := widget.selectedIssue()
+	if issue == nil {
+		return
+	}
+
+	key := issue.Key
+
+	go func() {
+		widget.ReportAction("", widget.AssignToMe(key), widget.RequestRefresh)
+	}()
+}
+
+func (widget *Widget) commentItem() {
+	issue := widget.selectedIssue()
+	if issue == nil {
+		return
+	}
+
+	key := issue.Key
+
+	widget.ShowInput(fmt.Sprintf("Comment on %s", key), func(text string) {
+		go func() {
+			widget.ReportAction("", widget.AddComment(key, text), widget.RequestRefresh)
+		}()
+	})
+}
+
+// showItem displays the detail view of the highlighted issue
+func (widget *Widget) showItem() {
+	issue := widget.selectedIssue()
+	if issue == nil {
+		return
+	}
+
+	key := issue.Key
+
+	go func() {
+		detail, err := widget.Issue(key)
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		widget.ShowMessage(issueDetail(detail))
+	}()
+}
+
+// transitionItem lists the transitions that can be made on the highlighted issue, and
+// makes the one that is chosen
+func (widget *Widget) transitionItem() {
+	issue := widget.selectedIssue()
+	if issue == nil {
+		return
+	}
+
+	key := issue.Key
+
+	go func() {
+		transitions, err := widget.Transitions(key)
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		if len(transitions) == 0 {
+			widget.ShowMessage(fmt.Sprintf(" %s has no transitions available", key))
+			return
+		}
+
+		names := []string{}
+		for _, transition := range transitions {
+			name := transition.Name
+			if transition.To != nil && transition.To.IName != transition.Name {
+				name += " → " + transition.To.IName
+			}
+			names = append(names, tview.Escape(name))
+		}
+
+		widget.ShowList(fmt.Sprintf("Transition %s", key), names, func(idx int) {
+			go func() {
+				widget.ReportAction("", widget.Transition(key, transitions[idx].ID), widget.RequestRefresh)
+			}()
+		})
+	}()
+}
@@ -1,8 +1,11 @@
 package jira
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -10,6 +13,9 @@ import (
 	"github.com/wtfutil/wtf/utils"
 )
 
+// issueDetailFields are the fields fetched for the detail view of an issue
+const issueDetailFields = "summary,description,status,issuetype,project,assignee,reporter,comment"
+
 func (widget *Widget) IssuesFor(username string, projects []string, jql string) (*SearchResult, error) {
 	query := []string{}
 
@@ -32,10 +38,11 @@ func (widget *Widget) IssuesFor(username string, projects []string, jql string)
 
 	url := fmt.Sprintf("/rest/api/2/search?%s", v.Encode())
 
-	resp, err := widget.jiraRequest(url)
+	resp, err := widget.jiraRequest("GET", url, nil)
 	if err != nil {
 		return &SearchResult{}, err
 	}
+	defer func() { _ = resp.Body.Close() }()
 
 	searchResult := &SearchResult{}
 	err = utils.ParseJSON(searchResult, resp.Body)
@@ -46,20 +53,115 @@ func (widget *Widget) IssuesFor(username string, projects []string, jql string)
 	return searchResult, nil
 }
 
+// Issue fetches a single issue, with the fields displayed in its detail view
+func (widget *Widget) Issue(key string) (*Issue, error) {
+	resp, err := widget.jiraRequest("GET", fmt.Sprintf("/rest/api/2/issue/%s?fields=%s", url.PathEscape(key), issueDetailFields), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	issue := &Issue{}
+	err = utils.ParseJSON(issue, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+// Transitions returns the transitions the current user can make on the issue from its
+// current status
+func (widget *Widget) Transitions(key string) ([]Transition, error) {
+	resp, err := widget.jiraRequest("GET", fmt.Sprintf("/rest/api/2/issue/%s/transitions", url.PathEscape(key)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result := struct {
+		Transitions []Transition `json:"transitions"`
+	}{}
+	err = utils.ParseJSON(&result, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Transitions, nil
+}
+
+// Transition moves the issue through the transition with the ID
+func (widget *Widget) Transition(key, transitionID string) error {
+	body := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+
+	return widget.jiraUpdate("POST", fmt.Sprintf("/rest/api/2/issue/%s/transitions", url.PathEscape(key)), body)
+}
+
+// AssignToMe assigns the issue to the user the widget is authenticated as
+func (widget *Widget) AssignToMe(key string) error {
+	resp, err := widget.jiraRequest("GET", "/rest/api/2/myself", nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	myself := &User{}
+	err = utils.ParseJSON(myself, resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Jira Cloud assigns by account ID and Jira Server by username, and each returns only
+	// the one it uses
+	assignee := User{AccountID: myself.AccountID, Name: myself.Name}
+
+	return widget.jiraUpdate("PUT", fmt.Sprintf("/rest/api/2/issue/%s/assignee", url.PathEscape(key)), assignee)
+}
+
+// AddComment adds a comment to the issue
+func (widget *Widget) AddComment(key, text string) error {
+	body := map[string]string{"body": text}
+
+	return widget.jiraUpdate("POST", fmt.Sprintf("/rest/api/2/issue/%s/comment", url.PathEscape(key)), body)
+}
+
 func buildJql(key string, value string) string {
 	return fmt.Sprintf("%s = \"%s\"", key, value)
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) jiraRequest(path string) (*http.Response, error) {
+// jiraRequest makes a request to the Jira REST API. If body is not nil it is sent as JSON
+func (widget *Widget) jiraRequest(method, path string, body interface{}) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", widget.settings.domain, path)
 
-	req, err := http.NewRequest("GET", url, nil)
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(widget.settings.email, widget.settings.apiKey)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Jira Server and Data Center accept personal access tokens in place of a password.
+	// Jira Cloud takes an email address and an API token
+	if widget.settings.personalAccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+widget.settings.personalAccessToken)
+	} else {
+		req.SetBasicAuth(widget.settings.email, widget.settings.apiKey)
+	}
 
 	httpClient := &http.Client{Transport: &http.Transport{
 		TLSClientConfig: &tls.Config{
@@ -74,12 +176,24 @@ func (widget *Widget) jiraRequest(path string) (*http.Response, error) {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_ = resp.Body.Close()
 		return nil, fmt.Errorf(resp.Status)
 	}
 
 	return resp, nil
 }
 
+// jiraUpdate makes a request that changes something in Jira, and whose response has no
+// content that is needed
+func (widget *Widget) jiraUpdate(method, path string, body interface{}) error {
+	resp, err := widget.jiraRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
 func getProjectQuery(projects []string) string {
 	singleEmptyProject := len(projects) == 1 && len(projects[0]) == 0
 	if len(projects) == 0 || singleEmptyProject {
@@ -0,0 +1,193 @@
+package jira
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRequest is a request received by the fake Jira server
+type fakeRequest struct {
+	method        string
+	path          string
+	authorization string
+	body          map[string]interface{}
+}
+
+// fakeJira starts a fake Jira server that replies to each method and path with the matching
+// response, and records the requests it receives. Paths without a response get a 204
+func fakeJira(t *testing.T, responses map[string]string) (*httptest.Server, *[]fakeRequest) {
+	requests := []fakeRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := fakeRequest{method: r.Method, path: r.URL.Path, authorization: r.Header.Get("Authorization")}
+
+		raw, _ := ioutil.ReadAll(r.Body)
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &req.body); err != nil {
+				t.Errorf("invalid request body: %s", raw)
+			}
+		}
+
+		requests = append(requests, req)
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+func testWidget(server *httptest.Server) *Widget {
+	return &Widget{
+		settings: &Settings{
+			apiKey: "token",
+			domain: server.URL,
+			email:  "me@example.com",
+		},
+	}
+}
+
+func Test_IssuesFor(t *testing.T) {
+	server, requests := fakeJira(t, map[string]string{
+		"GET /rest/api/2/search": `{"total": 1, "issues": [{"key": "WTF-1", "fields": {"summary": "Fix it", "project": {"key": "WTF", "name": "WTF"}}}]}`,
+	})
+	defer server.Close()
+
+	result, err := testWidget(server).IssuesFor("me", []string{"WTF"}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WTF-1", result.Issues[0].Key)
+	assert.Equal(t, "WTF", result.Issues[0].IssueFields.Project.Name)
+
+	// Jira Cloud authenticates with the email address and an API token
+	assert.Equal(t, "Basic bWVAZXhhbXBsZS5jb206dG9rZW4=", (*requests)[0].authorization)
+}
+
+func Test_IssuesFor_PersonalAccessToken(t *testing.T) {
+	server, requests := fakeJira(t, map[string]string{
+		"GET /rest/api/2/search": `{"total": 0, "issues": []}`,
+	})
+	defer server.Close()
+
+	widget := testWidget(server)
+	widget.settings.personalAccessToken = "pat"
+
+	_, err := widget.IssuesFor("me", []string{}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer pat", (*requests)[0].authorization)
+}
+
+func Test_IssuesFor_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := testWidget(server).IssuesFor("me", []string{}, "")
+
+	assert.EqualError(t, err, "401 Unauthorized")
+}
+
+func Test_Issue(t *testing.T) {
+	server, _ := fakeJira(t, map[string]string{
+		"GET /rest/api/2/issue/WTF-1": `{
+			"key": "WTF-1",
+			"fields": {
+				"summary": "Fix it",
+				"description": "It is broken",
+				"status": {"name": "In Progress"},
+				"assignee": {"displayName": "Chris"},
+				"comment": {"total": 1, "comments": [{"author": {"displayName": "Sam"}, "body": "On it", "created": "2020-08-01T12:00:00.000+0000"}]}
+			}
+		}`,
+	})
+	defer server.Close()
+
+	issue, err := testWidget(server).Issue("WTF-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "It is broken", issue.IssueFields.Description)
+	assert.Equal(t, "Chris", issue.IssueFields.Assignee.DisplayName)
+	assert.Equal(t, "On it", issue.IssueFields.Comment.Comments[0].Body)
+}
+
+func Test_Transitions(t *testing.T) {
+	server, requests := fakeJira(t, map[string]string{
+		"GET /rest/api/2/issue/WTF-1/transitions": `{"transitions": [{"id": "21", "name": "Start", "to": {"name": "In Progress"}}, {"id": "31", "name": "Done", "to": {"name": "Done"}}]}`,
+	})
+	defer server.Close()
+
+	widget := testWidget(server)
+
+	transitions, err := widget.Transitions("WTF-1")
+	assert.NoError(t, err)
+	assert.Len(t, transitions, 2)
+	assert.Equal(t, "In Progress", transitions[0].To.IName)
+
+	err = widget.Transition("WTF-1", "21")
+	assert.NoError(t, err)
+
+	transition := (*requests)[1]
+	assert.Equal(t, "POST", transition.method)
+	assert.Equal(t, "/rest/api/2/issue/WTF-1/transitions", transition.path)
+	assert.Equal(t, map[string]interface{}{"transition": map[string]interface{}{"id": "21"}}, transition.body)
+}
+
+func Test_AssignToMe(t *testing.T) {
+	tests := []struct {
+		name     string
+		myself   string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "on Jira Cloud",
+			myself:   `{"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Chris"}`,
+			expected: map[string]interface{}{"accountId": "5b10ac8d82e05b22cc7d4ef5"},
+		},
+		{
+			name:     "on Jira Server",
+			myself:   `{"name": "chris", "displayName": "Chris"}`,
+			expected: map[string]interface{}{"name": "chris"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := fakeJira(t, map[string]string{
+				"GET /rest/api/2/myself": tt.myself,
+			})
+			defer server.Close()
+
+			err := testWidget(server).AssignToMe("WTF-1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, "PUT", (*requests)[1].method)
+			assert.Equal(t, "/rest/api/2/issue/WTF-1/assignee", (*requests)[1].path)
+			assert.Equal(t, tt.expected, (*requests)[1].body)
+		})
+	}
+}
+
+func Test_AddComment(t *testing.T) {
+	server, requests := fakeJira(t, map[string]string{
+		"POST /rest/api/2/issue/WTF-1/comment": `{"id": "10000", "body": "Looks good"}`,
+	})
+	defer server.Close()
+
+	err := testWidget(server).AddComment("WTF-1", "Looks good")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"body": "Looks good"}, (*requests)[0].body)
+}
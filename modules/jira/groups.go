@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"sort"
+)
+
+// groupName returns the name of the group the issue is listed under when the issues are
+// grouped by groupBy, which is either "status" or "project"
+func groupName(issue Issue, groupBy string) string {
+	fields := issue.IssueFields
+	if fields == nil {
+		return ""
+	}
+
+	switch groupBy {
+	case "project":
+		if fields.Project != nil {
+			return fields.Project.Name
+		}
+	case "status":
+		if fields.IssueStatus != nil {
+			return fields.IssueStatus.IName
+		}
+	}
+
+	return ""
+}
+
+// groupIssues orders the issues so that the issues in each group are listed together.
+// Groups are listed in the order their first issue was found in, and the issues in each
+// group keep the order they were found in
+func groupIssues(issues []Issue, groupBy string) []Issue {
+	grouped := append([]Issue{}, issues...)
+
+	if groupBy == "" {
+		return grouped
+	}
+
+	groupOrder := map[string]int{}
+	for _, issue := range issues {
+		name := groupName(issue, groupBy)
+		if _, ok := groupOrder[name]; !ok {
+			groupOrder[name] = len(groupOrder)
+		}
+	}
+
+	sort.SliceStable(grouped, func(i, j int) bool {
+		return groupOrder[groupName(grouped[i], groupBy)] < groupOrder[groupName(grouped[j], groupBy)]
+	})
+
+	return grouped
+}
+
+// displayedIssues returns the issues in the order they are displayed in
+func (widget *Widget) displayedIssues() []Issue {
+	if widget.result == nil {
+		return []Issue{}
+	}
+
+	return groupIssues(widget.result.Issues, widget.settings.groupBy)
+}
+
+// selectedIssue returns the highlighted issue, or nil if none is highlighted
+func (widget *Widget) selectedIssue() *Issue {
+	issues := widget.displayedIssues()
+
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(issues) {
+		return nil
+	}
+
+	return &issues[sel]
+}
@@ -0,0 +1,52 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIssue(key, status, project string) Issue {
+	return Issue{
+		Key: key,
+		IssueFields: &IssueFields{
+			IssueStatus: &IssueStatus{IName: status},
+			Project:     &Project{Name: project},
+		},
+	}
+}
+
+func Test_groupIssues(t *testing.T) {
+	issues := []Issue{
+		testIssue("WTF-1", "To Do", "WTF"),
+		testIssue("OPS-1", "In Progress", "Ops"),
+		testIssue("WTF-2", "In Progress", "WTF"),
+		testIssue("OPS-2", "To Do", "Ops"),
+	}
+
+	keys := func(issues []Issue) []string {
+		result := []string{}
+		for _, issue := range issues {
+			result = append(result, issue.Key)
+		}
+		return result
+	}
+
+	tests := []struct {
+		groupBy  string
+		expected []string
+	}{
+		{groupBy: "", expected: []string{"WTF-1", "OPS-1", "WTF-2", "OPS-2"}},
+		{groupBy: "status", expected: []string{"WTF-1", "OPS-2", "OPS-1", "WTF-2"}},
+		{groupBy: "project", expected: []string{"WTF-1", "WTF-2", "OPS-1", "OPS-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			assert.Equal(t, tt.expected, keys(groupIssues(issues, tt.groupBy)))
+		})
+	}
+
+	// The search result keeps its order
+	assert.Equal(t, "OPS-1", issues[1].Key)
+}
@@ -10,10 +10,15 @@ type Issue struct {
 }
 
 type IssueFields struct {
-	Summary string `json:"summary"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
 
-	IssueType   *IssueType   `json:"issuetype"`
-	IssueStatus *IssueStatus `json:"status"`
+	Assignee    *User          `json:"assignee"`
+	Comment     *IssueComments `json:"comment"`
+	IssueType   *IssueType     `json:"issuetype"`
+	IssueStatus *IssueStatus   `json:"status"`
+	Project     *Project       `json:"project"`
+	Reporter    *User          `json:"reporter"`
 }
 
 type IssueType struct {
@@ -30,3 +35,36 @@ type IssueStatus struct {
 	IDescription string `json:"description"`
 	IName        string `json:"name"`
 }
+
+type IssueComments struct {
+	Total    int            `json:"total"`
+	Comments []IssueComment `json:"comments"`
+}
+
+type IssueComment struct {
+	ID      string `json:"id"`
+	Author  *User  `json:"author"`
+	Body    string `json:"body"`
+	Created string `json:"created"`
+}
+
+type Project struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// Transition is a step in an issue's workflow that moves it to another status
+type Transition struct {
+	ID   string       `json:"id"`
+	Name string       `json:"name"`
+	To   *IssueStatus `json:"to"`
+}
+
+// User is a Jira account. Jira Cloud identifies accounts by AccountID, and Jira Server
+// by Name
+type User struct {
+	AccountID   string `json:"accountId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
@@ -10,9 +10,12 @@ func (widget *Widget) initializeKeyboardControls() {
 	widget.SetKeyboardChar("j", widget.Next, "Select next item")
 	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
 	widget.SetKeyboardChar("o", widget.openItem, "Open item in browser")
+	widget.SetKeyboardChar("t", widget.transitionItem, "Transition the selected item")
+	widget.SetKeyboardChar("a", widget.assignItem, "Assign the selected item to me")
+	widget.SetKeyboardChar("c", widget.commentItem, "Comment on the selected item")
 
 	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
 	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
-	widget.SetKeyboardKey(tcell.KeyEnter, widget.openItem, "Open item in browser")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.showItem, "Show item details")
 	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
 }
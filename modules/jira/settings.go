@@ -26,8 +26,10 @@ type Settings struct {
 	apiKey                  string   `help:"Your Jira API key (or password for basic auth)."`
 	domain                  string   `help:"Your Jira corporate domain."`
 	email                   string   `help:"The email address associated with your Jira account (or username for basic auth)."`
+	groupBy                 string   `help:"Groups the issues under a heading for each status or project." values:"status, project, or empty to not group them" optional:"true"`
 	jql                     string   `help:"Custom JQL to be appended to the search query." values:"See Search Jira like a boss with JQL for details." optional:"true"`
-	projects                []string `help:"An array of projects to get data from"`
+	personalAccessToken     string   `help:"A Jira Server or Data Center personal access token, used instead of email and apiKey." optional:"true"`
+	projects                []string `help:"An array of projects to get data from" yaml:"project"`
 	username                string   `help:"Your Jira username."`
 	verifyServerCertificate bool     `help:"Determines whether or not the server’s certificate chain and host name are verified." values:"true or false" optional:"true"`
 }
@@ -40,7 +42,9 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 		apiKey:                  ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_JIRA_API_KEY"))),
 		domain:                  ymlConfig.UString("domain"),
 		email:                   ymlConfig.UString("email"),
+		groupBy:                 ymlConfig.UString("groupBy"),
 		jql:                     ymlConfig.UString("jql"),
+		personalAccessToken:     ymlConfig.UString("personalAccessToken", os.Getenv("WTF_JIRA_PERSONAL_ACCESS_TOKEN")),
 		username:                ymlConfig.UString("username"),
 		verifyServerCertificate: ymlConfig.UBool("verifyServerCertificate", true),
 	}
@@ -4,14 +4,19 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cache"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
 
+// issuesCacheKey is the key the search result is cached under
+const issuesCacheKey = "issues"
+
 type Widget struct {
 	view.KeyboardWidget
 	view.ScrollableWidget
 
+	cache    *cache.Cache
 	result   *SearchResult
 	settings *Settings
 	err      error
@@ -22,6 +27,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
 		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
 
+		cache:    cache.NewCache(settings.common),
 		settings: settings,
 	}
 
@@ -37,12 +43,24 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
+	if widget.result == nil && widget.loadCachedIssues() {
+		// Display the cached issues while the search runs
+		widget.Render()
+	}
+
 	searchResult, err := widget.IssuesFor(
 		widget.settings.username,
 		widget.settings.projects,
 		widget.settings.jql,
 	)
 
+	if err != nil && widget.loadCachedIssues() {
+		// Keep displaying the last issues found until the search works again
+		widget.SetRefreshError(err)
+		widget.Render()
+		return
+	}
+
 	if err != nil {
 		widget.err = err
 		widget.result = nil
@@ -51,7 +69,11 @@ func (widget *Widget) Refresh() {
 		widget.err = nil
 		widget.result = searchResult
 		widget.SetItemCount(len(searchResult.Issues))
+
+		widget.cache.Store(issuesCacheKey, searchResult)
 	}
+
+	widget.SetRefreshError(err)
 	widget.Render()
 }
 
@@ -61,10 +83,27 @@ func (widget *Widget) Render() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// loadCachedIssues loads the issues found by the last successful search, if they are
+// cached, marked as stale. It returns TRUE if there were cached issues to load
+func (widget *Widget) loadCachedIssues() bool {
+	searchResult := &SearchResult{}
+
+	storedAt, ok := widget.cache.Load(issuesCacheKey, searchResult)
+	if !ok {
+		return false
+	}
+
+	widget.err = nil
+	widget.result = searchResult
+	widget.SetItemCount(len(searchResult.Issues))
+	widget.SetStaleSince(storedAt)
+
+	return true
+}
+
 func (widget *Widget) openItem() {
-	sel := widget.GetSelected()
-	if sel >= 0 && widget.result != nil && sel < len(widget.result.Issues) {
-		issue := &widget.result.Issues[sel]
+	issue := widget.selectedIssue()
+	if issue != nil {
 		utils.OpenFile(widget.settings.domain + "/browse/" + issue.Key)
 	}
 }
@@ -87,7 +126,14 @@ func (widget *Widget) content() (string, string, bool) {
 
 	longestIssueTypeLength, longestKeyLength, longestStatusNameLength := getLongestColumnLengths(widget.result.Issues)
 
-	for idx, issue := range widget.result.Issues {
+	group := ""
+
+	for idx, issue := range widget.displayedIssues() {
+		if widget.settings.groupBy != "" && (idx == 0 || groupName(issue, widget.settings.groupBy) != group) {
+			group = groupName(issue, widget.settings.groupBy)
+			str += fmt.Sprintf(" [%s]%s[white]\n", widget.settings.common.Colors.Subheading, tview.Escape(group))
+		}
+
 		row := fmt.Sprintf(
 			`[%s] [%s]%-*s[white] [green]%-*s[white] [yellow]%-*s[white] [%s]%s`,
 			widget.RowColor(idx),
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedPod returns the highlighted resource if it is a pod
+func (widget *Widget) selectedPod() *resource {
+	res := widget.selectedResource()
+	if res == nil || res.kind != kindPod {
+		return nil
+	}
+
+	return res
+}
+
+// deletePod deletes the highlighted pod once it's confirmed
+func (widget *Widget) deletePod() {
+	pod := widget.selectedPod()
+	if pod == nil {
+		return
+	}
+
+	text := fmt.Sprintf("Delete pod %s in %s?", tview.Escape(pod.name), tview.Escape(pod.namespace))
+
+	widget.ShowChoice(text, []string{"Delete", "Cancel"}, func(choice string) {
+		if choice != "Delete" {
+			return
+		}
+
+		go func() {
+			client, err := widget.getInstance()
+			if err == nil {
+				err = client.deletePod(pod.namespace, pod.name)
+			}
+
+			widget.ReportAction(fmt.Sprintf("Deleted pod %s", pod.name), err, widget.RequestRefresh)
+		}()
+	})
+}
+
+// restartDeployment rollout restarts the highlighted deployment once it's confirmed
+func (widget *Widget) restartDeployment() {
+	deployment := widget.selectedResource()
+	if deployment == nil || deployment.kind != kindDeployment {
+		return
+	}
+
+	text := fmt.Sprintf("Restart every pod of deployment %s in %s?", tview.Escape(deployment.name), tview.Escape(deployment.namespace))
+
+	widget.ShowChoice(text, []string{"Restart", "Cancel"}, func(choice string) {
+		if choice != "Restart" {
+			return
+		}
+
+		go func() {
+			client, err := widget.getInstance()
+			if err == nil {
+				err = client.restartDeployment(deployment.namespace, deployment.name, time.Now())
+			}
+
+			widget.ReportAction(fmt.Sprintf("Restarted deployment %s", deployment.name), err, widget.RequestRefresh)
+		}()
+	})
+}
+
+// showEvents displays the recent events of the highlighted pod
+func (widget *Widget) showEvents() {
+	pod := widget.selectedPod()
+	if pod == nil {
+		return
+	}
+
+	go func() {
+		client, err := widget.getInstance()
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		events, err := client.podEvents(pod.namespace, pod.name)
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		widget.ShowMessage(fmt.Sprintf(" [::b]%s[::-] events\n\n%s", tview.Escape(pod.name), tview.Escape(eventsString(events))))
+	}()
+}
+
+// showLogs displays the last lines of the highlighted pod's logs
+func (widget *Widget) showLogs() {
+	pod := widget.selectedPod()
+	if pod == nil {
+		return
+	}
+
+	go func() {
+		client, err := widget.getInstance()
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		logs, err := client.podLogs(pod.namespace, pod.name, int64(widget.settings.logLines))
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		logs = strings.TrimRight(logs, "\n")
+		if logs == "" {
+			logs = "No logs"
+		}
+
+		widget.ShowMessage(fmt.Sprintf(" [::b]%s[::-] logs\n\n%s", tview.Escape(pod.name), tview.Escape(logs)))
+	}()
+}
@@ -0,0 +1,22 @@
+package kubernetes
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeCommonControls(widget.Refresh)
+
+	widget.SetKeyboardChar("j", widget.Next, "Select next item")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
+	widget.SetKeyboardChar("l", widget.NextSource, "Show next namespace")
+	widget.SetKeyboardChar("h", widget.PrevSource, "Show previous namespace")
+	widget.SetKeyboardChar("e", widget.showEvents, "Show the selected pod's recent events")
+	widget.SetKeyboardChar("d", widget.deletePod, "Delete the selected pod")
+	widget.SetKeyboardChar("R", widget.restartDeployment, "Rollout restart the selected deployment")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
+	widget.SetKeyboardKey(tcell.KeyRight, widget.NextSource, "Show next namespace")
+	widget.SetKeyboardKey(tcell.KeyLeft, widget.PrevSource, "Show previous namespace")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.showLogs, "Show the selected pod's logs")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
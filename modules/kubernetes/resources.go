@@ -0,0 +1,252 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Kinds of the resources the widget lists
+const (
+	kindDeployment = "deployment"
+	kindNode       = "node"
+	kindPod        = "pod"
+)
+
+// eventCount is the number of recent events shown for a pod
+const eventCount = 20
+
+// resource is a row of the widget: a node, deployment or pod
+type resource struct {
+	kind      string
+	namespace string
+	name      string
+
+	// columns are the details displayed after the name, i.e.: a pod's status
+	columns string
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// getNodes returns the cluster's nodes and whether each one is ready
+func (client *clientInstance) getNodes() ([]resource, error) {
+	nodes, err := client.Client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []resource{}
+	for _, node := range nodes.Items {
+		var nodeStatus string
+		for _, condition := range node.Status.Conditions {
+			if condition.Reason == "KubeletReady" {
+				switch {
+				case condition.Status == "True":
+					nodeStatus = "Ready"
+				case condition.Reason == "False":
+					nodeStatus = "NotReady"
+				default:
+					nodeStatus = "Unknown"
+				}
+			}
+		}
+
+		resources = append(resources, resource{kind: kindNode, name: node.ObjectMeta.Name, columns: nodeStatus})
+	}
+
+	return resources, nil
+}
+
+// getDeployments returns the deployments in the namespaces, or in every namespace if
+// none are given, and how many of their replicas are ready
+func (client *clientInstance) getDeployments(namespaces []string) ([]resource, error) {
+	resources := []resource{}
+
+	for _, namespace := range listedNamespaces(namespaces) {
+		deployments, err := client.Client.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, deployment := range deployments.Items {
+			resources = append(resources, resource{
+				kind:      kindDeployment,
+				namespace: deployment.ObjectMeta.Namespace,
+				name:      deployment.ObjectMeta.Name,
+				columns:   deploymentColumns(deployment),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// getPods returns the pods in the namespaces, or in every namespace if none are given,
+// with their status, how many of their containers are ready and how often they restarted
+func (client *clientInstance) getPods(namespaces []string) ([]resource, error) {
+	resources := []resource{}
+
+	for _, namespace := range listedNamespaces(namespaces) {
+		pods, err := client.Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods.Items {
+			resources = append(resources, resource{
+				kind:      kindPod,
+				namespace: pod.ObjectMeta.Namespace,
+				name:      pod.ObjectMeta.Name,
+				columns:   podColumns(pod),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// podLogs returns the last lines of the logs of each of a pod's containers
+func (client *clientInstance) podLogs(namespace, name string, lines int64) (string, error) {
+	pod, err := client.Client.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return containerLogs(pod.Spec.Containers, func(container string) ([]byte, error) {
+		opts := &corev1.PodLogOptions{Container: container, TailLines: &lines}
+		return client.Client.CoreV1().Pods(namespace).GetLogs(name, opts).DoRaw()
+	}), nil
+}
+
+// containerLogs puts the logs of each container under a header with its name. A container
+// whose logs can't be fetched, i.e.: one that hasn't started yet, gets the error instead
+func containerLogs(containers []corev1.Container, fetch func(container string) ([]byte, error)) string {
+	sections := []string{}
+
+	for _, container := range containers {
+		logs := ""
+
+		raw, err := fetch(container.Name)
+		if err != nil {
+			logs = err.Error()
+		} else {
+			logs = strings.TrimRight(string(raw), "\n")
+		}
+
+		if logs == "" {
+			logs = "No logs"
+		}
+
+		sections = append(sections, fmt.Sprintf("==> %s <==\n%s", container.Name, logs))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// podEvents returns the most recent events about a pod, oldest first
+func (client *clientInstance) podEvents(namespace, name string) ([]corev1.Event, error) {
+	events, err := client.Client.CoreV1().Events(namespace).List(metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := events.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].LastTimestamp.Before(&items[j].LastTimestamp)
+	})
+
+	if len(items) > eventCount {
+		items = items[len(items)-eventCount:]
+	}
+
+	return items, nil
+}
+
+func (client *clientInstance) deletePod(namespace, name string) error {
+	return client.Client.CoreV1().Pods(namespace).Delete(name, &metav1.DeleteOptions{})
+}
+
+// restartDeployment replaces a deployment's pods the way kubectl rollout restart does, by
+// changing an annotation of its pod template
+func (client *clientInstance) restartDeployment(namespace, name string, now time.Time) error {
+	patch := fmt.Sprintf(
+		`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		now.Format(time.RFC3339),
+	)
+
+	_, err := client.Client.AppsV1().Deployments(namespace).Patch(name, types.StrategicMergePatchType, []byte(patch))
+	return err
+}
+
+// listedNamespaces returns the namespaces to list resources in. The empty namespace
+// lists them in every namespace
+func listedNamespaces(namespaces []string) []string {
+	if len(namespaces) == 0 {
+		return []string{""}
+	}
+
+	return namespaces
+}
+
+func deploymentColumns(deployment appsv1.Deployment) string {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	return fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, desired)
+}
+
+// podColumns returns the ready/total containers, the status and the restart count of a
+// pod. A container that is waiting, i.e.: in CrashLoopBackOff, overrides the pod's phase
+func podColumns(pod corev1.Pod) string {
+	ready := 0
+	restarts := int32(0)
+	status := string(pod.Status.Phase)
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Ready {
+			ready++
+		}
+
+		restarts += container.RestartCount
+
+		if waiting := container.State.Waiting; waiting != nil && waiting.Reason != "" {
+			status = waiting.Reason
+		}
+	}
+
+	if pod.ObjectMeta.DeletionTimestamp != nil {
+		status = "Terminating"
+	}
+
+	return fmt.Sprintf("%d/%-3d %-18s %d", ready, len(pod.Spec.Containers), status, restarts)
+}
+
+// eventsString formats the events of a pod for display, one per line
+func eventsString(events []corev1.Event) string {
+	if len(events) == 0 {
+		return "No recent events"
+	}
+
+	lines := []string{}
+	for _, event := range events {
+		lines = append(lines, fmt.Sprintf(
+			"%s  %-8s %-16s %s",
+			event.LastTimestamp.Format("15:04:05"),
+			event.Type,
+			event.Reason,
+			strings.TrimSpace(event.Message),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
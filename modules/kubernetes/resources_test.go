@@ -0,0 +1,139 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testClient(objects ...runtime.Object) *clientInstance {
+	return &clientInstance{Client: fake.NewSimpleClientset(objects...)}
+}
+
+func testPod(namespace, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: true, RestartCount: 2},
+				{Name: "sidecar", Ready: false, RestartCount: 1},
+			},
+		},
+	}
+}
+
+func testDeployment(namespace, name string) *appsv1.Deployment {
+	replicas := int32(3)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+}
+
+func Test_getPods(t *testing.T) {
+	client := testClient(testPod("ns1", "web"), testPod("ns2", "worker"))
+
+	pods, err := client.getPods([]string{"ns1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+	assert.Equal(t, resource{kind: kindPod, namespace: "ns1", name: "web", columns: "1/2   Running            3"}, pods[0])
+
+	pods, err = client.getPods(nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+}
+
+func Test_podColumns_Waiting(t *testing.T) {
+	pod := testPod("ns1", "web")
+	pod.Status.ContainerStatuses[1].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+
+	assert.Equal(t, "1/2   CrashLoopBackOff   3", podColumns(*pod))
+}
+
+func Test_getDeployments(t *testing.T) {
+	client := testClient(testDeployment("ns1", "web"))
+
+	deployments, err := client.getDeployments([]string{"ns1", "ns2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []resource{{kind: kindDeployment, namespace: "ns1", name: "web", columns: "2/3"}}, deployments)
+}
+
+func Test_podEvents(t *testing.T) {
+	event := func(name, reason string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "ns1", Name: name},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web"},
+			Reason:         reason,
+			Type:           "Warning",
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+	client := testClient(
+		event("web.2", "BackOff", now),
+		event("web.1", "Pulled", now.Add(-time.Minute)),
+	)
+
+	events, err := client.podEvents("ns1", "web")
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "Pulled", events[0].Reason)
+	assert.Equal(t, "BackOff", events[1].Reason)
+	assert.Contains(t, eventsString(events), "Warning  BackOff")
+}
+
+func Test_containerLogs(t *testing.T) {
+	pod := testPod("default", "web")
+
+	fetched := []string{}
+	logs := containerLogs(pod.Spec.Containers, func(container string) ([]byte, error) {
+		fetched = append(fetched, container)
+
+		if container == "sidecar" {
+			return nil, errors.New("container sidecar is waiting to start")
+		}
+		return []byte("listening on :8080\n"), nil
+	})
+
+	assert.Equal(t, []string{"app", "sidecar"}, fetched)
+	assert.Equal(t, "==> app <==\nlistening on :8080\n\n==> sidecar <==\ncontainer sidecar is waiting to start", logs)
+}
+
+func Test_deletePod(t *testing.T) {
+	client := testClient(testPod("ns1", "web"))
+
+	assert.NoError(t, client.deletePod("ns1", "web"))
+
+	pods, err := client.getPods([]string{"ns1"})
+	assert.NoError(t, err)
+	assert.Empty(t, pods)
+}
+
+func Test_restartDeployment(t *testing.T) {
+	client := testClient(testDeployment("ns1", "web"))
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, client.restartDeployment("ns1", "web", now))
+
+	deployment, err := client.Client.AppsV1().Deployments("ns1").Get("web", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2020-08-01T12:00:00Z", deployment.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"])
+}
@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	defaultFocusable = false
+	defaultFocusable = true
 	defaultTitle     = "Kubernetes"
 )
 
@@ -17,7 +17,8 @@ type Settings struct {
 	objects    []string `help:"Kubernetes objects to show. Options are: [nodes, pods, deployments]."`
 	title      string   `help:"Override the title of widget."`
 	kubeconfig string   `help:"Location of a kubeconfig file."`
-	namespaces []string `help:"List of namespaces to watch. If blank, defaults to all namespaces."`
+	logLines   int      `help:"The number of lines of a pod's logs to show." optional:"true"`
+	namespaces []string `help:"List of namespaces to watch, one at a time. If blank, defaults to all namespaces."`
 	context    string   `help:"Kubernetes context to use. If blank, uses default context"`
 }
 
@@ -29,6 +30,7 @@ func NewSettingsFromYAML(name string, moduleConfig *config.Config, globalConfig
 		objects:    utils.ToStrs(moduleConfig.UList("objects")),
 		title:      moduleConfig.UString("title"),
 		kubeconfig: moduleConfig.UString("kubeconfig"),
+		logLines:   moduleConfig.UInt("logLines", 50),
 		namespaces: utils.ToStrs(moduleConfig.UList("namespaces")),
 		context:    moduleConfig.UString("context"),
 	}
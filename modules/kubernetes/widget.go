@@ -6,14 +6,16 @@ import (
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
-
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Widget contains all the config for the widget
 type Widget struct {
-	view.TextWidget
+	view.KeyboardWidget
+	view.MultiSourceWidget
+	view.ScrollableWidget
 
+	err        error
+	resources  []resource
 	objects    []string
 	title      string
 	kubeconfig string
@@ -23,9 +25,11 @@ type Widget struct {
 }
 
 // NewWidget creates a new instance of the widget
-func NewWidget(app *tview.Application, settings *Settings) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		TextWidget: view.NewTextWidget(app, settings.common),
+		KeyboardWidget:    view.NewKeyboardWidget(app, pages, settings.common),
+		MultiSourceWidget: view.NewMultiSourceWidget(settings.common, "namespace", "namespaces"),
+		ScrollableWidget:  view.NewScrollableWidget(app, settings.common),
 
 		objects:    settings.objects,
 		title:      settings.title,
@@ -35,63 +39,72 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 		context:    settings.context,
 	}
 
-	widget.View.SetWrap(true)
+	// Without namespaces to cycle through, every namespace is displayed at once
+	if len(widget.Sources) == 0 {
+		widget.Sources = []string{""}
+	}
+
+	widget.SetDisplayFunction(widget.showNamespace)
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
 
 	return &widget
 }
 
 // Refresh executes the command and updates the view with the results
 func (widget *Widget) Refresh() {
-	title := widget.generateTitle()
 	client, err := widget.getInstance()
-
 	if err != nil {
-		widget.Redraw(func() (string, string, bool) { return title, err.Error(), true })
+		widget.err = err
+		widget.Render()
 		return
 	}
 
-	var content string
+	resources := []resource{}
 
 	if utils.Includes(widget.objects, "nodes") {
-		nodeList, nodeError := client.getNodes()
-		if nodeError != nil {
-			widget.Redraw(func() (string, string, bool) { return title, "[red] Error getting node data [white]\n", true })
+		nodes, err := client.getNodes()
+		if err != nil {
+			widget.err = fmt.Errorf("error getting node data: %w", err)
+			widget.Render()
 			return
 		}
-		content += fmt.Sprintf("[%s]Nodes[white]\n", widget.settings.common.Colors.Subheading)
-		for _, node := range nodeList {
-			content += fmt.Sprintf("%s\n", node)
-		}
-		content += "\n"
+		resources = append(resources, nodes...)
 	}
 
 	if utils.Includes(widget.objects, "deployments") {
-		deploymentList, deploymentError := client.getDeployments(widget.namespaces)
-		if deploymentError != nil {
-			widget.Redraw(func() (string, string, bool) { return title, "[red] Error getting deployment data [white]\n", true })
+		deployments, err := client.getDeployments(widget.namespaces)
+		if err != nil {
+			widget.err = fmt.Errorf("error getting deployment data: %w", err)
+			widget.Render()
 			return
 		}
-		content += fmt.Sprintf("[%s]Deployments[white]\n", widget.settings.common.Colors.Subheading)
-		for _, deployment := range deploymentList {
-			content += fmt.Sprintf("%s\n", deployment)
-		}
-		content += "\n"
+		resources = append(resources, deployments...)
 	}
 
 	if utils.Includes(widget.objects, "pods") {
-		podList, podError := client.getPods(widget.namespaces)
-		if podError != nil {
-			widget.Redraw(func() (string, string, bool) { return title, "[red] Error getting pod data [white]\n", false })
+		pods, err := client.getPods(widget.namespaces)
+		if err != nil {
+			widget.err = fmt.Errorf("error getting pod data: %w", err)
+			widget.Render()
 			return
 		}
-		content += fmt.Sprintf("[%s]Pods[white]\n", widget.settings.common.Colors.Subheading)
-		for _, pod := range podList {
-			content += fmt.Sprintf("%s\n", pod)
-		}
-		content += "\n"
+		resources = append(resources, pods...)
 	}
 
-	widget.Redraw(func() (string, string, bool) { return title, content, false })
+	widget.err = nil
+	widget.resources = resources
+
+	widget.Render()
+}
+
+// Render displays the resources of the current namespace
+func (widget *Widget) Render() {
+	widget.SetItemCount(len(widget.displayedResources()))
+	widget.Redraw(widget.content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -107,109 +120,86 @@ func (widget *Widget) generateTitle() string {
 		title = fmt.Sprintf("%s (%s)", title, widget.context)
 	}
 
-	if len(widget.namespaces) == 1 {
-		title += fmt.Sprintf(" - Namespace: %s", widget.namespaces[0])
-	} else if len(widget.namespaces) > 1 {
-		title += fmt.Sprintf(" - Namespaces: %q", widget.namespaces)
+	if namespace := widget.CurrentSource(); namespace != "" {
+		title += fmt.Sprintf(" - Namespace: %s", namespace)
+	}
+
+	if len(widget.Sources) > 1 {
+		title += fmt.Sprintf(" (%d/%d)", widget.Idx+1, len(widget.Sources))
 	}
+
 	return title
 }
 
-// getPods returns a slice of pod strings
-func (client *clientInstance) getPods(namespaces []string) ([]string, error) {
-	var podList []string
-	if len(namespaces) != 0 {
-		for _, namespace := range namespaces {
-			pods, err := client.Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-			if err != nil {
-				return nil, err
-			}
+// displayedResources returns the resources of the current namespace, in the order they
+// are displayed. Nodes don't belong to a namespace, so they are always displayed
+func (widget *Widget) displayedResources() []resource {
+	namespace := widget.CurrentSource()
 
-			for _, pod := range pods.Items {
-				var podString string
-				status := pod.Status.Phase
-				name := pod.ObjectMeta.Name
-				if len(namespaces) == 1 {
-					podString = fmt.Sprintf("%-50s %s", name, status)
-				} else {
-					podString = fmt.Sprintf("%-20s %-50s %s", namespace, name, status)
-				}
-				podList = append(podList, podString)
-			}
-		}
-	} else {
-		pods, err := client.Client.CoreV1().Pods("").List(metav1.ListOptions{})
-		if err != nil {
-			return nil, err
-		}
-		for _, pod := range pods.Items {
-			podString := fmt.Sprintf("%-20s %-50s %s", pod.ObjectMeta.Namespace, pod.ObjectMeta.Name, pod.Status.Phase)
-			podList = append(podList, podString)
+	displayed := []resource{}
+	for _, res := range widget.resources {
+		if namespace == "" || res.kind == kindNode || res.namespace == namespace {
+			displayed = append(displayed, res)
 		}
 	}
 
-	return podList, nil
+	return displayed
 }
 
-// get Deployments returns a string slice of pod strings
-func (client *clientInstance) getDeployments(namespaces []string) ([]string, error) {
-	var deploymentList []string
-	if len(namespaces) != 0 {
-		for _, namespace := range namespaces {
-			deployments, err := client.Client.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
-			if err != nil {
-				return nil, err
-			}
-
-			for _, deployment := range deployments.Items {
-				var deployString string
-				if len(namespaces) == 1 {
-					deployString = fmt.Sprintf("%-50s", deployment.ObjectMeta.Name)
-				} else {
-					deployString = fmt.Sprintf("%-20s %-50s", deployment.ObjectMeta.Namespace, deployment.ObjectMeta.Name)
-				}
-				deploymentList = append(deploymentList, deployString)
-			}
-		}
-	} else {
-		deployments, err := client.Client.AppsV1().Deployments("").List(metav1.ListOptions{})
-		if err != nil {
-			return nil, err
-		}
+// selectedResource returns the highlighted resource, or nil if none is
+func (widget *Widget) selectedResource() *resource {
+	displayed := widget.displayedResources()
 
-		for _, deployment := range deployments.Items {
-			deployString := fmt.Sprintf("%-20s %-50s", deployment.ObjectMeta.Namespace, deployment.ObjectMeta.Name)
-			deploymentList = append(deploymentList, deployString)
-		}
+	idx := widget.GetSelected()
+	if idx < 0 || idx >= len(displayed) {
+		return nil
 	}
-	return deploymentList, nil
+
+	return &displayed[idx]
 }
 
-// getNodes returns a string slice of nodes
-func (client *clientInstance) getNodes() ([]string, error) {
-	var nodeList []string
+// showNamespace displays the namespace that was cycled to, with nothing selected
+func (widget *Widget) showNamespace() {
+	widget.Unselect()
+}
 
-	nodes, err := client.Client.CoreV1().Nodes().List(metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+func (widget *Widget) content() (string, string, bool) {
+	title := widget.generateTitle()
+
+	if widget.err != nil {
+		return title, fmt.Sprintf("[red]%s[white]", tview.Escape(widget.err.Error())), true
+	}
+
+	headings := map[string]string{
+		kindDeployment: "Deployments",
+		kindNode:       "Nodes",
+		kindPod:        "Pods",
 	}
 
-	for _, node := range nodes.Items {
-		var nodeStatus string
-		for _, condition := range node.Status.Conditions {
-			if condition.Reason == "KubeletReady" {
-				switch {
-				case condition.Status == "True":
-					nodeStatus = "Ready"
-				case condition.Reason == "False":
-					nodeStatus = "NotReady"
-				default:
-					nodeStatus = "Unknown"
-				}
+	// Namespaces are only shown when every namespace is displayed at once
+	showNamespace := widget.CurrentSource() == ""
+
+	var content string
+	kind := ""
+
+	for idx, res := range widget.displayedResources() {
+		if res.kind != kind {
+			if kind != "" {
+				content += "\n"
 			}
+
+			kind = res.kind
+			content += fmt.Sprintf("[%s]%s[white]\n", widget.settings.common.Colors.Subheading, headings[kind])
+		}
+
+		text := fmt.Sprintf("%-50s %s", res.name, res.columns)
+		if showNamespace && res.kind != kindNode {
+			text = fmt.Sprintf("%-20s %s", res.namespace, text)
 		}
-		nodeString := fmt.Sprintf("%-50s %s", node.ObjectMeta.Name, nodeStatus)
-		nodeList = append(nodeList, nodeString)
+
+		row := fmt.Sprintf("[%s]%s", widget.RowColor(idx), tview.Escape(text))
+		content += utils.HighlightableHelper(widget.View, row, idx, len(text))
 	}
-	return nodeList, nil
+
+	return title, content, false
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/view"
 )
 
 func Test_generateTitle(t *testing.T) {
@@ -25,6 +26,13 @@ func Test_generateTitle(t *testing.T) {
 			},
 			want: "Kube",
 		},
+		{
+			name: "All Namespaces",
+			fields: fields{
+				namespaces: []string{""},
+			},
+			want: "Kube",
+		},
 		{
 			name: "One Namespace",
 			fields: fields{
@@ -37,7 +45,7 @@ func Test_generateTitle(t *testing.T) {
 			fields: fields{
 				namespaces: []string{"ns1", "ns2"},
 			},
-			want: `Kube - Namespaces: ["ns1" "ns2"]`,
+			want: "Kube - Namespace: ns1 (1/2)",
 		},
 		{
 			name: "Explicit Title Set",
@@ -60,11 +68,41 @@ func Test_generateTitle(t *testing.T) {
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			widget := &Widget{
-				title:      tt.fields.title,
-				namespaces: tt.fields.namespaces,
-				context:    tt.fields.context,
+				MultiSourceWidget: view.MultiSourceWidget{Sources: tt.fields.namespaces},
+
+				title:   tt.fields.title,
+				context: tt.fields.context,
 			}
 			assert.Equal(t, tt.want, widget.generateTitle())
 		})
 	}
 }
+
+func Test_displayedResources(t *testing.T) {
+	widget := &Widget{
+		MultiSourceWidget: view.MultiSourceWidget{Sources: []string{"ns1", "ns2"}},
+
+		resources: []resource{
+			{kind: kindNode, name: "node1"},
+			{kind: kindPod, namespace: "ns1", name: "pod1"},
+			{kind: kindPod, namespace: "ns2", name: "pod2"},
+		},
+	}
+
+	names := func() []string {
+		names := []string{}
+		for _, res := range widget.displayedResources() {
+			names = append(names, res.name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"node1", "pod1"}, names())
+
+	widget.Idx = 1
+	assert.Equal(t, []string{"node1", "pod2"}, names())
+
+	widget.Sources = []string{""}
+	widget.Idx = 0
+	assert.Equal(t, []string{"node1", "pod1", "pod2"}, names())
+}
@@ -0,0 +1,67 @@
+package oncall
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedIncident returns the highlighted incident, or nil if none is
+func (widget *Widget) selectedIncident() *Incident {
+	idx := widget.GetSelected()
+	if idx < 0 || idx >= len(widget.incidents) {
+		return nil
+	}
+
+	incident := widget.incidents[idx]
+	return &incident
+}
+
+func (widget *Widget) acknowledgeIncident() {
+	incident := widget.selectedIncident()
+	if incident == nil || incident.Status != statusTriggered {
+		return
+	}
+
+	go func() {
+		widget.ReportAction(
+			fmt.Sprintf("Acknowledged %s", incident.Title),
+			incident.provider.Acknowledge(*incident),
+			widget.RequestRefresh,
+		)
+	}()
+}
+
+func (widget *Widget) openIncident() {
+	incident := widget.selectedIncident()
+	if incident != nil && incident.URL != "" {
+		utils.OpenFile(incident.URL)
+	}
+}
+
+// resolveIncident resolves the highlighted incident once it's confirmed
+func (widget *Widget) resolveIncident() {
+	incident := widget.selectedIncident()
+	if incident == nil {
+		return
+	}
+
+	text := fmt.Sprintf("Resolve %s on %s?", tview.Escape(incident.Title), tview.Escape(incident.provider.Name()))
+
+	widget.ShowChoice(text, []string{"Resolve", "Cancel"}, func(choice string) {
+		if choice != "Resolve" {
+			return
+		}
+
+		go func() {
+			widget.ReportAction(
+				fmt.Sprintf("Resolved %s", incident.Title),
+				incident.provider.Resolve(*incident),
+				widget.RequestRefresh,
+			)
+		}()
+	})
+}
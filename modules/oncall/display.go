@@ -0,0 +1,130 @@
+package oncall
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const shiftEndLayout = "Mon Jan 2 15:04"
+
+// statusLabels are the short labels shown in front of each incident
+var statusLabels = map[string]string{
+	statusAcknowledged: "ACK",
+	statusTriggered:    "NEW",
+}
+
+func (widget *Widget) content() (string, string, bool) {
+	title := widget.CommonSettings().Title
+
+	if len(widget.settings.providers) == 0 && len(widget.errs) == 0 {
+		return title, " No providers are configured", false
+	}
+
+	str := ""
+
+	for _, err := range widget.errs {
+		str += fmt.Sprintf(" [red]%s[white]\n", tview.Escape(err.Error()))
+	}
+
+	if widget.settings.showSchedules {
+		str += fmt.Sprintf(" [%s]On Call[white]\n", widget.settings.common.Colors.Subheading)
+		str += widget.shiftsContent()
+		str += "\n"
+	}
+
+	if widget.settings.showIncidents {
+		str += fmt.Sprintf(" [%s]Incidents[white]\n", widget.settings.common.Colors.Subheading)
+		str += widget.incidentsContent()
+	}
+
+	return title, str, false
+}
+
+func (widget *Widget) incidentsContent() string {
+	if len(widget.incidents) == 0 {
+		return " No open incidents\n"
+	}
+
+	str := ""
+
+	for idx, incident := range widget.incidents {
+		urgencyColor := "yellow"
+		if incident.Urgency == urgencyHigh {
+			urgencyColor = "red"
+		}
+
+		text := tview.Escape(incident.Title)
+		if incident.Service != "" {
+			text += fmt.Sprintf(" [gray](%s)", tview.Escape(incident.Service))
+		}
+
+		row := fmt.Sprintf(
+			"[%s] [%s]%-3s[%s] %s",
+			widget.RowColor(idx),
+			urgencyColor,
+			statusLabels[incident.Status],
+			widget.RowColor(idx),
+			text,
+		)
+
+		str += utils.HighlightableHelper(widget.View, row, idx, len(incident.Title)+len(incident.Service)+3)
+	}
+
+	return str
+}
+
+func (widget *Widget) shiftsContent() string {
+	str := ""
+	count := 0
+
+	for _, provider := range widget.shifts {
+		for _, shift := range provider.shifts {
+			count++
+
+			str += fmt.Sprintf(
+				" [%s]%s [gray](%s)[white]\n",
+				widget.settings.common.Colors.Label,
+				tview.Escape(shift.Schedule),
+				tview.Escape(provider.provider),
+			)
+
+			now := widget.namesString(shift.Now)
+			if !shift.EndsAt.IsZero() {
+				now += fmt.Sprintf(" [gray]until %s[white]", shift.EndsAt.In(time.Local).Format(shiftEndLayout))
+			}
+			str += fmt.Sprintf("   Now:  %s\n", now)
+
+			if len(shift.Next) > 0 {
+				str += fmt.Sprintf("   Next: %s\n", widget.namesString(shift.Next))
+			}
+		}
+	}
+
+	if count == 0 {
+		return " No schedules\n"
+	}
+
+	return str
+}
+
+// namesString joins the names of the people on call, in bold if they are myName
+func (widget *Widget) namesString(names []string) string {
+	if len(names) == 0 {
+		return "[gray]nobody[white]"
+	}
+
+	escaped := []string{}
+	for _, name := range names {
+		if name == widget.settings.myName {
+			escaped = append(escaped, fmt.Sprintf("[::b]%s[::-]", tview.Escape(name)))
+		} else {
+			escaped = append(escaped, tview.Escape(name))
+		}
+	}
+
+	return strings.Join(escaped, ", ")
+}
@@ -0,0 +1,20 @@
+package oncall
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeCommonControls(widget.Refresh)
+
+	widget.SetKeyboardChar("j", widget.Next, "Select next incident")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous incident")
+	widget.SetKeyboardChar("o", widget.openIncident, "Open incident in browser")
+	widget.SetKeyboardChar("a", widget.acknowledgeIncident, "Acknowledge the selected incident")
+	widget.SetKeyboardChar("x", widget.resolveIncident, "Resolve the selected incident")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next incident")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous incident")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openIncident, "Open incident in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,107 @@
+package oncall
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/modules/opsgenie"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// opsGenie is an OpsGenie account, reached through the opsgenie module's client
+type opsGenie struct {
+	client *opsgenie.Client
+
+	name                   string
+	scheduleIdentifierType string
+	schedules              []string
+}
+
+func newOpsGenie(ymlConfig *config.Config) *opsGenie {
+	return &opsGenie{
+		client: opsgenie.NewClient(ymlConfig.UString("apiKey"), ymlConfig.UString("region", "us")),
+
+		name:                   ymlConfig.UString("name", "OpsGenie"),
+		scheduleIdentifierType: ymlConfig.UString("scheduleIdentifierType", "id"),
+		schedules:              utils.ToStrs(ymlConfig.UList("schedules")),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (og *opsGenie) Name() string {
+	return og.name
+}
+
+// Shifts returns who is on call now and next for each schedule. OpsGenie doesn't say
+// when the current shift ends
+func (og *opsGenie) Shifts() ([]Shift, error) {
+	shifts := []Shift{}
+
+	for _, schedule := range og.schedules {
+		now, err := og.client.OnCall(schedule, og.scheduleIdentifierType)
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := og.client.NextOnCall(schedule, og.scheduleIdentifierType)
+		if err != nil {
+			return nil, err
+		}
+
+		name := now.OnCallData.Parent.Name
+		if name == "" {
+			name = schedule
+		}
+
+		shifts = append(shifts, Shift{
+			Schedule: name,
+			Now:      now.OnCallData.Recipients,
+			Next:     next.NextOnCallData.Recipients,
+		})
+	}
+
+	return shifts, nil
+}
+
+// Incidents returns the open alerts. Alerts of priority P1 and P2 are high urgency
+func (og *opsGenie) Incidents() ([]Incident, error) {
+	alerts, err := og.client.OpenAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := []Incident{}
+	for _, alert := range alerts {
+		incident := Incident{
+			ID:        alert.ID,
+			Title:     alert.Message,
+			Status:    statusTriggered,
+			Urgency:   urgencyLow,
+			Service:   alert.Source,
+			URL:       og.client.AlertURL(alert.ID),
+			CreatedAt: alert.CreatedAt,
+
+			provider: og,
+		}
+
+		if alert.Acknowledged {
+			incident.Status = statusAcknowledged
+		}
+
+		if alert.Priority == "P1" || alert.Priority == "P2" {
+			incident.Urgency = urgencyHigh
+		}
+
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+func (og *opsGenie) Acknowledge(incident Incident) error {
+	return og.client.AcknowledgeAlert(incident.ID)
+}
+
+// Resolve closes the alert, which is what OpsGenie calls resolving it
+func (og *opsGenie) Resolve(incident Incident) error {
+	return og.client.CloseAlert(incident.ID)
+}
@@ -0,0 +1,67 @@
+package oncall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_opsGenie_Shifts(t *testing.T) {
+	server, requests := fakeProvider(t, map[string]string{
+		"GET /v2/schedules/ops/on-calls":      `{"data": {"_parent": {"name": "Ops"}, "onCallRecipients": ["ann@example.com"]}}`,
+		"GET /v2/schedules/ops/next-on-calls": `{"data": {"nextOnCallRecipients": ["bob@example.com"]}}`,
+	})
+	defer server.Close()
+
+	og := newOpsGenie(providerConfig(t, "type: opsgenie\napiKey: token\nschedules: [ops]"))
+	og.client.APIURL = server.URL
+
+	shifts, err := og.Shifts()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Shift{{Schedule: "Ops", Now: []string{"ann@example.com"}, Next: []string{"bob@example.com"}}}, shifts)
+	assert.Equal(t, "GenieKey token", (*requests)[0].header.Get("Authorization"))
+}
+
+func Test_opsGenie_Incidents(t *testing.T) {
+	server, _ := fakeProvider(t, map[string]string{
+		"GET /v2/alerts": `{"data": [
+			{"id": "a1", "message": "CPU high", "acknowledged": true, "priority": "P1", "createdAt": "2020-08-01T12:00:00Z"},
+			{"id": "a2", "message": "Disk low", "acknowledged": false, "priority": "P4", "createdAt": "2020-08-01T12:00:00Z"}
+		]}`,
+	})
+	defer server.Close()
+
+	og := newOpsGenie(providerConfig(t, "type: opsgenie\napiKey: token\nregion: eu"))
+	og.client.APIURL = server.URL
+
+	incidents, err := og.Incidents()
+
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 2)
+	assert.Equal(t, statusAcknowledged, incidents[0].Status)
+	assert.Equal(t, urgencyHigh, incidents[0].Urgency)
+	assert.Equal(t, statusTriggered, incidents[1].Status)
+	assert.Equal(t, urgencyLow, incidents[1].Urgency)
+	assert.Equal(t, "https://app.eu.opsgenie.com/alert/detail/a1/details", incidents[0].URL)
+}
+
+func Test_opsGenie_Resolve(t *testing.T) {
+	server, requests := fakeProvider(t, map[string]string{})
+	defer server.Close()
+
+	og := newOpsGenie(providerConfig(t, "type: opsgenie\napiKey: token"))
+	og.client.APIURL = server.URL
+
+	assert.NoError(t, og.Resolve(Incident{ID: "a1"}))
+	assert.Equal(t, "POST", (*requests)[0].method)
+	assert.Equal(t, "/v2/alerts/a1/close", (*requests)[0].path)
+}
+
+func Test_opsGenie_UnknownRegion(t *testing.T) {
+	og := newOpsGenie(providerConfig(t, "type: opsgenie\nregion: mars"))
+
+	_, err := og.Incidents()
+
+	assert.EqualError(t, err, `unknown OpsGenie region "mars", must be us or eu`)
+}
@@ -0,0 +1,160 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/olebedev/config"
+	pdclient "github.com/wtfutil/wtf/modules/pagerduty"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// pagerDutyHorizon is how far ahead on-calls are looked up to find who is on call next
+const pagerDutyHorizon = 7 * 24 * time.Hour
+
+// pagerDuty is a PagerDuty account, reached through the pagerduty module's client
+type pagerDuty struct {
+	client *pdclient.Client
+
+	name        string
+	email       string
+	scheduleIDs []string
+	teamIDs     []string
+	userIDs     []string
+}
+
+func newPagerDuty(ymlConfig *config.Config) *pagerDuty {
+	return &pagerDuty{
+		client: pdclient.NewClient(ymlConfig.UString("apiKey"), ymlConfig.UString("email")),
+
+		name:        ymlConfig.UString("name", "PagerDuty"),
+		email:       ymlConfig.UString("email"),
+		scheduleIDs: utils.ToStrs(ymlConfig.UList("scheduleIDs")),
+		teamIDs:     utils.ToStrs(ymlConfig.UList("teamIDs")),
+		userIDs:     utils.ToStrs(ymlConfig.UList("userIDs")),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (pd *pagerDuty) Name() string {
+	return pd.name
+}
+
+// Shifts returns who is first in line on each schedule now, and who takes over from them
+func (pd *pagerDuty) Shifts() ([]Shift, error) {
+	now := time.Now()
+
+	onCalls, err := pd.client.OnCalls(pd.scheduleIDs, now, now.Add(pagerDutyHorizon))
+	if err != nil {
+		return nil, err
+	}
+
+	return pagerDutyShifts(onCalls, now), nil
+}
+
+func (pd *pagerDuty) Incidents() ([]Incident, error) {
+	remotes, err := pd.client.Incidents(pd.teamIDs, pd.userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := []Incident{}
+	for _, remote := range remotes {
+		createdAt, _ := time.Parse(time.RFC3339, remote.CreatedAt)
+
+		incidents = append(incidents, Incident{
+			ID:        remote.Id,
+			Title:     remote.Title,
+			Status:    remote.Status,
+			Urgency:   remote.Urgency,
+			Service:   remote.Service.Summary,
+			URL:       remote.HTMLURL,
+			CreatedAt: createdAt,
+
+			provider: pd,
+		})
+	}
+
+	return incidents, nil
+}
+
+func (pd *pagerDuty) Acknowledge(incident Incident) error {
+	return pd.setStatus(incident, statusAcknowledged)
+}
+
+func (pd *pagerDuty) Resolve(incident Incident) error {
+	return pd.setStatus(incident, "resolved")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// setStatus changes the status of an incident. PagerDuty records who changed it from the
+// email address of a PagerDuty user, so it can't be done unless one is configured
+func (pd *pagerDuty) setStatus(incident Incident, status string) error {
+	if pd.email == "" {
+		return errNotSupported
+	}
+
+	return pd.client.UpdateIncident(incident.ID, map[string]interface{}{"status": status})
+}
+
+// pagerDutyShifts builds a shift for each schedule from the on-calls at its first
+// escalation level. The people whose on-calls have started are on call now, and the
+// people whose on-calls start soonest after that are next
+func pagerDutyShifts(onCalls []pagerduty.OnCall, now time.Time) []Shift {
+	shifts := map[string]*Shift{}
+	nextStarts := map[string]time.Time{}
+	names := []string{}
+
+	for _, onCall := range onCalls {
+		if onCall.EscalationLevel > 1 {
+			continue
+		}
+
+		name := onCall.Schedule.Summary
+		if name == "" {
+			name = onCall.EscalationPolicy.Summary
+		}
+
+		shift, ok := shifts[name]
+		if !ok {
+			shift = &Shift{Schedule: name}
+			shifts[name] = shift
+			names = append(names, name)
+		}
+
+		// On-calls that are permanent, rather than scheduled, have no start or end
+		start, err := time.Parse(time.RFC3339, onCall.Start)
+		if err != nil || !start.After(now) {
+			shift.Now = append(shift.Now, onCall.User.Summary)
+
+			if end, err := time.Parse(time.RFC3339, onCall.End); err == nil {
+				if shift.EndsAt.IsZero() || end.Before(shift.EndsAt) {
+					shift.EndsAt = end
+				}
+			}
+
+			continue
+		}
+
+		nextStart, ok := nextStarts[name]
+		switch {
+		case !ok || start.Before(nextStart):
+			nextStarts[name] = start
+			shift.Next = []string{onCall.User.Summary}
+		case start.Equal(nextStart):
+			shift.Next = append(shift.Next, onCall.User.Summary)
+		}
+	}
+
+	sort.Strings(names)
+
+	result := []Shift{}
+	for _, name := range names {
+		result = append(result, *shifts[name])
+	}
+
+	return result
+}
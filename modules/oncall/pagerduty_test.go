@@ -0,0 +1,92 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPagerDuty(t *testing.T, responses map[string]string) (*pagerDuty, *[]fakeRequest, func()) {
+	server, requests := fakeProvider(t, responses)
+
+	pd := newPagerDuty(providerConfig(t, "type: pagerduty\napiKey: token\nemail: me@example.com"))
+	pd.client.HTTPClient = rewriteClient{server: server}
+
+	return pd, requests, server.Close
+}
+
+func Test_pagerDutyShifts(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	onCall := func(schedule, user string, level uint, start, end string) pagerduty.OnCall {
+		remote := pagerduty.OnCall{EscalationLevel: level, Start: start, End: end}
+		remote.Schedule.Summary = schedule
+		remote.User.Summary = user
+		return remote
+	}
+
+	shifts := pagerDutyShifts([]pagerduty.OnCall{
+		onCall("Ops", "Ann", 1, "2020-08-01T09:00:00Z", "2020-08-01T17:00:00Z"),
+		onCall("Ops", "Bob", 1, "2020-08-01T17:00:00Z", "2020-08-02T09:00:00Z"),
+		onCall("Ops", "Cat", 1, "2020-08-02T09:00:00Z", "2020-08-02T17:00:00Z"),
+		onCall("Ops", "Dan", 2, "2020-08-01T09:00:00Z", "2020-08-01T17:00:00Z"),
+		onCall("Dev", "Eve", 1, "", ""),
+	}, now)
+
+	assert.Equal(t, []Shift{
+		{Schedule: "Dev", Now: []string{"Eve"}},
+		{
+			Schedule: "Ops",
+			Now:      []string{"Ann"},
+			Next:     []string{"Bob"},
+			EndsAt:   time.Date(2020, 8, 1, 17, 0, 0, 0, time.UTC),
+		},
+	}, shifts)
+}
+
+func Test_pagerDuty_Incidents(t *testing.T) {
+	pd, _, closeFunc := testPagerDuty(t, map[string]string{
+		"GET /incidents": `{"incidents": [{"id": "P1", "title": "Disk full", "status": "triggered", "urgency": "high",
+			"html_url": "https://example.pagerduty.com/incidents/P1", "created_at": "2020-08-01T12:00:00Z",
+			"service": {"summary": "Database"}}]}`,
+	})
+	defer closeFunc()
+
+	incidents, err := pd.Incidents()
+
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, "P1", incidents[0].ID)
+	assert.Equal(t, "Disk full", incidents[0].Title)
+	assert.Equal(t, "Database", incidents[0].Service)
+	assert.Equal(t, urgencyHigh, incidents[0].Urgency)
+	assert.Equal(t, "https://example.pagerduty.com/incidents/P1", incidents[0].URL)
+}
+
+func Test_pagerDuty_Resolve(t *testing.T) {
+	pd, requests, closeFunc := testPagerDuty(t, map[string]string{
+		"PUT /incidents/P1": `{"incident": {}}`,
+	})
+	defer closeFunc()
+
+	err := pd.Resolve(Incident{ID: "P1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "me@example.com", (*requests)[0].header.Get("From"))
+
+	assert.Equal(t, "/incidents/P1", (*requests)[0].path)
+	assert.Equal(t, "resolved", (*requests)[0].body["incident"].(map[string]interface{})["status"])
+}
+
+func Test_pagerDuty_AcknowledgeWithoutEmail(t *testing.T) {
+	pd, requests, closeFunc := testPagerDuty(t, map[string]string{})
+	defer closeFunc()
+
+	pd.email = ""
+
+	assert.Equal(t, errNotSupported, pd.Acknowledge(Incident{ID: "P1"}))
+	assert.Empty(t, *requests)
+}
@@ -0,0 +1,102 @@
+package oncall
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+// Incident urgencies, most urgent first
+const (
+	urgencyHigh = "high"
+	urgencyLow  = "low"
+)
+
+// Incident statuses. Resolved incidents are not listed
+const (
+	statusAcknowledged = "acknowledged"
+	statusTriggered    = "triggered"
+)
+
+// errNotSupported is returned by providers for actions their API, or the way they are
+// configured, doesn't allow
+var errNotSupported = errors.New("not supported by this provider")
+
+// Provider is an on-call service, such as PagerDuty, that has schedules of who is on
+// call, and incidents that page them
+type Provider interface {
+	// Name is the name the provider's schedules and incidents are labeled with
+	Name() string
+
+	// Shifts returns who is on call now, and next, for each of the provider's schedules
+	Shifts() ([]Shift, error)
+
+	// Incidents returns the incidents that have not been resolved
+	Incidents() ([]Incident, error)
+
+	Acknowledge(incident Incident) error
+	Resolve(incident Incident) error
+}
+
+// Shift is who is on call for a schedule
+type Shift struct {
+	Schedule string
+	Now      []string
+	Next     []string
+
+	// EndsAt is when the current shift ends. It is zero if the provider doesn't say
+	EndsAt time.Time
+}
+
+// Incident is an open incident, or alert, from any provider
+type Incident struct {
+	ID        string
+	Title     string
+	Status    string
+	Urgency   string
+	Service   string
+	URL       string
+	CreatedAt time.Time
+
+	provider Provider
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// newProvider creates the provider defined by a block of the providers list. The type of
+// the provider is set by its type key, one of pagerduty, opsgenie or victorops
+func newProvider(ymlConfig *config.Config) (Provider, error) {
+	providerType := ymlConfig.UString("type")
+
+	switch providerType {
+	case "opsgenie":
+		return newOpsGenie(ymlConfig), nil
+	case "pagerduty":
+		return newPagerDuty(ymlConfig), nil
+	case "victorops":
+		return newVictorOps(ymlConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown provider type %q, must be one of pagerduty, opsgenie or victorops", providerType)
+	}
+}
+
+// sortIncidents sorts the incidents by urgency, then puts the ones that haven't been
+// acknowledged first, then the newest first
+func sortIncidents(incidents []Incident) {
+	sort.SliceStable(incidents, func(i, j int) bool {
+		a, b := incidents[i], incidents[j]
+
+		if a.Urgency != b.Urgency {
+			return a.Urgency == urgencyHigh
+		}
+
+		if a.Status != b.Status {
+			return a.Status == statusTriggered
+		}
+
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+}
@@ -0,0 +1,109 @@
+package oncall
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRequest is a request received by the fake provider server
+type fakeRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   map[string]interface{}
+}
+
+// fakeProvider starts a fake provider API that replies to each method and path with the
+// matching response, and records the requests it receives. Paths without a response get
+// a 202, which is how the providers accept actions
+func fakeProvider(t *testing.T, responses map[string]string) (*httptest.Server, *[]fakeRequest) {
+	requests := []fakeRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := fakeRequest{method: r.Method, path: r.URL.Path, header: r.Header}
+
+		raw, _ := ioutil.ReadAll(r.Body)
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &req.body); err != nil {
+				t.Errorf("invalid request body: %s", raw)
+			}
+		}
+
+		requests = append(requests, req)
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+// rewriteClient sends every request to the fake server, whatever host it was made for
+type rewriteClient struct {
+	server *httptest.Server
+}
+
+func (client rewriteClient) Do(req *http.Request) (*http.Response, error) {
+	serverURL, _ := url.Parse(client.server.URL)
+
+	req.URL.Scheme = serverURL.Scheme
+	req.URL.Host = serverURL.Host
+
+	return client.server.Client().Do(req)
+}
+
+func providerConfig(t *testing.T, yml string) *config.Config {
+	ymlConfig, err := config.ParseYaml(yml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ymlConfig
+}
+
+func Test_newProvider(t *testing.T) {
+	provider, err := newProvider(providerConfig(t, "type: opsgenie\nname: Ops"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Ops", provider.Name())
+
+	provider, err = newProvider(providerConfig(t, "type: pagerduty"))
+	assert.NoError(t, err)
+	assert.Equal(t, "PagerDuty", provider.Name())
+
+	_, err = newProvider(providerConfig(t, "type: pagerdoodie"))
+	assert.EqualError(t, err, `unknown provider type "pagerdoodie", must be one of pagerduty, opsgenie or victorops`)
+}
+
+func Test_sortIncidents(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	incidents := []Incident{
+		{ID: "low", Urgency: urgencyLow, Status: statusTriggered, CreatedAt: now},
+		{ID: "acked", Urgency: urgencyHigh, Status: statusAcknowledged, CreatedAt: now},
+		{ID: "old", Urgency: urgencyHigh, Status: statusTriggered, CreatedAt: now.Add(-time.Hour)},
+		{ID: "new", Urgency: urgencyHigh, Status: statusTriggered, CreatedAt: now},
+	}
+
+	sortIncidents(incidents)
+
+	ids := []string{}
+	for _, incident := range incidents {
+		ids = append(ids, incident.ID)
+	}
+
+	assert.Equal(t, []string{"new", "old", "acked", "low"}, ids)
+}
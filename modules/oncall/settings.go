@@ -0,0 +1,72 @@
+package oncall
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const (
+	defaultFocusable = true
+	defaultTitle     = "On Call"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	providers     []Provider `help:"The on-call services to display, each with a type of pagerduty, opsgenie or victorops and that service's settings."`
+	providerErrs  []error
+	showIncidents bool   `help:"Whether or not to list the open incidents." values:"true or false" optional:"true"`
+	showSchedules bool   `help:"Whether or not to list who is on call." values:"true or false" optional:"true"`
+	myName        string `help:"The name that you appear as in the schedules, which is highlighted." optional:"true"`
+}
+
+// NewSettingsFromYAML creates the settings for the module. Each provider is defined by a
+// block of the providers list, i.e.:
+//
+//    providers:
+//      - type: pagerduty
+//        apiKey: "<token>"
+//        email: "me@example.com"
+//        scheduleIDs: ["PX1Y2Z3"]
+//      - type: opsgenie
+//        apiKey: "<token>"
+//        region: eu
+//        schedules: ["Ops Team_schedule"]
+//        scheduleIdentifierType: name
+//      - type: victorops
+//        apiID: "<id>"
+//        apiKey: "<key>"
+//        team: ops
+//        userName: me
+//
+// Providers that can't be created are reported by the widget instead of their schedules
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
+
+		showIncidents: ymlConfig.UBool("showIncidents", true),
+		showSchedules: ymlConfig.UBool("showSchedules", true),
+		myName:        ymlConfig.UString("myName"),
+	}
+
+	for idx := range ymlConfig.UList("providers") {
+		providerConfig, err := ymlConfig.Get(fmt.Sprintf("providers.%d", idx))
+		if err != nil {
+			settings.providerErrs = append(settings.providerErrs, err)
+			continue
+		}
+
+		provider, err := newProvider(providerConfig)
+		if err != nil {
+			settings.providerErrs = append(settings.providerErrs, err)
+			continue
+		}
+
+		settings.providers = append(settings.providers, provider)
+	}
+
+	return &settings
+}
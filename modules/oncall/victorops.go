@@ -0,0 +1,127 @@
+package oncall
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/modules/victorops"
+)
+
+// victorOps is a VictorOps account, reached through the victorops module's client
+type victorOps struct {
+	client *victorops.Client
+
+	name         string
+	organization string
+	team         string
+	userName     string
+}
+
+func newVictorOps(ymlConfig *config.Config) *victorOps {
+	return &victorOps{
+		client: victorops.NewClient(ymlConfig.UString("apiID"), ymlConfig.UString("apiKey")),
+
+		name:         ymlConfig.UString("name", "VictorOps"),
+		organization: ymlConfig.UString("organization"),
+		team:         ymlConfig.UString("team"),
+		userName:     ymlConfig.UString("userName"),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (vo *victorOps) Name() string {
+	return vo.name
+}
+
+// Shifts returns who is on call now for each escalation policy of each team. VictorOps
+// doesn't say who is on call next, or when the current shift ends
+func (vo *victorOps) Shifts() ([]Shift, error) {
+	response, err := vo.client.OnCallNow()
+	if err != nil {
+		return nil, err
+	}
+
+	shifts := []Shift{}
+	for _, teamOnCall := range response.TeamsOnCall {
+		team := teamOnCall.Team
+		if vo.team != "" && vo.team != team.Slug && vo.team != team.Name {
+			continue
+		}
+
+		for _, onCall := range teamOnCall.OnCallNow {
+			shift := Shift{Schedule: fmt.Sprintf("%s: %s", team.Name, onCall.EscalationPolicy.Name)}
+
+			for _, user := range onCall.Users {
+				shift.Now = append(shift.Now, user.OnCallUser.Username)
+			}
+
+			shifts = append(shifts, shift)
+		}
+	}
+
+	return shifts, nil
+}
+
+// Incidents returns the incidents that have not been resolved. VictorOps incidents have
+// no urgency, so they are all treated as urgent
+func (vo *victorOps) Incidents() ([]Incident, error) {
+	remotes, err := vo.client.Incidents()
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := []Incident{}
+	for _, remote := range remotes {
+		incident := Incident{
+			ID:        remote.IncidentNumber,
+			Title:     remote.EntityDisplayName,
+			Urgency:   urgencyHigh,
+			Service:   remote.Service,
+			CreatedAt: remote.StartTime,
+
+			provider: vo,
+		}
+
+		if incident.Title == "" {
+			incident.Title = remote.EntityID
+		}
+
+		switch remote.CurrentPhase {
+		case "UNACKED":
+			incident.Status = statusTriggered
+		case "ACKED":
+			incident.Status = statusAcknowledged
+		default:
+			continue
+		}
+
+		if vo.organization != "" {
+			incident.URL = fmt.Sprintf("https://portal.victorops.com/ui/%s/incident/%s/details", vo.organization, remote.IncidentNumber)
+		}
+
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+func (vo *victorOps) Acknowledge(incident Incident) error {
+	return vo.manage("ack", incident)
+}
+
+func (vo *victorOps) Resolve(incident Incident) error {
+	return vo.manage("resolve", incident)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// manage acknowledges or resolves an incident. VictorOps records the user who did it, so
+// it can't be done unless a user name is configured
+func (vo *victorOps) manage(action string, incident Incident) error {
+	if vo.userName == "" {
+		return errNotSupported
+	}
+
+	return vo.client.ManageIncidents(action, vo.userName, []string{incident.ID})
+}
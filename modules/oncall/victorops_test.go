@@ -0,0 +1,66 @@
+package oncall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_victorOps_Shifts(t *testing.T) {
+	server, _ := fakeProvider(t, map[string]string{
+		"GET /oncall/current": `{"teamsOnCall": [
+			{"team": {"name": "Ops", "slug": "ops"}, "oncallNow": [
+				{"escalationPolicy": {"name": "Primary"}, "users": [{"onCalluser": {"username": "ann"}}]}
+			]},
+			{"team": {"name": "Dev", "slug": "dev"}, "oncallNow": [
+				{"escalationPolicy": {"name": "Primary"}, "users": [{"onCalluser": {"username": "bob"}}]}
+			]}
+		]}`,
+	})
+	defer server.Close()
+
+	vo := newVictorOps(providerConfig(t, "type: victorops\nteam: ops"))
+	vo.client.APIURL = server.URL
+
+	shifts, err := vo.Shifts()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Shift{{Schedule: "Ops: Primary", Now: []string{"ann"}}}, shifts)
+}
+
+func Test_victorOps_Incidents(t *testing.T) {
+	server, _ := fakeProvider(t, map[string]string{
+		"GET /incidents": `{"incidents": [
+			{"incidentNumber": "7", "currentPhase": "ACKED", "entityDisplayName": "Disk full", "startTime": "2020-08-01T12:00:00Z"},
+			{"incidentNumber": "8", "currentPhase": "RESOLVED", "entityDisplayName": "CPU high", "startTime": "2020-08-01T12:00:00Z"}
+		]}`,
+	})
+	defer server.Close()
+
+	vo := newVictorOps(providerConfig(t, "type: victorops\norganization: acme"))
+	vo.client.APIURL = server.URL
+
+	incidents, err := vo.Incidents()
+
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, statusAcknowledged, incidents[0].Status)
+	assert.Equal(t, "https://portal.victorops.com/ui/acme/incident/7/details", incidents[0].URL)
+}
+
+func Test_victorOps_Acknowledge(t *testing.T) {
+	server, requests := fakeProvider(t, map[string]string{})
+	defer server.Close()
+
+	vo := newVictorOps(providerConfig(t, "type: victorops\nuserName: ann"))
+	vo.client.APIURL = server.URL
+
+	assert.NoError(t, vo.Acknowledge(Incident{ID: "7"}))
+	assert.Equal(t, "PATCH", (*requests)[0].method)
+	assert.Equal(t, "/incidents/ack", (*requests)[0].path)
+	assert.Equal(t, "ann", (*requests)[0].body["userName"])
+	assert.Equal(t, []interface{}{"7"}, (*requests)[0].body["incidentNames"])
+
+	vo.userName = ""
+	assert.Equal(t, errNotSupported, vo.Resolve(Incident{ID: "7"}))
+}
@@ -0,0 +1,142 @@
+package oncall
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
+	"github.com/wtfutil/wtf/view"
+)
+
+// providerShifts are the shifts of a single provider
+type providerShifts struct {
+	provider string
+	shifts   []Shift
+}
+
+type Widget struct {
+	view.KeyboardWidget
+	view.ScrollableWidget
+
+	errs      []error
+	incidents []Incident
+	notifier  *notify.Notifier
+	settings  *Settings
+	shifts    []providerShifts
+}
+
+// NewWidget creates and returns an instance of the on-call widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
+
+		notifier: notify.NewNotifier(settings.common),
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Refresh fetches the shifts and incidents of every provider at once. A provider that
+// fails is reported in place of its data, without hiding the others
+func (widget *Widget) Refresh() {
+	providers := widget.settings.providers
+
+	shifts := make([]providerShifts, len(providers))
+	incidents := make([][]Incident, len(providers))
+	errs := make([]error, len(providers))
+
+	var wg sync.WaitGroup
+	for idx, provider := range providers {
+		wg.Add(1)
+
+		go func(idx int, provider Provider) {
+			defer wg.Done()
+
+			shifts[idx].provider = provider.Name()
+
+			if widget.settings.showSchedules {
+				shifts[idx].shifts, errs[idx] = provider.Shifts()
+				if errs[idx] != nil {
+					return
+				}
+			}
+
+			if widget.settings.showIncidents {
+				incidents[idx], errs[idx] = provider.Incidents()
+			}
+		}(idx, provider)
+	}
+	wg.Wait()
+
+	allErrs := append([]error{}, widget.settings.providerErrs...)
+	allIncidents := []Incident{}
+
+	for idx, err := range errs {
+		if err != nil {
+			allErrs = append(allErrs, fmt.Errorf("%s: %w", providers[idx].Name(), err))
+			continue
+		}
+
+		allIncidents = append(allIncidents, incidents[idx]...)
+	}
+
+	sortIncidents(allIncidents)
+	widget.notifier.Publish(widget.incidentEvents(allIncidents)...)
+
+	widget.errs = allErrs
+	widget.incidents = allIncidents
+	widget.shifts = shifts
+	widget.SetItemCount(len(allIncidents))
+
+	if len(allErrs) > 0 {
+		widget.SetRefreshError(allErrs[0])
+	} else {
+		widget.SetRefreshError(nil)
+	}
+
+	widget.Render()
+}
+
+func (widget *Widget) Render() {
+	widget.Redraw(widget.content)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// incidentEvents returns an event for the status of each incident
+func (widget *Widget) incidentEvents(incidents []Incident) []notify.Event {
+	events := []notify.Event{}
+
+	for _, incident := range incidents {
+		event := notify.Event{
+			Key:     fmt.Sprintf("%s/%s", incident.provider.Name(), incident.ID),
+			Message: incident.Title,
+		}
+
+		switch incident.Status {
+		case statusTriggered:
+			event.Type = notify.IncidentTriggered
+			event.Title = fmt.Sprintf("%s: incident triggered", widget.CommonSettings().Title)
+		case statusAcknowledged:
+			event.Type = notify.IncidentAcknowledged
+			event.Title = fmt.Sprintf("%s: incident acknowledged", widget.CommonSettings().Title)
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
@@ -1,9 +1,12 @@
 package opsgenie
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 type OnCallResponse struct {
@@ -24,9 +27,54 @@ type Parent struct {
 	Enabled bool   `json:"enabled"`
 }
 
-var opsGenieAPIUrl = map[string]string{
-	"us": "https://api.opsgenie.com",
-	"eu": "https://api.eu.opsgenie.com",
+type NextOnCallResponse struct {
+	NextOnCallData NextOnCallData `json:"data"`
+}
+
+type NextOnCallData struct {
+	Recipients []string `json:"nextOnCallRecipients"`
+	Parent     Parent   `json:"_parent"`
+}
+
+// Alert is an OpsGenie alert, which is what OpsGenie calls an incident
+type Alert struct {
+	ID           string    `json:"id"`
+	Message      string    `json:"message"`
+	Status       string    `json:"status"`
+	Acknowledged bool      `json:"acknowledged"`
+	Priority     string    `json:"priority"`
+	Source       string    `json:"source"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// opsGenieURLs are the API and web app addresses of each OpsGenie region
+var opsGenieURLs = map[string]struct{ api, app string }{
+	"eu": {api: "https://api.eu.opsgenie.com", app: "https://app.eu.opsgenie.com"},
+	"us": {api: "https://api.opsgenie.com", app: "https://app.opsgenie.com"},
+}
+
+// Client is an OpsGenie API client, shared by this module and the oncall module
+type Client struct {
+	// APIURL and AppURL are the addresses of the region's API and web app. APIURL is empty
+	// if the region is unknown
+	APIURL string
+	AppURL string
+
+	apiKey     string
+	httpClient *http.Client
+	region     string
+}
+
+// NewClient creates a client for the API key in the region, which is us or eu
+func NewClient(apiKey, region string) *Client {
+	return &Client{
+		APIURL: opsGenieURLs[region].api,
+		AppURL: opsGenieURLs[region].app,
+
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+		region:     region,
+	}
 }
 
 /* -------------------- Exported Functions -------------------- */
@@ -34,43 +82,115 @@ var opsGenieAPIUrl = map[string]string{
 func (widget *Widget) Fetch(scheduleIdentifierType string, schedules []string) ([]*OnCallResponse, error) {
 	agregatedResponses := []*OnCallResponse{}
 
-	if regionURL, regionErr := opsGenieAPIUrl[widget.settings.region]; regionErr {
-		for _, sched := range schedules {
-			scheduleURL := fmt.Sprintf("%s/v2/schedules/%s/on-calls?scheduleIdentifierType=%s&flat=true", regionURL, sched, scheduleIdentifierType)
-			response, err := opsGenieRequest(scheduleURL, widget.settings.apiKey)
-			agregatedResponses = append(agregatedResponses, response)
-			if err != nil {
-				return nil, err
-			}
+	for _, sched := range schedules {
+		response, err := widget.client.OnCall(sched, scheduleIdentifierType)
+		if err != nil {
+			return nil, err
 		}
-		return agregatedResponses, nil
-	} else {
-		return nil, fmt.Errorf("you specified wrong region. Possible options are only 'us' and 'eu'")
+		agregatedResponses = append(agregatedResponses, response)
 	}
+
+	return agregatedResponses, nil
 }
 
-/* -------------------- Unexported Functions -------------------- */
+// OnCall returns who is on call now for a schedule
+func (client *Client) OnCall(schedule, scheduleIdentifierType string) (*OnCallResponse, error) {
+	response := &OnCallResponse{}
+	err := client.request("GET", scheduleOnCallsPath(schedule, "on-calls", scheduleIdentifierType), nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
 
-func opsGenieRequest(url string, apiKey string) (*OnCallResponse, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// NextOnCall returns who is on call next for a schedule
+func (client *Client) NextOnCall(schedule, scheduleIdentifierType string) (*NextOnCallResponse, error) {
+	response := &NextOnCallResponse{}
+	err := client.request("GET", scheduleOnCallsPath(schedule, "next-on-calls", scheduleIdentifierType), nil, response)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", apiKey))
+	return response, nil
+}
 
-	client := &http.Client{}
+// OpenAlerts returns the alerts that haven't been closed
+func (client *Client) OpenAlerts() ([]Alert, error) {
+	response := struct {
+		Data []Alert `json:"data"`
+	}{}
 
-	resp, err := client.Do(req)
-	if err != nil {
+	if err := client.request("GET", "/v2/alerts?limit=100&query="+url.QueryEscape("status:open"), nil, &response); err != nil {
 		return nil, err
 	}
+
+	return response.Data, nil
+}
+
+// AcknowledgeAlert acknowledges an alert
+func (client *Client) AcknowledgeAlert(id string) error {
+	return client.request("POST", fmt.Sprintf("/v2/alerts/%s/acknowledge", url.PathEscape(id)), map[string]string{}, nil)
+}
+
+// CloseAlert closes an alert, which is what OpsGenie calls resolving it
+func (client *Client) CloseAlert(id string) error {
+	return client.request("POST", fmt.Sprintf("/v2/alerts/%s/close", url.PathEscape(id)), map[string]string{}, nil)
+}
+
+// AlertURL returns the address of an alert in the OpsGenie web app
+func (client *Client) AlertURL(id string) string {
+	return fmt.Sprintf("%s/alert/detail/%s/details", client.AppURL, id)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func scheduleOnCallsPath(schedule, endpoint, scheduleIdentifierType string) string {
+	return fmt.Sprintf(
+		"/v2/schedules/%s/%s?scheduleIdentifierType=%s&flat=true",
+		url.PathEscape(schedule),
+		endpoint,
+		url.QueryEscape(scheduleIdentifierType),
+	)
+}
+
+// request makes a request to the OpsGenie API, sending body as JSON if it isn't nil and
+// decoding the response into result if it isn't nil
+func (client *Client) request(method, path string, body interface{}, result interface{}) error {
+	if client.APIURL == "" {
+		return fmt.Errorf("unknown OpsGenie region %q, must be us or eu", client.region)
+	}
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, client.APIURL+path, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", client.apiKey))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
 	defer func() { _ = resp.Body.Close() }()
 
-	response := &OnCallResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		return nil, err
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("OpsGenie API returned %s", resp.Status)
 	}
 
-	return response, nil
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
 }
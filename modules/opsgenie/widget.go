@@ -12,6 +12,7 @@ import (
 type Widget struct {
 	view.TextWidget
 
+	client   *Client
 	settings *Settings
 }
 
@@ -19,6 +20,7 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 	widget := Widget{
 		TextWidget: view.NewTextWidget(app, settings.common),
 
+		client:   NewClient(settings.apiKey, settings.region),
 		settings: settings,
 	}
 
@@ -0,0 +1,140 @@
+package pagerduty
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedIncident returns the highlighted incident, or nil if none is
+func (widget *Widget) selectedIncident() *pagerduty.Incident {
+	idx := widget.GetSelected()
+	if idx < 0 || idx >= len(widget.incidents) {
+		return nil
+	}
+
+	incident := widget.incidents[idx]
+	return &incident
+}
+
+// confirmAction asks whether to go ahead with an action on an incident, with a button
+// labeled with the action, and runs the action if it's chosen
+func (widget *Widget) confirmAction(question, button, success string, action func() error) {
+	widget.ShowChoice(question, []string{button, "Cancel"}, func(choice string) {
+		if choice != button {
+			return
+		}
+
+		go func() {
+			widget.ReportAction(success, action(), widget.RequestRefresh)
+		}()
+	})
+}
+
+func (widget *Widget) acknowledgeIncident() {
+	incident := widget.selectedIncident()
+	if incident == nil || incident.Status != "triggered" {
+		return
+	}
+
+	widget.confirmAction(
+		fmt.Sprintf("Acknowledge %s?", tview.Escape(incident.Summary)),
+		"Acknowledge",
+		fmt.Sprintf("Acknowledged %s", incident.Summary),
+		func() error {
+			return widget.client.UpdateIncident(incident.Id, map[string]interface{}{"status": "acknowledged"})
+		},
+	)
+}
+
+// escalateIncident lists the levels of the highlighted incident's escalation policy, and
+// reassigns the incident to the level that is chosen
+func (widget *Widget) escalateIncident() {
+	incident := widget.selectedIncident()
+	if incident == nil {
+		return
+	}
+
+	go func() {
+		rules, err := widget.client.EscalationRules(incident.EscalationPolicy.ID)
+		if err != nil {
+			widget.ReportAction("", err, widget.RequestRefresh)
+			return
+		}
+
+		if len(rules) == 0 {
+			widget.ShowMessage(fmt.Sprintf(" %s has no escalation levels", tview.Escape(incident.EscalationPolicy.Summary)))
+			return
+		}
+
+		levels := []string{}
+		for idx, rule := range rules {
+			targets := []string{}
+			for _, target := range rule.Targets {
+				targets = append(targets, target.Summary)
+			}
+
+			levels = append(levels, tview.Escape(fmt.Sprintf("Level %d: %s", idx+1, strings.Join(targets, ", "))))
+		}
+
+		widget.ShowList(fmt.Sprintf("Reassign %s", incident.Summary), levels, func(idx int) {
+			level := idx + 1
+
+			widget.confirmAction(
+				fmt.Sprintf("Reassign %s to escalation level %d?", tview.Escape(incident.Summary), level),
+				"Reassign",
+				fmt.Sprintf("Reassigned %s to escalation level %d", incident.Summary, level),
+				func() error {
+					return widget.client.UpdateIncident(incident.Id, map[string]interface{}{"escalation_level": level})
+				},
+			)
+		})
+	}()
+}
+
+// noteIncident prompts for a note and adds it to the highlighted incident
+func (widget *Widget) noteIncident() {
+	incident := widget.selectedIncident()
+	if incident == nil {
+		return
+	}
+
+	widget.ShowInput(fmt.Sprintf("Note on %s", incident.Summary), func(text string) {
+		widget.confirmAction(
+			fmt.Sprintf("Add this note to %s?\n\n%s", tview.Escape(incident.Summary), tview.Escape(text)),
+			"Add note",
+			fmt.Sprintf("Added a note to %s", incident.Summary),
+			func() error {
+				return widget.client.AddIncidentNote(incident.Id, text)
+			},
+		)
+	})
+}
+
+func (widget *Widget) openIncident() {
+	incident := widget.selectedIncident()
+	if incident != nil && incident.HTMLURL != "" {
+		utils.OpenFile(incident.HTMLURL)
+	}
+}
+
+func (widget *Widget) resolveIncident() {
+	incident := widget.selectedIncident()
+	if incident == nil {
+		return
+	}
+
+	widget.confirmAction(
+		fmt.Sprintf("Resolve %s?", tview.Escape(incident.Summary)),
+		"Resolve",
+		fmt.Sprintf("Resolved %s", incident.Summary),
+		func() error {
+			return widget.client.UpdateIncident(incident.Id, map[string]interface{}{"status": "resolved"})
+		},
+	)
+}
@@ -1,71 +1,183 @@
 package pagerduty
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
 )
 
-const (
-	queryTimeFmt = "2006-01-02T15:04:05Z07:00"
-)
+// apiURL is the PagerDuty REST API that incidents are managed through
+const apiURL = "https://api.pagerduty.com"
 
-// GetOnCalls returns a list of people currently on call
-func GetOnCalls(apiKey string, scheduleIDs []string) ([]pagerduty.OnCall, error) {
-	client := pagerduty.NewClient(apiKey)
+// errNoEmail is returned by actions on incidents when there is no email to make them as.
+// PagerDuty records who acted on an incident from the email of their PagerDuty login
+var errNoEmail = errors.New("set email to the address of your PagerDuty login to act on incidents")
 
-	var results []pagerduty.OnCall
-	var queryOpts pagerduty.ListOnCallOptions
+// Client is a PagerDuty API client, shared by this module and the oncall module. On-calls
+// and incidents are listed through go-pagerduty, which it embeds. Incidents are acted on
+// through the REST API directly, as go-pagerduty can't change an incident's escalation
+// level, but over go-pagerduty's HTTP client
+type Client struct {
+	*pagerduty.Client
 
-	queryOpts.ScheduleIDs = scheduleIDs
-	queryOpts.Since = time.Now().Format(queryTimeFmt)
-	queryOpts.Until = time.Now().Format(queryTimeFmt)
+	apiKey string
+	email  string
+}
 
-	oncalls, err := client.ListOnCalls(queryOpts)
-	if err != nil {
-		return nil, err
+// EscalationRule is a level of an escalation policy and who it notifies
+type EscalationRule struct {
+	Targets []struct {
+		Summary string `json:"summary"`
+	} `json:"targets"`
+}
+
+// NewClient creates a client for the API key. email is the address of the PagerDuty login
+// that incidents are acted on as, and can be empty if they are only listed
+func NewClient(apiKey, email string) *Client {
+	return &Client{
+		Client: pagerduty.NewClient(apiKey),
+
+		apiKey: apiKey,
+		email:  email,
 	}
+}
+
+/* -------------------- Exported Functions -------------------- */
 
-	results = append(results, oncalls.OnCalls...)
+// OnCalls returns the on-calls of the schedules between since and until
+func (client *Client) OnCalls(scheduleIDs []string, since, until time.Time) ([]pagerduty.OnCall, error) {
+	opts := pagerduty.ListOnCallOptions{
+		ScheduleIDs: scheduleIDs,
+		Since:       since.Format(time.RFC3339),
+		Until:       until.Format(time.RFC3339),
+	}
 
-	for oncalls.APIListObject.More {
-		queryOpts.APIListObject.Offset = oncalls.APIListObject.Offset
-		oncalls, err = client.ListOnCalls(queryOpts)
+	onCalls := []pagerduty.OnCall{}
+	for {
+		response, err := client.ListOnCalls(opts)
 		if err != nil {
 			return nil, err
 		}
-		results = append(results, oncalls.OnCalls...)
+
+		onCalls = append(onCalls, response.OnCalls...)
+
+		if !response.More || len(response.OnCalls) == 0 {
+			break
+		}
+		opts.Offset += uint(len(response.OnCalls))
+	}
+
+	return onCalls, nil
+}
+
+// Incidents returns the unresolved incidents of the teams and users
+func (client *Client) Incidents(teamIDs, userIDs []string) ([]pagerduty.Incident, error) {
+	opts := pagerduty.ListIncidentsOptions{
+		DateRange: "all",
+		Statuses:  []string{"triggered", "acknowledged"},
+		TeamIDs:   teamIDs,
+		UserIDs:   userIDs,
+	}
+
+	incidents := []pagerduty.Incident{}
+	for {
+		response, err := client.ListIncidents(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		incidents = append(incidents, response.Incidents...)
+
+		if !response.More || len(response.Incidents) == 0 {
+			break
+		}
+		opts.Offset += uint(len(response.Incidents))
 	}
 
-	return results, nil
+	return incidents, nil
 }
 
-// GetIncidents returns a list of unresolved incidents
-func GetIncidents(apiKey string, teamIDs []string, userIDs []string) ([]pagerduty.Incident, error) {
-	client := pagerduty.NewClient(apiKey)
+// UpdateIncident changes fields of an incident, such as its status or escalation level
+func (client *Client) UpdateIncident(id string, fields map[string]interface{}) error {
+	fields["type"] = "incident_reference"
 
-	var results []pagerduty.Incident
+	body := map[string]interface{}{"incident": fields}
 
-	var queryOpts pagerduty.ListIncidentsOptions
-	queryOpts.DateRange = "all"
-	queryOpts.Statuses = []string{"triggered", "acknowledged"}
-	queryOpts.TeamIDs = teamIDs
-	queryOpts.UserIDs = userIDs
+	return client.request("PUT", "/incidents/"+url.PathEscape(id), body, nil)
+}
+
+// AddIncidentNote adds a note to the timeline of an incident
+func (client *Client) AddIncidentNote(id, content string) error {
+	body := map[string]interface{}{
+		"note": map[string]string{"content": content},
+	}
 
-	items, err := client.ListIncidents(queryOpts)
+	return client.request("POST", "/incidents/"+url.PathEscape(id)+"/notes", body, nil)
+}
+
+// EscalationRules returns the levels of an escalation policy, first level first
+func (client *Client) EscalationRules(policyID string) ([]EscalationRule, error) {
+	response := struct {
+		EscalationPolicy struct {
+			EscalationRules []EscalationRule `json:"escalation_rules"`
+		} `json:"escalation_policy"`
+	}{}
+
+	err := client.request("GET", "/escalation_policies/"+url.PathEscape(policyID), nil, &response)
 	if err != nil {
 		return nil, err
 	}
-	results = append(results, items.Incidents...)
 
-	for items.APIListObject.More {
-		queryOpts.APIListObject.Offset = items.APIListObject.Offset
-		items, err = client.ListIncidents(queryOpts)
-		if err != nil {
-			return nil, err
+	return response.EscalationPolicy.EscalationRules, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// request makes a request to the PagerDuty API as the configured email, sending body as
+// JSON if it isn't nil and decoding the response into result if it isn't nil
+func (client *Client) request(method, path string, body interface{}, result interface{}) error {
+	if client.email == "" {
+		return errNoEmail
+	}
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
 		}
-		results = append(results, items.Incidents...)
 	}
 
-	return results, nil
+	req, err := http.NewRequest(method, apiURL+path, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+client.apiKey)
+	req.Header.Set("From", client.email)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("PagerDuty API returned %s", resp.Status)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
 }
@@ -0,0 +1,138 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRequest is a request received by the fake PagerDuty server
+type fakeRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   map[string]interface{}
+}
+
+// fakePagerDuty starts a fake PagerDuty server that replies to each method and path with
+// the matching response, and records the requests it receives. Paths without a response
+// get an empty object
+func fakePagerDuty(t *testing.T, responses map[string]string) (*httptest.Server, *[]fakeRequest) {
+	requests := []fakeRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := fakeRequest{method: r.Method, path: r.URL.Path, header: r.Header}
+
+		raw, _ := ioutil.ReadAll(r.Body)
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &req.body); err != nil {
+				t.Errorf("invalid request body: %s", raw)
+			}
+		}
+
+		requests = append(requests, req)
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			response = "{}"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+// rewriteClient sends every request to the fake server, whatever host it was made for
+type rewriteClient struct {
+	server *httptest.Server
+}
+
+func (client rewriteClient) Do(req *http.Request) (*http.Response, error) {
+	serverURL, _ := url.Parse(client.server.URL)
+
+	req.URL.Scheme = serverURL.Scheme
+	req.URL.Host = serverURL.Host
+
+	return client.server.Client().Do(req)
+}
+
+func testClient(server *httptest.Server) *Client {
+	client := NewClient("token", "me@example.com")
+	client.HTTPClient = rewriteClient{server}
+
+	return client
+}
+
+func Test_UpdateIncident(t *testing.T) {
+	server, requests := fakePagerDuty(t, map[string]string{})
+	defer server.Close()
+
+	err := testClient(server).UpdateIncident("P1", map[string]interface{}{"escalation_level": 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 1)
+
+	req := (*requests)[0]
+	assert.Equal(t, "PUT", req.method)
+	assert.Equal(t, "/incidents/P1", req.path)
+	assert.Equal(t, "me@example.com", req.header.Get("From"))
+	assert.Equal(t, "Token token=token", req.header.Get("Authorization"))
+	assert.Equal(t, map[string]interface{}{"type": "incident_reference", "escalation_level": float64(2)}, req.body["incident"])
+}
+
+func Test_AddIncidentNote(t *testing.T) {
+	server, requests := fakePagerDuty(t, map[string]string{})
+	defer server.Close()
+
+	err := testClient(server).AddIncidentNote("P1", "Restarted the database")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", (*requests)[0].method)
+	assert.Equal(t, "/incidents/P1/notes", (*requests)[0].path)
+	assert.Equal(t, map[string]interface{}{"content": "Restarted the database"}, (*requests)[0].body["note"])
+}
+
+func Test_EscalationRules(t *testing.T) {
+	server, _ := fakePagerDuty(t, map[string]string{
+		"GET /escalation_policies/EP1": `{"escalation_policy": {"escalation_rules": [
+			{"targets": [{"summary": "Primary"}]},
+			{"targets": [{"summary": "Ann"}, {"summary": "Bob"}]}
+		]}}`,
+	})
+	defer server.Close()
+
+	rules, err := testClient(server).EscalationRules("EP1")
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "Bob", rules[1].Targets[1].Summary)
+}
+
+func Test_request_WithoutEmail(t *testing.T) {
+	server, requests := fakePagerDuty(t, map[string]string{})
+	defer server.Close()
+
+	client := testClient(server)
+	client.email = ""
+
+	assert.Equal(t, errNoEmail, client.UpdateIncident("P1", map[string]interface{}{"status": "resolved"}))
+	assert.Empty(t, *requests)
+}
+
+func Test_request_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := testClient(server).AddIncidentNote("P1", "Hello")
+
+	assert.EqualError(t, err, "PagerDuty API returned 403 Forbidden")
+}
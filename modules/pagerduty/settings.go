@@ -8,7 +8,7 @@ import (
 )
 
 const (
-	defaultFocusable = false
+	defaultFocusable = true
 	defaultTitle     = "PagerDuty"
 )
 
@@ -17,8 +17,9 @@ type Settings struct {
 	common *cfg.Common
 
 	apiKey           string        `help:"Your PagerDuty API key."`
+	email            string        `help:"The email address of your PagerDuty login, which incidents are acknowledged, resolved, reassigned and noted as." optional:"true"`
 	escalationFilter []interface{} `help:"An array of schedule names you want to filter the OnCalls on."`
-	myName           string        `help:"The name to highlight when on-call in PagerDuty."`
+	myName           string        `help:"The name to highlight when on-call in PagerDuty, and on the incidents assigned to it."`
 	scheduleIDs      []interface{} `help:"An array of schedule IDs you want to restrict the OnCalls query to."`
 	showIncidents    bool          `help:"Whether or not to list incidents." optional:"true"`
 	showOnCallEnd    bool          `help:"Whether or not to display the date the oncall schedule ends." optional:"true"`
@@ -33,6 +34,7 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
 
 		apiKey:           ymlConfig.UString("apiKey", ymlConfig.UString("apikey", os.Getenv("WTF_PAGERDUTY_API_KEY"))),
+		email:            ymlConfig.UString("email"),
 		escalationFilter: ymlConfig.UList("escalationFilter"),
 		myName:           ymlConfig.UString("myName"),
 		scheduleIDs:      ymlConfig.UList("scheduleIDs", []interface{}{}),
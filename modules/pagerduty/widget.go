@@ -7,6 +7,7 @@ import (
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 )
@@ -17,19 +18,34 @@ const (
 )
 
 type Widget struct {
-	view.TextWidget
-
-	settings *Settings
+	view.KeyboardWidget
+	view.ScrollableWidget
+
+	client    *Client
+	err       error
+	incidents []pagerduty.Incident
+	notifier  *notify.Notifier
+	onCalls   []pagerduty.OnCall
+	settings  *Settings
 }
 
 // NewWidget creates and returns an instance of PagerDuty widget
-func NewWidget(app *tview.Application, settings *Settings) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		TextWidget: view.NewTextWidget(app, settings.common),
+		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
 
+		client:   NewClient(settings.apiKey, settings.email),
+		notifier: notify.NewNotifier(settings.common),
 		settings: settings,
 	}
 
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
 	return &widget
 }
 
@@ -45,35 +61,83 @@ func (widget *Widget) Refresh() {
 	if widget.settings.showIncidents {
 		teamIDs := utils.ToStrs(widget.settings.teamIDs)
 		userIDs := utils.ToStrs(widget.settings.userIDs)
-		incidents, err2 = GetIncidents(widget.settings.apiKey, teamIDs, userIDs)
+		incidents, err2 = widget.client.Incidents(teamIDs, userIDs)
+
+		if err2 == nil {
+			widget.notifier.Publish(widget.incidentEvents(incidents)...)
+		}
 	}
 
 	if widget.settings.showSchedules {
 		scheduleIDs := utils.ToStrs(widget.settings.scheduleIDs)
-		onCalls, err1 = GetOnCalls(widget.settings.apiKey, scheduleIDs)
+		now := time.Now()
+		onCalls, err1 = widget.client.OnCalls(scheduleIDs, now, now)
 	}
 
-	var content string
-	wrap := false
-	if err1 != nil || err2 != nil {
-		wrap = true
-		if err1 != nil {
-			content += err1.Error()
-		}
-		if err2 != nil {
-			content += err2.Error()
-		}
+	if err1 != nil && err2 != nil {
+		widget.err = fmt.Errorf("%s\n%s", err1, err2)
+	} else if err1 != nil {
+		widget.err = err1
 	} else {
-		widget.View.SetWrap(false)
-		content = widget.contentFrom(onCalls, incidents)
+		widget.err = err2
 	}
 
-	widget.Redraw(func() (string, string, bool) { return widget.CommonSettings().Title, content, wrap })
+	if widget.err != nil {
+		widget.onCalls = nil
+		widget.incidents = nil
+	} else {
+		widget.onCalls = onCalls
+		widget.incidents = incidents
+	}
+	widget.SetItemCount(len(widget.incidents))
+
+	if err1 != nil {
+		widget.SetRefreshError(err1)
+	} else {
+		widget.SetRefreshError(err2)
+	}
+
+	widget.Render()
+}
+
+func (widget *Widget) Render() {
+	widget.Redraw(widget.content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerduty.Incident) string {
+// incidentEvents returns an event for the status of each incident. Incidents are keyed on
+// Id, as that is what the incident's id decodes into. The embedded APIObject's ID is empty
+func (widget *Widget) incidentEvents(incidents []pagerduty.Incident) []notify.Event {
+	events := []notify.Event{}
+
+	for _, incident := range incidents {
+		event := notify.Event{Key: incident.Id, Message: incident.Summary}
+
+		switch incident.Status {
+		case "triggered":
+			event.Type = notify.IncidentTriggered
+			event.Title = fmt.Sprintf("%s: incident triggered", widget.CommonSettings().Title)
+		case "acknowledged":
+			event.Type = notify.IncidentAcknowledged
+			event.Title = fmt.Sprintf("%s: incident acknowledged", widget.CommonSettings().Title)
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func (widget *Widget) content() (string, string, bool) {
+	title := widget.CommonSettings().Title
+
+	if widget.err != nil {
+		return title, widget.err.Error(), true
+	}
+
 	var str string
 
 	// Incidents
@@ -81,9 +145,17 @@ func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerd
 	if widget.settings.showIncidents {
 		str += fmt.Sprintf("[%s] Incidents[white]\n", widget.settings.common.Colors.Subheading)
 
-		if len(incidents) > 0 {
-			for _, incident := range incidents {
-				str += fmt.Sprintf("\n [%s]%s[white]\n", widget.settings.common.Colors.Label, tview.Escape(incident.Summary))
+		if len(widget.incidents) > 0 {
+			for idx, incident := range widget.incidents {
+				summary := tview.Escape(incident.Summary)
+				if widget.assignedToMe(incident) {
+					summary = fmt.Sprintf("[::b]%s[::-]", summary)
+				}
+
+				row := fmt.Sprintf("[%s] %s", widget.RowColor(idx), summary)
+
+				str += "\n"
+				str += utils.HighlightableHelper(widget.View, row, idx, len(incident.Summary))
 				str += fmt.Sprintf("     Status: %s\n", incident.Status)
 				str += fmt.Sprintf("    Service: %s\n", incident.Service.Summary)
 				str += fmt.Sprintf(" Escalation: %s\n", incident.EscalationPolicy.Summary)
@@ -105,7 +177,7 @@ func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerd
 
 	// OnCalls
 
-	for _, onCall := range onCalls {
+	for _, onCall := range widget.onCalls {
 		summary := onCall.EscalationPolicy.Summary
 		if len(widget.settings.escalationFilter) == 0 || filter[summary] {
 			onCallTree[summary] = append(onCallTree[summary], onCall)
@@ -153,7 +225,22 @@ func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerd
 		}
 	}
 
-	return str
+	return title, str, false
+}
+
+// assignedToMe returns TRUE if the incident is assigned to the user named myName
+func (widget *Widget) assignedToMe(incident pagerduty.Incident) bool {
+	if widget.settings.myName == "" {
+		return false
+	}
+
+	for _, assignment := range incident.Assignments {
+		if assignment.Assignee.Summary == widget.settings.myName {
+			return true
+		}
+	}
+
+	return false
 }
 
 // onCallEndSummary may or may not return the date that the specified onCall schedule ends
@@ -0,0 +1,55 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/notify"
+	"github.com/wtfutil/wtf/view"
+)
+
+func Test_incidentEvents(t *testing.T) {
+	widget := &Widget{
+		ScrollableWidget: view.NewScrollableWidget(nil, &cfg.Common{Title: "PagerDuty"}),
+	}
+
+	// The incidents are decoded the way the API client decodes them, which fills in the
+	// incident's own ID rather than the one of the embedded APIObject
+	incidents := []pagerduty.Incident{}
+	err := json.Unmarshal([]byte(`[
+		{"id": "P1", "status": "triggered", "summary": "Disk full"},
+		{"id": "P2", "status": "triggered", "summary": "API down"},
+		{"id": "P3", "status": "resolved", "summary": "Cert expired"}
+	]`), &incidents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]notify.Event{
+			{Type: notify.IncidentTriggered, Key: "P1", Title: "PagerDuty: incident triggered", Message: "Disk full"},
+			{Type: notify.IncidentTriggered, Key: "P2", Title: "PagerDuty: incident triggered", Message: "API down"},
+		},
+		widget.incidentEvents(incidents),
+	)
+}
+
+func Test_assignedToMe(t *testing.T) {
+	widget := &Widget{settings: &Settings{myName: "Ann"}}
+
+	incident := pagerduty.Incident{}
+	assert.False(t, widget.assignedToMe(incident))
+
+	incident.Assignments = []pagerduty.Assignment{{Assignee: pagerduty.APIObject{Summary: "Bob"}}}
+	assert.False(t, widget.assignedToMe(incident))
+
+	incident.Assignments = append(incident.Assignments, pagerduty.Assignment{Assignee: pagerduty.APIObject{Summary: "Ann"}})
+	assert.True(t, widget.assignedToMe(incident))
+
+	widget.settings.myName = ""
+	assert.False(t, widget.assignedToMe(incident))
+}
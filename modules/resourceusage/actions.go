@@ -0,0 +1,68 @@
+package resourceusage
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// selectedProcess returns the highlighted process, or nil if none is
+func (widget *Widget) selectedProcess() *processInfo {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	idx := widget.GetSelected()
+	if idx < 0 || idx >= len(widget.processes) {
+		return nil
+	}
+
+	proc := widget.processes[idx]
+	return &proc
+}
+
+// killSelected asks whether to terminate the highlighted process, which lets it clean up,
+// or to kill it outright, and sends it the chosen signal
+func (widget *Widget) killSelected() {
+	proc := widget.selectedProcess()
+	if proc == nil {
+		return
+	}
+
+	text := fmt.Sprintf("Stop process %d (%s)?", proc.pid, tview.Escape(proc.name))
+
+	widget.ShowChoice(text, []string{"Terminate", "Kill", "Cancel"}, func(choice string) {
+		if choice == "Cancel" {
+			return
+		}
+
+		kill := choice == "Kill"
+
+		go func() {
+			verb := "Terminated"
+			if kill {
+				verb = "Killed"
+			}
+
+			widget.ReportAction(fmt.Sprintf("%s process %d (%s)", verb, proc.pid, proc.name), signalProcess(proc.pid, kill), widget.RequestRefresh)
+		}()
+	})
+}
+
+// toggleSort switches the processes between being sorted by CPU and by memory
+func (widget *Widget) toggleSort() {
+	widget.mutex.Lock()
+
+	if widget.processSort == sortByRSS {
+		widget.processSort = sortByCPU
+	} else {
+		widget.processSort = sortByRSS
+	}
+
+	widget.processes = topProcesses(widget.allProcesses, widget.processSort, widget.settings.processCount)
+
+	widget.mutex.Unlock()
+
+	widget.Render()
+}
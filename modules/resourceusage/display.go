@@ -0,0 +1,220 @@
+package resourceusage
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/bytefmt"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) display() (string, string, bool) {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
+
+	widget.updateBorder()
+
+	str := widget.sparklines(widget.usageLines())
+
+	if widget.settings.showDisk && len(widget.disks) > 0 {
+		str += widget.subheading("Disks")
+		str += widget.diskContent()
+	}
+
+	if widget.settings.showDiskIO {
+		str += widget.subheading("Disk I/O")
+		str += widget.sparklines(widget.diskIOLines())
+	}
+
+	if widget.settings.showNetwork {
+		str += widget.subheading("Network")
+		str += widget.sparklines(widget.networkLines())
+	}
+
+	if widget.settings.showProcesses {
+		str += widget.subheading(fmt.Sprintf("Processes (by %s)", widget.processSort))
+		str += widget.processContent()
+	}
+
+	return widget.CommonSettings().Title, str, false
+}
+
+// updateBorder draws the border in the alert color while a metric is above its threshold.
+// While the widget has focus the border stays in the focus color
+func (widget *Widget) updateBorder() {
+	if widget.View.HasFocus() {
+		return
+	}
+
+	color := widget.ScrollableWidget.BorderColor()
+	if len(widget.breached) > 0 {
+		color = widget.settings.alertColor
+	}
+
+	widget.View.SetBorderColor(wtf.ColorFor(color))
+}
+
+// usageLines returns a sparkline for each CPU, or one for all of them, and for the memory
+// and the swap, each in percent used
+func (widget *Widget) usageLines() []view.Sparkline {
+	lines := []view.Sparkline{}
+
+	if widget.settings.showCPU {
+		for idx, stat := range widget.cpuStats {
+			label := fmt.Sprint(idx)
+			if widget.settings.cpuCombined {
+				label = "CPU"
+			}
+
+			lines = append(lines, view.Sparkline{
+				Label:      label,
+				Values:     widget.historyOf(cpuMetric(idx, widget.settings.cpuCombined)),
+				Max:        100,
+				ValueLabel: widget.alertLabel(metricCPU, fmt.Sprintf("%d%%", int(stat))),
+				LabelColor: "red",
+			})
+		}
+	}
+
+	if widget.memInfo == nil {
+		return lines
+	}
+
+	if widget.settings.showMem {
+		lines = append(lines, view.Sparkline{
+			Label:      "Mem",
+			Values:     widget.historyOf(metricMem),
+			Max:        100,
+			ValueLabel: widget.alertLabel(metricMem, usedOfTotal(widget.memInfo.Used, widget.memInfo.Total)),
+			LabelColor: "green",
+		})
+	}
+
+	if widget.settings.showSwp {
+		lines = append(lines, view.Sparkline{
+			Label:      "Swp",
+			Values:     widget.historyOf(metricSwap),
+			Max:        100,
+			ValueLabel: widget.alertLabel(metricSwap, usedOfTotal(widget.memInfo.SwapTotal-widget.memInfo.SwapFree, widget.memInfo.SwapTotal)),
+			LabelColor: "yellow",
+		})
+	}
+
+	return lines
+}
+
+func (widget *Widget) diskContent() string {
+	bars := []view.Bar{}
+
+	for _, d := range widget.disks {
+		bars = append(bars, view.Bar{
+			Label:      d.mount,
+			Percent:    int(d.usage.UsedPercent),
+			ValueLabel: widget.alertLabel(metricDisk, usedOfTotal(d.usage.Used, d.usage.Total)),
+			LabelColor: "blue",
+		})
+	}
+
+	return view.BuildStars(
+		bars,
+		widget.settings.graphStars,
+		widget.settings.graphIcon,
+	)
+}
+
+func (widget *Widget) diskIOLines() []view.Sparkline {
+	return []view.Sparkline{
+		{
+			Label:      "read",
+			Values:     widget.historyOf("disk read"),
+			ValueLabel: perSecond(widget.diskIO.in),
+			LabelColor: "blue",
+		},
+		{
+			Label:      "write",
+			Values:     widget.historyOf("disk write"),
+			ValueLabel: perSecond(widget.diskIO.out),
+			LabelColor: "blue",
+		},
+	}
+}
+
+func (widget *Widget) networkLines() []view.Sparkline {
+	lines := []view.Sparkline{}
+
+	for _, rates := range widget.network {
+		lines = append(lines,
+			view.Sparkline{
+				Label:      rates.name + " rx",
+				Values:     widget.historyOf(rates.name + " rx"),
+				ValueLabel: perSecond(rates.in),
+				LabelColor: "lightblue",
+			},
+			view.Sparkline{
+				Label:      rates.name + " tx",
+				Values:     widget.historyOf(rates.name + " tx"),
+				ValueLabel: perSecond(rates.out),
+				LabelColor: "lightblue",
+			},
+		)
+	}
+
+	return lines
+}
+
+func (widget *Widget) processContent() string {
+	if len(widget.processes) == 0 {
+		return " no processes\n"
+	}
+
+	str := ""
+	for idx, proc := range widget.processes {
+		text := fmt.Sprintf("%7d %-16.16s %5.1f%% %6s", proc.pid, proc.name, proc.cpu, bytefmt.ByteSize(proc.rss))
+		row := fmt.Sprintf("[%s]%s", widget.RowColor(idx), tview.Escape(text))
+
+		str += utils.HighlightableHelper(widget.View, row, idx, len(text))
+	}
+
+	return str
+}
+
+func (widget *Widget) sparklines(lines []view.Sparkline) string {
+	return view.BuildSparklines(
+		lines,
+		widget.settings.graphStars,
+		widget.settings.sparklineStyle,
+	)
+}
+
+func (widget *Widget) subheading(text string) string {
+	return fmt.Sprintf("[%s]%s[white]\n", widget.settings.common.Colors.Subheading, text)
+}
+
+// alertLabel draws the label in the alert color if the metric is above its threshold
+func (widget *Widget) alertLabel(metric, label string) string {
+	if !widget.breached[metric] {
+		return label
+	}
+
+	return fmt.Sprintf("[%s]%s[default]", widget.settings.alertColor, label)
+}
+
+// usedOfTotal returns i.e.: "3.5/16G", with the unit only shown once if both are the same
+func usedOfTotal(used, total uint64) string {
+	usedLabel := bytefmt.ByteSize(used)
+	totalLabel := bytefmt.ByteSize(total)
+
+	if usedLabel[len(usedLabel)-1] == totalLabel[len(totalLabel)-1] {
+		usedLabel = usedLabel[:len(usedLabel)-1]
+	}
+
+	return fmt.Sprintf("%s/%s", usedLabel, totalLabel)
+}
+
+func perSecond(bytes float64) string {
+	return bytefmt.ByteSize(uint64(bytes)) + "/s"
+}
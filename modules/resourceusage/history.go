@@ -0,0 +1,52 @@
+package resourceusage
+
+// history is a rolling buffer of the most recent samples of a metric, oldest first
+type history struct {
+	size   int
+	values []float64
+}
+
+func newHistory(size int) *history {
+	if size < 1 {
+		size = 1
+	}
+
+	return &history{
+		size:   size,
+		values: make([]float64, 0, size),
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// add appends a sample, dropping the oldest one if the buffer is full
+func (hist *history) add(value float64) {
+	if len(hist.values) < hist.size {
+		hist.values = append(hist.values, value)
+		return
+	}
+
+	copy(hist.values, hist.values[1:])
+	hist.values[len(hist.values)-1] = value
+}
+
+// record adds a sample to the history of the named metric, creating it on the first sample
+func (widget *Widget) record(name string, value float64) {
+	hist, ok := widget.histories[name]
+	if !ok {
+		hist = newHistory(widget.settings.historyLength)
+		widget.histories[name] = hist
+	}
+
+	hist.add(value)
+}
+
+// historyOf returns the samples of the named metric, oldest first
+func (widget *Widget) historyOf(name string) []float64 {
+	hist, ok := widget.histories[name]
+	if !ok {
+		return []float64{}
+	}
+
+	return hist.values
+}
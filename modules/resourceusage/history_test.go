@@ -0,0 +1,27 @@
+package resourceusage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_history_add(t *testing.T) {
+	hist := newHistory(3)
+
+	hist.add(1)
+	hist.add(2)
+	assert.Equal(t, []float64{1, 2}, hist.values)
+
+	hist.add(3)
+	hist.add(4)
+	assert.Equal(t, []float64{2, 3, 4}, hist.values)
+}
+
+func Test_newHistory_NoSize(t *testing.T) {
+	hist := newHistory(0)
+
+	hist.add(1)
+	hist.add(2)
+	assert.Equal(t, []float64{2}, hist.values)
+}
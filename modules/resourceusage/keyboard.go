@@ -0,0 +1,16 @@
+package resourceusage
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.InitializeCommonControls(widget.Refresh)
+
+	widget.SetKeyboardChar("j", widget.Next, "Select next process")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous process")
+	widget.SetKeyboardChar("s", widget.toggleSort, "Sort the processes by CPU or by memory")
+	widget.SetKeyboardChar("x", widget.killSelected, "Terminate or kill the selected process")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next process")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous process")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
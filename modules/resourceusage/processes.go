@@ -0,0 +1,127 @@
+package resourceusage
+
+import (
+	"sort"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+// What the processes can be sorted by
+const (
+	sortByCPU = "cpu"
+	sortByRSS = "rss"
+)
+
+// processInfo is a snapshot of a running process
+type processInfo struct {
+	pid  int32
+	name string
+	cpu  float64
+	rss  uint64
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loadProcesses reads every running process. Processes are kept between refreshes because
+// gopsutil measures a process's CPU use since the last time it was measured: the first
+// time a process is seen, its CPU use is 0
+func (widget *Widget) loadProcesses() []processInfo {
+	pids, err := process.Pids()
+	if err != nil {
+		return []processInfo{}
+	}
+
+	running := map[int32]*process.Process{}
+	infos := []processInfo{}
+
+	for _, pid := range pids {
+		proc, ok := widget.processCache[pid]
+		if !ok {
+			proc, err = process.NewProcess(pid)
+			if err != nil {
+				// It exited after it was listed
+				continue
+			}
+		}
+		running[pid] = proc
+
+		info, err := readProcess(proc)
+		if err != nil {
+			// It exited, or belongs to a user whose processes can't be read
+			continue
+		}
+
+		infos = append(infos, info)
+	}
+
+	widget.processCache = running
+
+	return infos
+}
+
+func readProcess(proc *process.Process) (processInfo, error) {
+	name, err := proc.Name()
+	if err != nil {
+		return processInfo{}, err
+	}
+
+	cpu, err := proc.Percent(0)
+	if err != nil {
+		return processInfo{}, err
+	}
+
+	memInfo, err := proc.MemoryInfo()
+	if err != nil {
+		return processInfo{}, err
+	}
+
+	return processInfo{pid: proc.Pid, name: name, cpu: cpu, rss: memInfo.RSS}, nil
+}
+
+// topProcesses returns the count processes that use the most CPU, or memory if sortBy is
+// rss. Ties are broken by the other measure, then by PID
+func topProcesses(infos []processInfo, sortBy string, count int) []processInfo {
+	sorted := append([]processInfo{}, infos...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+
+		if sortBy == sortByRSS {
+			if a.rss != b.rss {
+				return a.rss > b.rss
+			}
+			if a.cpu != b.cpu {
+				return a.cpu > b.cpu
+			}
+		} else {
+			if a.cpu != b.cpu {
+				return a.cpu > b.cpu
+			}
+			if a.rss != b.rss {
+				return a.rss > b.rss
+			}
+		}
+
+		return a.pid < b.pid
+	})
+
+	if len(sorted) > count {
+		sorted = sorted[:count]
+	}
+
+	return sorted
+}
+
+// signalProcess terminates the process, or kills it if kill is TRUE
+func signalProcess(pid int32, kill bool) error {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	if kill {
+		return proc.Kill()
+	}
+
+	return proc.Terminate()
+}
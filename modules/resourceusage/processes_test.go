@@ -0,0 +1,30 @@
+package resourceusage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_topProcesses(t *testing.T) {
+	infos := []processInfo{
+		{pid: 1, name: "init", cpu: 0, rss: 10},
+		{pid: 2, name: "browser", cpu: 20, rss: 2000},
+		{pid: 3, name: "compiler", cpu: 90, rss: 500},
+		{pid: 4, name: "editor", cpu: 20, rss: 300},
+	}
+
+	pids := func(infos []processInfo) []int32 {
+		result := []int32{}
+		for _, info := range infos {
+			result = append(result, info.pid)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int32{3, 2, 4}, pids(topProcesses(infos, sortByCPU, 3)))
+	assert.Equal(t, []int32{2, 3, 4, 1}, pids(topProcesses(infos, sortByRSS, 10)))
+
+	// The processes are sorted in a copy
+	assert.Equal(t, int32(1), infos[0].pid)
+}
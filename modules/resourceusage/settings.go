@@ -1,12 +1,18 @@
 package resourceusage
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/view"
 )
 
 const (
-	defaultFocusable = false
+	defaultFocusable = true
 	defaultTitle     = "ResourceUsage"
 )
 
@@ -16,6 +22,21 @@ type Settings struct {
 	showCPU     bool
 	showMem     bool
 	showSwp     bool
+
+	alertColor     string      `help:"The color of the border while a metric is above its threshold." optional:"true"`
+	graphIcon      string      `help:"The character used to draw the disk usage bars." optional:"true"`
+	graphStars     int         `help:"The width of the bars and sparklines, in characters." optional:"true"`
+	historyLength  int         `help:"The number of samples of each metric to keep for its sparkline." values:"A positive integer, 0..n." optional:"true"`
+	interfaces     []string    `help:"The network interfaces to show. Defaults to all of them, except loopback." optional:"true"`
+	mounts         []string    `help:"The mount points to show. Defaults to all of the physical ones." optional:"true"`
+	processCount   int         `help:"The number of processes to show." values:"A positive integer, 0..n." optional:"true"`
+	processSort    string      `help:"What the processes are sorted by." values:"cpu or rss" optional:"true"`
+	showDisk       bool        `help:"Whether or not to show the disk usage of each mount point." values:"true or false" optional:"true"`
+	showDiskIO     bool        `help:"Whether or not to show the disk read and write throughput." values:"true or false" optional:"true"`
+	showNetwork    bool        `help:"Whether or not to show the throughput of each network interface." values:"true or false" optional:"true"`
+	showProcesses  bool        `help:"Whether or not to show the processes using the most CPU or memory." values:"true or false" optional:"true"`
+	sparklineStyle string      `help:"How the sparklines are drawn." values:"block or braille" optional:"true"`
+	thresholds     []threshold `help:"Limits, in percent, for cpu, mem, swap and disk, and how many seconds a metric can stay above its limit before the border changes color." optional:"true"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
@@ -25,7 +46,59 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 		showCPU:     ymlConfig.UBool("showCPU", true),
 		showMem:     ymlConfig.UBool("showMem", true),
 		showSwp:     ymlConfig.UBool("showSwp", true),
+
+		alertColor:     ymlConfig.UString("alertColor", "red"),
+		graphIcon:      ymlConfig.UString("graphIcon", "|"),
+		graphStars:     ymlConfig.UInt("graphStars", 20),
+		historyLength:  ymlConfig.UInt("historyLength", 60),
+		interfaces:     utils.ToStrs(ymlConfig.UList("interfaces")),
+		mounts:         utils.ToStrs(ymlConfig.UList("mounts")),
+		processCount:   ymlConfig.UInt("processCount", 10),
+		processSort:    ymlConfig.UString("processSort", sortByCPU),
+		showDisk:       ymlConfig.UBool("showDisk", false),
+		showDiskIO:     ymlConfig.UBool("showDiskIO", false),
+		showNetwork:    ymlConfig.UBool("showNetwork", false),
+		showProcesses:  ymlConfig.UBool("showProcesses", false),
+		sparklineStyle: ymlConfig.UString("sparklineStyle", view.SparklineBlock),
+		thresholds:     parseThresholds(ymlConfig),
 	}
 
 	return &settings
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// parseThresholds reads the thresholds, each keyed by the metric it applies to:
+//
+//    thresholds:
+//      cpu:
+//        limit: 90
+//        duration: 60
+//      disk:
+//        limit: 95
+//
+// The duration is in seconds, and defaults to 0 so that the border changes color as soon as
+// the metric goes above the limit
+func parseThresholds(ymlConfig *config.Config) []threshold {
+	thresholdsConfig, err := ymlConfig.Map("thresholds")
+	if err != nil {
+		return []threshold{}
+	}
+
+	thresholds := []threshold{}
+	for metric := range thresholdsConfig {
+		path := fmt.Sprintf("thresholds.%s", metric)
+
+		thresholds = append(thresholds, threshold{
+			metric:   metric,
+			limit:    ymlConfig.UFloat64(path+".limit", 100),
+			duration: time.Duration(ymlConfig.UInt(path+".duration", 0)) * time.Second,
+		})
+	}
+
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i].metric < thresholds[j].metric
+	})
+
+	return thresholds
+}
@@ -0,0 +1,320 @@
+package resourceusage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/mem"
+	"github.com/shirou/gopsutil/net"
+)
+
+// diskUsage is the usage of the filesystem mounted at a mount point
+type diskUsage struct {
+	mount string
+	usage *disk.UsageStat
+}
+
+// ioTotals are the total bytes read and written, or received and sent, since boot
+type ioTotals struct {
+	in  uint64
+	out uint64
+}
+
+// ioRates are the bytes per second read and written, or received and sent, between two
+// samples
+type ioRates struct {
+	name string
+	in   float64
+	out  float64
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// sample reads the metrics that are displayed, or that have thresholds, and adds them to
+// their histories
+func (widget *Widget) sample(now time.Time) {
+	elapsed := now.Sub(widget.sampledAt)
+	if widget.sampledAt.IsZero() {
+		elapsed = 0
+	}
+	widget.sampledAt = now
+
+	values := map[string]float64{}
+
+	if widget.settings.showCPU || widget.tracker.watches(metricCPU) {
+		widget.cpuStats = cpuStats(widget.settings.cpuCombined)
+
+		for idx, stat := range widget.cpuStats {
+			widget.record(cpuMetric(idx, widget.settings.cpuCombined), stat)
+		}
+
+		if len(widget.cpuStats) > 0 {
+			values[metricCPU] = average(widget.cpuStats)
+		}
+	}
+
+	if widget.settings.showMem || widget.settings.showSwp ||
+		widget.tracker.watches(metricMem) || widget.tracker.watches(metricSwap) {
+		widget.memInfo = nil
+		if memInfo, err := mem.VirtualMemory(); err == nil {
+			widget.memInfo = memInfo
+
+			values[metricMem] = memInfo.UsedPercent
+			values[metricSwap] = swapPercent(memInfo)
+
+			widget.record(metricMem, values[metricMem])
+			widget.record(metricSwap, values[metricSwap])
+		}
+	}
+
+	if widget.settings.showDisk || widget.tracker.watches(metricDisk) {
+		widget.disks = diskUsages(widget.settings.mounts)
+
+		for _, d := range widget.disks {
+			values[metricDisk] = math.Max(values[metricDisk], d.usage.UsedPercent)
+		}
+	}
+
+	if widget.settings.showDiskIO {
+		totals, err := diskIOTotals()
+		if err == nil {
+			if widget.lastDiskIO != nil {
+				widget.diskIO = ioRate("disk", *widget.lastDiskIO, totals, elapsed)
+				widget.record("disk read", widget.diskIO.in)
+				widget.record("disk write", widget.diskIO.out)
+			}
+			widget.lastDiskIO = &totals
+		}
+	}
+
+	if widget.settings.showNetwork {
+		totals, err := netIOTotals(widget.settings.interfaces)
+		if err == nil {
+			widget.network = []ioRates{}
+
+			names := []string{}
+			for name := range totals {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				last, ok := widget.lastNetwork[name]
+				if !ok {
+					continue
+				}
+
+				rates := ioRate(name, last, totals[name], elapsed)
+				widget.network = append(widget.network, rates)
+				widget.record(name+" rx", rates.in)
+				widget.record(name+" tx", rates.out)
+			}
+
+			widget.lastNetwork = totals
+		}
+	}
+
+	widget.breached = widget.tracker.update(values, now)
+}
+
+func cpuStats(combined bool) []float64 {
+	stats, err := cpu.Percent(time.Duration(0), !combined)
+	if err != nil {
+		return []float64{}
+	}
+
+	// Stats sometimes jump outside the 0-100 range, possibly due to timing
+	for idx, stat := range stats {
+		stats[idx] = math.Max(0, math.Min(100, stat))
+	}
+
+	return stats
+}
+
+// cpuMetric returns the name of the history of a CPU
+func cpuMetric(idx int, combined bool) string {
+	if combined {
+		return metricCPU
+	}
+
+	return fmt.Sprintf("%s%d", metricCPU, idx)
+}
+
+// diskUsages returns the usage of the filesystems mounted at the mount points, or at every
+// physical device's mount point if there are none
+func diskUsages(mounts []string) []diskUsage {
+	if len(mounts) == 0 {
+		partitions, err := disk.Partitions(false)
+		if err != nil {
+			return []diskUsage{}
+		}
+
+		for _, partition := range partitions {
+			mounts = append(mounts, partition.Mountpoint)
+		}
+	}
+
+	usages := []diskUsage{}
+	seen := map[string]bool{}
+
+	for _, mount := range mounts {
+		if seen[mount] {
+			continue
+		}
+		seen[mount] = true
+
+		usage, err := disk.Usage(mount)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+
+		usages = append(usages, diskUsage{mount: mount, usage: usage})
+	}
+
+	return usages
+}
+
+// diskIOTotals returns the bytes read from and written to all the disks
+func diskIOTotals() (ioTotals, error) {
+	counters, err := disk.IOCounters()
+	if err != nil {
+		return ioTotals{}, err
+	}
+
+	totals := ioTotals{}
+	for _, name := range wholeDisks(counters) {
+		totals.in += counters[name].ReadBytes
+		totals.out += counters[name].WriteBytes
+	}
+
+	return totals, nil
+}
+
+// wholeDisks returns the names of the devices that are not partitions of another device,
+// so that the bytes read from a partition aren't counted twice. On Linux the counters of
+// sda include those of sda1, and those of nvme0n1 include those of nvme0n1p1
+func wholeDisks(counters map[string]disk.IOCountersStat) []string {
+	names := []string{}
+	for name := range counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	disks := []string{}
+	for _, name := range names {
+		partition := false
+
+		for _, other := range names {
+			if isPartitionOf(name, other) {
+				partition = true
+				break
+			}
+		}
+
+		if !partition {
+			disks = append(disks, name)
+		}
+	}
+
+	return disks
+}
+
+// isPartitionOf returns TRUE if the device name is that of a partition of the disk, i.e.:
+// sda1 of sda, or nvme0n1p1 of nvme0n1. dm-10 is not a partition of dm-1
+func isPartitionOf(name, disk string) bool {
+	if disk == "" || name == disk || !strings.HasPrefix(name, disk) {
+		return false
+	}
+
+	suffix := name[len(disk):]
+	if unicode.IsDigit(rune(disk[len(disk)-1])) {
+		// Disks whose names end in a digit number their partitions p1, p2...
+		if !strings.HasPrefix(suffix, "p") {
+			return false
+		}
+		suffix = suffix[1:]
+	}
+
+	if suffix == "" {
+		return false
+	}
+
+	for _, char := range suffix {
+		if !unicode.IsDigit(char) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// netIOTotals returns the bytes received and sent by each of the interfaces, or by every
+// interface except loopback if there are none
+func netIOTotals(interfaces []string) (map[string]ioTotals, error) {
+	counters, err := net.IOCounters(true)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range interfaces {
+		wanted[name] = true
+	}
+
+	totals := map[string]ioTotals{}
+	for _, counter := range counters {
+		if len(wanted) > 0 && !wanted[counter.Name] {
+			continue
+		}
+
+		if len(wanted) == 0 && strings.HasPrefix(counter.Name, "lo") {
+			continue
+		}
+
+		totals[counter.Name] = ioTotals{in: counter.BytesRecv, out: counter.BytesSent}
+	}
+
+	return totals, nil
+}
+
+// ioRate returns the bytes per second between two samples taken elapsed apart. Counters
+// that went down, i.e.: because the device was reset, count as no activity
+func ioRate(name string, last, current ioTotals, elapsed time.Duration) ioRates {
+	rates := ioRates{name: name}
+
+	if elapsed <= 0 {
+		return rates
+	}
+
+	if current.in >= last.in {
+		rates.in = float64(current.in-last.in) / elapsed.Seconds()
+	}
+	if current.out >= last.out {
+		rates.out = float64(current.out-last.out) / elapsed.Seconds()
+	}
+
+	return rates
+}
+
+func average(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+func swapPercent(memInfo *mem.VirtualMemoryStat) float64 {
+	if memInfo.SwapTotal == 0 {
+		return 0
+	}
+
+	return float64(memInfo.SwapTotal-memInfo.SwapFree) / float64(memInfo.SwapTotal) * 100
+}
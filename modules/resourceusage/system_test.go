@@ -0,0 +1,59 @@
+package resourceusage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ioRate(t *testing.T) {
+	last := ioTotals{in: 1000, out: 5000}
+
+	assert.Equal(t, ioRates{name: "eth0", in: 500, out: 0}, ioRate("eth0", last, ioTotals{in: 2000, out: 5000}, 2*time.Second))
+	assert.Equal(t, ioRates{name: "eth0"}, ioRate("eth0", last, ioTotals{in: 2000, out: 6000}, 0))
+
+	// The counters were reset
+	assert.Equal(t, ioRates{name: "eth0", in: 0, out: 100}, ioRate("eth0", last, ioTotals{in: 10, out: 5100}, time.Second))
+}
+
+func Test_wholeDisks(t *testing.T) {
+	counters := map[string]disk.IOCountersStat{}
+	for _, name := range []string{"sda", "sda1", "sda2", "nvme0n1", "nvme0n1p1", "dm-1", "dm-10", "mmcblk0", "mmcblk0p2"} {
+		counters[name] = disk.IOCountersStat{Name: name}
+	}
+
+	assert.Equal(t, []string{"dm-1", "dm-10", "mmcblk0", "nvme0n1", "sda"}, wholeDisks(counters))
+}
+
+func Test_isPartitionOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		disk     string
+		expected bool
+	}{
+		{name: "sda1", disk: "sda", expected: true},
+		{name: "sdab", disk: "sda", expected: false},
+		{name: "nvme0n1p1", disk: "nvme0n1", expected: true},
+		{name: "nvme0n10", disk: "nvme0n1", expected: false},
+		{name: "sda", disk: "sda", expected: false},
+		{name: "sda", disk: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" of "+tt.disk, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isPartitionOf(tt.name, tt.disk))
+		})
+	}
+}
+
+func Test_cpuMetric(t *testing.T) {
+	assert.Equal(t, "cpu", cpuMetric(3, true))
+	assert.Equal(t, "cpu3", cpuMetric(3, false))
+}
+
+func Test_usedOfTotal(t *testing.T) {
+	assert.Equal(t, "1.5/4G", usedOfTotal(1536*1024*1024, 4*1024*1024*1024))
+	assert.Equal(t, "512M/4G", usedOfTotal(512*1024*1024, 4*1024*1024*1024))
+}
@@ -0,0 +1,74 @@
+package resourceusage
+
+import (
+	"time"
+)
+
+// The metrics that thresholds can be set for
+const (
+	metricCPU  = "cpu"
+	metricDisk = "disk"
+	metricMem  = "mem"
+	metricSwap = "swap"
+)
+
+// threshold is a limit, in percent, that a metric should not stay above for longer than
+// duration
+type threshold struct {
+	metric   string
+	limit    float64
+	duration time.Duration
+}
+
+// thresholdTracker keeps track of how long each metric has been above its threshold
+type thresholdTracker struct {
+	thresholds []threshold
+	since      map[string]time.Time
+}
+
+func newThresholdTracker(thresholds []threshold) *thresholdTracker {
+	return &thresholdTracker{
+		thresholds: thresholds,
+		since:      map[string]time.Time{},
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// update records the values of the metrics, sampled at now, and returns the metrics that
+// have stayed above their limit for at least their duration. A metric that is missing from
+// values, i.e.: because it could not be read, is treated as being below its limit
+func (tracker *thresholdTracker) update(values map[string]float64, now time.Time) map[string]bool {
+	breached := map[string]bool{}
+
+	for _, threshold := range tracker.thresholds {
+		value, ok := values[threshold.metric]
+		if !ok || value <= threshold.limit {
+			delete(tracker.since, threshold.metric)
+			continue
+		}
+
+		since, ok := tracker.since[threshold.metric]
+		if !ok {
+			since = now
+			tracker.since[threshold.metric] = now
+		}
+
+		if now.Sub(since) >= threshold.duration {
+			breached[threshold.metric] = true
+		}
+	}
+
+	return breached
+}
+
+// watches returns TRUE if there is a threshold for the metric
+func (tracker *thresholdTracker) watches(metric string) bool {
+	for _, threshold := range tracker.thresholds {
+		if threshold.metric == metric {
+			return true
+		}
+	}
+
+	return false
+}
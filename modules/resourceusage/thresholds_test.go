@@ -0,0 +1,66 @@
+package resourceusage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_thresholdTracker_update(t *testing.T) {
+	tracker := newThresholdTracker([]threshold{
+		{metric: metricCPU, limit: 90, duration: time.Minute},
+		{metric: metricDisk, limit: 95},
+	})
+
+	start := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, tracker.update(map[string]float64{metricCPU: 95, metricDisk: 50}, start))
+	assert.Empty(t, tracker.update(map[string]float64{metricCPU: 95, metricDisk: 50}, start.Add(30*time.Second)))
+
+	assert.Equal(
+		t,
+		map[string]bool{metricCPU: true, metricDisk: true},
+		tracker.update(map[string]float64{metricCPU: 99, metricDisk: 96}, start.Add(time.Minute)),
+	)
+
+	// Going back under the limit starts the wait over
+	assert.Empty(t, tracker.update(map[string]float64{metricCPU: 10}, start.Add(90*time.Second)))
+	assert.Empty(t, tracker.update(map[string]float64{metricCPU: 95}, start.Add(2*time.Minute)))
+	assert.Equal(
+		t,
+		map[string]bool{metricCPU: true},
+		tracker.update(map[string]float64{metricCPU: 95}, start.Add(3*time.Minute)),
+	)
+}
+
+func Test_thresholdTracker_watches(t *testing.T) {
+	tracker := newThresholdTracker([]threshold{{metric: metricMem, limit: 80}})
+
+	assert.True(t, tracker.watches(metricMem))
+	assert.False(t, tracker.watches(metricSwap))
+}
+
+func Test_parseThresholds(t *testing.T) {
+	ymlConfig, err := config.ParseYaml(`
+thresholds:
+  disk:
+    limit: 95
+  cpu:
+    limit: 90.5
+    duration: 60
+`)
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]threshold{
+			{metric: metricCPU, limit: 90.5, duration: time.Minute},
+			{metric: metricDisk, limit: 95},
+		},
+		parseThresholds(ymlConfig),
+	)
+
+	assert.Empty(t, parseThresholds(&config.Config{Root: map[string]interface{}{}}))
+}
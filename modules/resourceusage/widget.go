@@ -1,34 +1,61 @@
 package resourceusage
 
 import (
-	"fmt"
-	"math"
+	"sync"
 	"time"
 
-	"code.cloudfoundry.org/bytefmt"
 	"github.com/rivo/tview"
-	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/mem"
+	"github.com/shirou/gopsutil/process"
 	"github.com/wtfutil/wtf/view"
 )
 
 // Widget define wtf widget to register widget later
 type Widget struct {
-	view.BarGraph
-
-	app      *tview.Application
-	settings *Settings
+	view.KeyboardWidget
+	view.ScrollableWidget
+
+	breached     map[string]bool
+	cpuStats     []float64
+	diskIO       ioRates
+	disks        []diskUsage
+	histories    map[string]*history
+	lastDiskIO   *ioTotals
+	lastNetwork  map[string]ioTotals
+	memInfo      *mem.VirtualMemoryStat
+	mutex        *sync.Mutex
+	network      []ioRates
+	allProcesses []processInfo
+	processCache map[int32]*process.Process
+	processes    []processInfo
+	processSort  string
+	sampledAt    time.Time
+	settings     *Settings
+	tracker      *thresholdTracker
 }
 
 // NewWidget Make new instance of widget
-func NewWidget(app *tview.Application, settings *Settings) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
-		BarGraph: view.NewBarGraph(app, settings.common.Name, settings.common),
-
-		app:      app,
-		settings: settings,
+		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
+
+		breached:     map[string]bool{},
+		histories:    map[string]*history{},
+		lastNetwork:  map[string]ioTotals{},
+		mutex:        &sync.Mutex{},
+		processCache: map[int32]*process.Process{},
+		processSort:  settings.processSort,
+		settings:     settings,
+		tracker:      newThresholdTracker(settings.thresholds),
 	}
 
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
 	widget.View.SetWrap(false)
 	widget.View.SetWordWrap(false)
 
@@ -37,92 +64,17 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-// MakeGraph - Load the dead drop stats
-func MakeGraph(widget *Widget) {
-	cpuStats, memInfo := getDataFromSystem(widget)
-
-	var itemsCount = 0
-	if widget.settings.showCPU {
-		itemsCount += len(cpuStats)
-	}
-
-	if widget.settings.showMem {
-		itemsCount++
-	}
-
-	if widget.settings.showSwp {
-		itemsCount++
-	}
-
-	var stats = make([]view.Bar, itemsCount)
-	var nextIndex = 0
-
-	if widget.settings.showCPU && len(cpuStats) > 0 {
-		for i, stat := range cpuStats {
-			// Stats sometimes jump outside the 0-100 range, possibly due to timing
-			stat = math.Min(100, stat)
-			stat = math.Max(0, stat)
-
-			var label string
-			if widget.settings.cpuCombined {
-				label = "CPU"
-			} else {
-				label = fmt.Sprint(i)
-			}
-
-			bar := view.Bar{
-				Label:      label,
-				Percent:    int(stat),
-				ValueLabel: fmt.Sprintf("%d%%", int(stat)),
-				LabelColor: "red",
-			}
-
-			stats[nextIndex] = bar
-			nextIndex++
-		}
-	}
-
-	if widget.settings.showMem {
-		usedMemLabel := bytefmt.ByteSize(memInfo.Used)
-		totalMemLabel := bytefmt.ByteSize(memInfo.Total)
-
-		if usedMemLabel[len(usedMemLabel)-1] == totalMemLabel[len(totalMemLabel)-1] {
-			usedMemLabel = usedMemLabel[:len(usedMemLabel)-1]
-		}
-
-		stats[nextIndex] = view.Bar{
-			Label:      "Mem",
-			Percent:    int(memInfo.UsedPercent),
-			ValueLabel: fmt.Sprintf("%s/%s", usedMemLabel, totalMemLabel),
-			LabelColor: "green",
-		}
-		nextIndex++
-	}
+// BorderColor returns the alert color while a metric is above its threshold, so that the
+// border goes back to it when the widget loses focus
+func (widget *Widget) BorderColor() string {
+	widget.mutex.Lock()
+	defer widget.mutex.Unlock()
 
-	if widget.settings.showSwp {
-		swapUsed := memInfo.SwapTotal - memInfo.SwapFree
-		var swapPercent float64
-		if memInfo.SwapTotal > 0 {
-			swapPercent = float64(swapUsed) / float64(memInfo.SwapTotal)
-		}
-
-		usedSwapLabel := bytefmt.ByteSize(swapUsed)
-		totalSwapLabel := bytefmt.ByteSize(memInfo.SwapTotal)
-
-		if usedSwapLabel[len(usedSwapLabel)-1] == totalSwapLabel[len(totalSwapLabel)-1] {
-			usedSwapLabel = usedSwapLabel[:len(usedSwapLabel)-1]
-		}
-
-		stats[nextIndex] = view.Bar{
-			Label:      "Swp",
-			Percent:    int(swapPercent * 100),
-			ValueLabel: fmt.Sprintf("%s/%s", usedSwapLabel, totalSwapLabel),
-			LabelColor: "yellow",
-		}
+	if len(widget.breached) > 0 {
+		return widget.settings.alertColor
 	}
 
-	widget.BarGraph.BuildBars(stats)
-
+	return widget.ScrollableWidget.BorderColor()
 }
 
 // Refresh & update after interval time
@@ -131,32 +83,24 @@ func (widget *Widget) Refresh() {
 		return
 	}
 
-	widget.app.QueueUpdateDraw(func() {
-		widget.View.Clear()
-		display(widget)
-	})
-}
+	widget.mutex.Lock()
 
-/* -------------------- Unexported Functions -------------------- */
+	widget.sample(time.Now())
 
-func display(widget *Widget) {
-	MakeGraph(widget)
-}
-
-func getDataFromSystem(widget *Widget) (cpuStats []float64, memInfo mem.VirtualMemoryStat) {
-	if widget.settings.showCPU {
-		rCPUStats, err := cpu.Percent(time.Duration(0), !widget.settings.cpuCombined)
-		if err == nil {
-			cpuStats = rCPUStats
-		}
+	if widget.settings.showProcesses {
+		widget.allProcesses = widget.loadProcesses()
+		widget.processes = topProcesses(widget.allProcesses, widget.processSort, widget.settings.processCount)
 	}
 
-	if widget.settings.showMem || widget.settings.showSwp {
-		rMemInfo, err := mem.VirtualMemory()
-		if err == nil {
-			memInfo = *rMemInfo
-		}
-	}
+	widget.mutex.Unlock()
+
+	widget.Render()
+}
+
+func (widget *Widget) Render() {
+	widget.mutex.Lock()
+	widget.SetItemCount(len(widget.processes))
+	widget.mutex.Unlock()
 
-	return cpuStats, memInfo
+	widget.Redraw(widget.display)
 }
@@ -0,0 +1,250 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commandTimeout is how long a command a check runs has to finish. Checking for updates
+// can take a while, but a command that hangs mustn't hold up the widget for good
+const commandTimeout = 30 * time.Second
+
+// errTimedOut is returned by commands that didn't finish within commandTimeout
+var errTimedOut = errors.New("timed out")
+
+// status is the outcome of a check
+type status int
+
+const (
+	// statusUnknown means the check could not run, i.e.: because a command is missing or
+	// needs root. It doesn't count towards the score
+	statusUnknown status = iota
+
+	// statusInfo is for checks that report something worth knowing that is neither good
+	// nor bad. It doesn't count towards the score
+	statusInfo
+
+	statusFail
+	statusWarn
+	statusPass
+)
+
+// result is the outcome of a check, and the reason for it
+type result struct {
+	name   string
+	status status
+	reason string
+}
+
+// check inspects one aspect of the system's security
+type check struct {
+	title string
+	run   func(sys *system) result
+}
+
+// checks are the checks that can be enabled, by the names they're enabled with
+var checks = map[string]check{
+	"dns":        {title: "DNS", run: checkDNS},
+	"encryption": {title: "Encryption", run: checkEncryption},
+	"firewall":   {title: "Firewall", run: checkFirewall},
+	"ports":      {title: "Ports", run: checkPorts},
+	"screenlock": {title: "Screen lock", run: checkScreenLock},
+	"ssh":        {title: "SSH", run: checkSSH},
+	"updates":    {title: "Updates", run: checkUpdates},
+	"users":      {title: "Users", run: checkUsers},
+	"wifi":       {title: "WiFi", run: checkWifi},
+}
+
+// defaultChecks are the checks that run when none are configured, in display order
+var defaultChecks = []string{"firewall", "encryption", "updates", "ssh", "ports", "screenlock", "dns", "wifi", "users"}
+
+// system is how checks look at the machine. Tests replace its functions with ones that
+// return fixtures
+type system struct {
+	goos string
+
+	// allowedPorts are the ports that may listen on interfaces other than loopback
+	allowedPorts []string
+
+	// trustedDNS are the resolvers that may be used. Any resolver is fine if it's empty
+	trustedDNS []string
+
+	glob     func(pattern string) ([]string, error)
+	lookPath func(file string) (string, error)
+	readFile func(path string) (string, error)
+	run      func(name string, args ...string) (string, error)
+}
+
+func newSystem(settings *Settings) *system {
+	return &system{
+		goos:         runtime.GOOS,
+		allowedPorts: settings.allowedPorts,
+		trustedDNS:   settings.trustedDNS,
+
+		glob:     filepath.Glob,
+		lookPath: exec.LookPath,
+		readFile: readFile,
+		run:      runCommand,
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// runChecks runs the named checks at the same time, and returns their results in the
+// same order. Unknown names are reported as unknown checks
+func runChecks(sys *system, names []string) []result {
+	results := make([]result, len(names))
+
+	var wg sync.WaitGroup
+
+	for idx, name := range names {
+		chk, ok := checks[name]
+		if !ok {
+			results[idx] = result{name: name, status: statusUnknown, reason: "no such check"}
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(idx int, chk check) {
+			defer wg.Done()
+
+			res := runCheck(sys, chk)
+			res.name = chk.title
+			results[idx] = res
+		}(idx, chk)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// runCheck runs a check. Its result is unknown if a command it ran timed out, as checks
+// can't tell a command that was cut short from one that had nothing to say
+func runCheck(sys *system, chk check) result {
+	var timedOut []string
+	var mutex sync.Mutex
+
+	checkSys := *sys
+	checkSys.run = func(name string, args ...string) (string, error) {
+		out, err := sys.run(name, args...)
+		if errors.Is(err, errTimedOut) {
+			mutex.Lock()
+			timedOut = append(timedOut, name)
+			mutex.Unlock()
+		}
+		return out, err
+	}
+
+	res := chk.run(&checkSys)
+
+	if len(timedOut) > 0 {
+		return unknown("%s timed out", strings.Join(timedOut, ", "))
+	}
+
+	return res
+}
+
+// score returns the share, in percent, of the points scored by the checks that ran, where
+// passing is worth 2 points and warning is worth 1. It returns -1 if no check was scored
+func score(results []result) int {
+	points, total := 0, 0
+
+	for _, res := range results {
+		switch res.status {
+		case statusPass:
+			points += 2
+		case statusWarn:
+			points++
+		case statusFail:
+		default:
+			continue
+		}
+
+		total += 2
+	}
+
+	if total == 0 {
+		return -1
+	}
+
+	return points * 100 / total
+}
+
+func pass(format string, args ...interface{}) result {
+	return result{status: statusPass, reason: fmt.Sprintf(format, args...)}
+}
+
+func warn(format string, args ...interface{}) result {
+	return result{status: statusWarn, reason: fmt.Sprintf(format, args...)}
+}
+
+func fail(format string, args ...interface{}) result {
+	return result{status: statusFail, reason: fmt.Sprintf(format, args...)}
+}
+
+func info(format string, args ...interface{}) result {
+	return result{status: statusInfo, reason: fmt.Sprintf(format, args...)}
+}
+
+func unknown(format string, args ...interface{}) result {
+	return result{status: statusUnknown, reason: fmt.Sprintf(format, args...)}
+}
+
+// unsupported is the result of a check that doesn't know how to inspect this OS
+func unsupported(sys *system) result {
+	return unknown("not supported on %s", sys.goos)
+}
+
+// has returns TRUE if the command is installed
+func (sys *system) has(command string) bool {
+	_, err := sys.lookPath(command)
+	return err == nil
+}
+
+func readFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	return string(data), err
+}
+
+// runCommand runs the command and returns what it wrote to stdout. If it fails, the error
+// includes the first line of what it wrote to stderr, which usually says why
+func runCommand(name string, args ...string) (string, error) {
+	return runCommandWithin(commandTimeout, name, args...)
+}
+
+// runCommandWithin runs the command like runCommand does, killing it if it hasn't
+// finished within the timeout. It returns errTimedOut if it was killed
+func runCommandWithin(timeout time.Duration, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(out), fmt.Errorf("%s: %w", name, errTimedOut)
+	}
+
+	if err != nil {
+		if msg := strings.SplitN(strings.TrimSpace(stderr.String()), "\n", 2)[0]; msg != "" {
+			return string(out), fmt.Errorf("%s: %s", name, msg)
+		}
+
+		return string(out), fmt.Errorf("%s: %s", name, err)
+	}
+
+	return string(out), nil
+}
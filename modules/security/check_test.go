@@ -0,0 +1,140 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOutput is what a fake command prints, or the error it fails with
+type fakeOutput struct {
+	out string
+	err error
+}
+
+// fakeSystem returns a system whose commands and files are fixtures. Commands are keyed by
+// their command line, and a command is installed if any of its command lines is
+func fakeSystem(goos string, commands map[string]fakeOutput, files map[string]string) *system {
+	return &system{
+		goos: goos,
+
+		glob: func(pattern string) ([]string, error) {
+			matches := []string{}
+			for path := range files {
+				if ok, _ := filepath.Match(pattern, path); ok {
+					matches = append(matches, path)
+				}
+			}
+			return matches, nil
+		},
+		lookPath: func(file string) (string, error) {
+			for cmdLine := range commands {
+				if strings.SplitN(cmdLine, " ", 2)[0] == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+			return "", errors.New("not found")
+		},
+		readFile: func(path string) (string, error) {
+			text, ok := files[path]
+			if !ok {
+				return "", &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+			}
+			return text, nil
+		},
+		run: func(name string, args ...string) (string, error) {
+			output, ok := commands[strings.Join(append([]string{name}, args...), " ")]
+			if !ok {
+				return "", errors.New(name + ": not found")
+			}
+			return output.out, output.err
+		},
+	}
+}
+
+func Test_runChecks(t *testing.T) {
+	sys := fakeSystem("linux", map[string]fakeOutput{
+		"ufw status": {out: "Status: active\n"},
+		"who -us":    {out: "chris    tty2         2020-08-01 09:00  old         1234\n"},
+	}, nil)
+
+	results := runChecks(sys, []string{"firewall", "users", "missing", "screenlock"})
+
+	assert.Equal(
+		t,
+		[]result{
+			{name: "Firewall", status: statusPass, reason: "ufw is active"},
+			{name: "Users", status: statusInfo, reason: "chris"},
+			{name: "missing", status: statusUnknown, reason: "no such check"},
+			{name: "Screen lock", status: statusUnknown, reason: "no supported desktop is installed"},
+		},
+		results,
+	)
+}
+
+func Test_runChecks_TimedOut(t *testing.T) {
+	sys := fakeSystem("linux", map[string]fakeOutput{
+		// firewall-cmd's output is ignored, so only the timeout says it didn't answer
+		"ufw status":           {err: errors.New("ufw: need to be root")},
+		"firewall-cmd --state": {err: fmt.Errorf("firewall-cmd: %w", errTimedOut)},
+		"who -us":              {out: "chris    tty2         2020-08-01 09:00  old         1234\n"},
+	}, nil)
+
+	results := runChecks(sys, []string{"firewall", "users"})
+
+	assert.Equal(t, result{name: "Firewall", status: statusUnknown, reason: "firewall-cmd timed out"}, results[0])
+	assert.Equal(t, statusInfo, results[1].status)
+}
+
+func Test_runCommandWithin(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep is not installed")
+	}
+
+	_, err := runCommandWithin(50*time.Millisecond, "sleep", "5")
+	assert.True(t, errors.Is(err, errTimedOut))
+
+	_, err = runCommandWithin(5*time.Second, "sleep", "0")
+	assert.NoError(t, err)
+}
+
+func Test_score(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []status
+		expected int
+	}{
+		{name: "all passing", statuses: []status{statusPass, statusPass}, expected: 100},
+		{name: "mixed", statuses: []status{statusPass, statusWarn, statusFail, statusInfo, statusUnknown}, expected: 50},
+		{name: "all failing", statuses: []status{statusFail}, expected: 0},
+		{name: "nothing scored", statuses: []status{statusInfo, statusUnknown}, expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []result{}
+			for _, s := range tt.statuses {
+				results = append(results, result{status: s})
+			}
+
+			assert.Equal(t, tt.expected, score(results))
+		})
+	}
+}
+
+func Test_unsupported(t *testing.T) {
+	sys := fakeSystem("plan9", nil, nil)
+
+	for name, chk := range checks {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, statusUnknown, chk.run(sys).status)
+		})
+	}
+}
@@ -1,65 +1,196 @@
 package security
 
 import (
-	"os/exec"
-	"runtime"
+	"net"
+	"regexp"
 	"strings"
 
 	"github.com/wtfutil/wtf/utils"
 )
 
-/* -------------------- Exported Functions -------------------- */
+// resolvedStub is the address of systemd-resolved's local DNS stub, which forwards queries
+// to the resolvers that systemd-resolved was configured with
+const resolvedStub = "127.0.0.53"
 
-func DnsServers() []string {
-	switch runtime.GOOS {
+var ipPattern = regexp.MustCompile(`[0-9a-fA-F:.]*[:.][0-9a-fA-F:.]+`)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// checkDNS reports which resolvers are in use. If trusted resolvers are configured it fails
+// when any other resolver is in use
+func checkDNS(sys *system) result {
+	var servers []string
+	var err error
+
+	switch sys.goos {
 	case "linux":
-		return dnsLinux()
+		servers, err = dnsLinux(sys)
 	case "darwin":
-		return dnsMacOS()
+		servers, err = dnsMacOS(sys)
 	case "windows":
-		return dnsWindows()
+		servers, err = dnsWindows(sys)
 	default:
-		return []string{runtime.GOOS}
+		return unsupported(sys)
 	}
+
+	if err != nil {
+		return unknown("%s", err)
+	}
+
+	return dnsResult(servers, sys.trustedDNS)
 }
 
-/* -------------------- Unexported Functions -------------------- */
+func dnsResult(servers []string, trusted []string) result {
+	if len(servers) == 0 {
+		return warn("no resolvers are configured")
+	}
+
+	if len(trusted) == 0 {
+		return info("%s", strings.Join(servers, ", "))
+	}
+
+	untrusted := []string{}
+	for _, server := range servers {
+		if utils.DoesNotInclude(trusted, server) {
+			untrusted = append(untrusted, server)
+		}
+	}
+
+	if len(untrusted) > 0 {
+		return fail("untrusted resolvers: %s", strings.Join(untrusted, ", "))
+	}
+
+	return pass("%s", strings.Join(servers, ", "))
+}
+
+// dnsLinux reads the resolvers from resolv.conf. If that points at systemd-resolved's stub,
+// it asks systemd-resolved which resolvers it forwards to
+func dnsLinux(sys *system) ([]string, error) {
+	resolvConf, err := sys.readFile("/etc/resolv.conf")
+	if err != nil {
+		return nil, err
+	}
+
+	servers := parseResolvConf(resolvConf)
+	if len(servers) != 1 || servers[0] != resolvedStub {
+		return servers, nil
+	}
+
+	out, err := sys.run("resolvectl", "dns")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResolvectlDNS(out), nil
+}
 
-func dnsLinux() []string {
-	// This may be very Ubuntu specific
-	cmd := exec.Command("nmcli", "device", "show")
-	out := utils.ExecuteCommand(cmd)
+// parseResolvConf returns the nameservers in a resolv.conf file
+func parseResolvConf(resolvConf string) []string {
+	servers := []string{}
 
-	lines := strings.Split(out, "\n")
+	for _, line := range strings.Split(resolvConf, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = appendUnique(servers, fields[1])
+		}
+	}
 
-	dns := []string{}
+	return servers
+}
 
-	for _, l := range lines {
-		if strings.HasPrefix(l, "IP4.DNS") {
-			parts := strings.Split(l, ":")
-			dns = append(dns, strings.TrimSpace(parts[1]))
+// parseResolvectlDNS reads the output of `resolvectl dns`, which lists the global resolvers
+// and those of each link:
+//
+//    Global: 1.1.1.1
+//    Link 2 (eth0): 192.168.1.1 1.1.1.1:853#cloudflare-dns.com
+//    Link 3 (wlan0):
+func parseResolvectlDNS(out string) []string {
+	servers := []string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
 		}
+
+		for _, field := range strings.Fields(line[idx+1:]) {
+			// Resolvers can be followed by a port and a server name, i.e.: 1.1.1.1:853#cloudflare-dns.com
+			if server := resolverAddress(strings.SplitN(field, "#", 2)[0]); server != "" {
+				servers = appendUnique(servers, server)
+			}
+		}
+	}
+
+	return servers
+}
+
+// resolverAddress returns the IP address of a resolver, without its port or interface, or
+// "" if it isn't an address
+func resolverAddress(field string) string {
+	if host, _, err := net.SplitHostPort(field); err == nil {
+		field = host
+	}
+
+	field = strings.SplitN(field, "%", 2)[0]
+	if net.ParseIP(field) == nil {
+		return ""
+	}
+
+	return field
+}
+
+func dnsMacOS(sys *system) ([]string, error) {
+	out, err := sys.run("scutil", "--dns")
+	if err != nil {
+		return nil, err
 	}
-	return dns
+
+	return parseScutilDNS(out), nil
 }
 
-func dnsMacOS() []string {
-	cmdString := `scutil --dns | head -n 7 | grep -o '[0-9]\{1,3\}\.[0-9]\{1,3\}\.[0-9]\{1,3\}\.[0-9]\{1,3\}'`
-	cmd := exec.Command("sh", "-c", cmdString)
-	out := utils.ExecuteCommand(cmd)
+// parseScutilDNS returns the nameservers of the first resolver that `scutil --dns` lists,
+// which is the one that's used for queries that aren't for a scoped domain
+func parseScutilDNS(out string) []string {
+	servers := []string{}
 
-	lines := strings.Split(out, "\n")
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
 
-	if len(lines) > 0 {
-		return lines
+		if strings.HasPrefix(line, "resolver #") && len(servers) > 0 {
+			break
+		}
+
+		if strings.HasPrefix(line, "nameserver[") {
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) == 2 {
+				servers = appendUnique(servers, strings.TrimSpace(parts[1]))
+			}
+		}
 	}
 
-	return []string{}
+	return servers
 }
 
-func dnsWindows() []string {
+func dnsWindows(sys *system) ([]string, error) {
+	out, err := sys.run("powershell.exe", "-NoProfile", "Get-DnsClientServerAddress | Select-Object -ExpandProperty ServerAddresses")
+	if err != nil {
+		return nil, err
+	}
 
-	cmd := exec.Command("powershell.exe", "-NoProfile", "Get-DnsClientServerAddress | Select-Object –ExpandProperty ServerAddresses")
+	servers := []string{}
+	for _, match := range ipPattern.FindAllString(out, -1) {
+		if net.ParseIP(match) != nil {
+			servers = appendUnique(servers, match)
+		}
+	}
+
+	return servers, nil
+}
+
+func appendUnique(strs []string, str string) []string {
+	if utils.Includes(strs, str) {
+		return strs
+	}
 
-	return []string{utils.ExecuteCommand(cmd)}
+	return append(strs, str)
 }
@@ -0,0 +1,64 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const scutilDNS = `DNS configuration
+
+resolver #1
+  nameserver[0] : 9.9.9.9
+  nameserver[1] : 149.112.112.112
+  flags    : Request A records
+  reach    : 0x00000002 (Reachable)
+
+resolver #2
+  domain   : local
+  nameserver[0] : 224.0.0.251
+`
+
+func Test_parseResolvConf(t *testing.T) {
+	resolvConf := "# Generated by NetworkManager\nsearch home\nnameserver 192.168.1.1\nnameserver 1.1.1.1\nnameserver 192.168.1.1\n"
+
+	assert.Equal(t, []string{"192.168.1.1", "1.1.1.1"}, parseResolvConf(resolvConf))
+	assert.Equal(t, []string{}, parseResolvConf(""))
+}
+
+func Test_parseResolvectlDNS(t *testing.T) {
+	out := "Global: 1.1.1.1\nLink 2 (eth0): 192.168.1.1 1.1.1.1:853#cloudflare-dns.com fe80::1%2\nLink 3 (wlan0):\n"
+
+	assert.Equal(t, []string{"1.1.1.1", "192.168.1.1", "fe80::1"}, parseResolvectlDNS(out))
+}
+
+func Test_parseScutilDNS(t *testing.T) {
+	assert.Equal(t, []string{"9.9.9.9", "149.112.112.112"}, parseScutilDNS(scutilDNS))
+}
+
+func Test_dnsResult(t *testing.T) {
+	servers := []string{"192.168.1.1", "1.1.1.1"}
+
+	assert.Equal(t, warn("no resolvers are configured"), dnsResult([]string{}, nil))
+	assert.Equal(t, info("192.168.1.1, 1.1.1.1"), dnsResult(servers, nil))
+	assert.Equal(t, fail("untrusted resolvers: 192.168.1.1"), dnsResult(servers, []string{"1.1.1.1"}))
+	assert.Equal(t, pass("192.168.1.1, 1.1.1.1"), dnsResult(servers, []string{"1.1.1.1", "192.168.1.1"}))
+}
+
+func Test_checkDNS_ResolvedStub(t *testing.T) {
+	sys := fakeSystem(
+		"linux",
+		map[string]fakeOutput{"resolvectl dns": {out: "Global:\nLink 2 (eth0): 192.168.1.1\n"}},
+		map[string]string{"/etc/resolv.conf": "nameserver 127.0.0.53\noptions edns0 trust-ad\n"},
+	)
+	sys.trustedDNS = []string{"1.1.1.1"}
+
+	assert.Equal(t, fail("untrusted resolvers: 192.168.1.1"), checkDNS(sys))
+}
+
+func Test_checkDNS_Windows(t *testing.T) {
+	cmd := "powershell.exe -NoProfile Get-DnsClientServerAddress | Select-Object -ExpandProperty ServerAddresses"
+	sys := fakeSystem("windows", map[string]fakeOutput{cmd: {out: "fec0:0:0:ffff::1\r\n8.8.8.8\r\n8.8.8.8\r\n"}}, nil)
+
+	assert.Equal(t, info("fec0:0:0:ffff::1, 8.8.8.8"), checkDNS(sys))
+}
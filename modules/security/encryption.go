@@ -0,0 +1,106 @@
+package security
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// encryptedMounts are the mount points that should be on an encrypted device, if they
+// are mounted
+var encryptedMounts = []string{"/", "/home"}
+
+// blockDevice is a device in the tree that `lsblk --json` prints
+type blockDevice struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Mountpoint string        `json:"mountpoint"`
+	Children   []blockDevice `json:"children"`
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func checkEncryption(sys *system) result {
+	switch sys.goos {
+	case "linux":
+		out, err := sys.run("lsblk", "--json", "-o", "NAME,TYPE,MOUNTPOINT")
+		if err != nil {
+			return unknown("%s", err)
+		}
+
+		return parseLsblk(out)
+	case "darwin":
+		out, err := sys.run("fdesetup", "status")
+		if err != nil {
+			return unknown("%s", err)
+		}
+
+		return parseFdesetup(out)
+	default:
+		return unsupported(sys)
+	}
+}
+
+// parseLsblk reads the output of `lsblk --json -o NAME,TYPE,MOUNTPOINT`. A filesystem is
+// encrypted if one of the devices it's on is a dm-crypt device, i.e.: a LUKS volume
+func parseLsblk(out string) result {
+	devices := struct {
+		Blockdevices []blockDevice `json:"blockdevices"`
+	}{}
+
+	if err := json.Unmarshal([]byte(out), &devices); err != nil {
+		return unknown("could not read the block devices: %s", err)
+	}
+
+	encrypted := map[string]bool{}
+	var walk func(devices []blockDevice, inCrypt bool)
+	walk = func(devices []blockDevice, inCrypt bool) {
+		for _, device := range devices {
+			crypt := inCrypt || device.Type == "crypt"
+
+			if device.Mountpoint != "" {
+				encrypted[device.Mountpoint] = crypt
+			}
+
+			walk(device.Children, crypt)
+		}
+	}
+	walk(devices.Blockdevices, false)
+
+	plain := []string{}
+	checked := 0
+
+	for _, mount := range encryptedMounts {
+		crypt, ok := encrypted[mount]
+		if !ok {
+			continue
+		}
+		checked++
+
+		if !crypt {
+			plain = append(plain, mount)
+		}
+	}
+
+	switch {
+	case checked == 0:
+		return unknown("could not find the root filesystem")
+	case len(plain) > 0:
+		return fail("%s is not encrypted", strings.Join(plain, " and "))
+	default:
+		return pass("the disks are encrypted")
+	}
+}
+
+// parseFdesetup reads the output of `fdesetup status`
+func parseFdesetup(out string) result {
+	switch {
+	case strings.Contains(out, "Encryption in progress"):
+		return warn("FileVault is encrypting the disk")
+	case strings.Contains(out, "FileVault is On"):
+		return pass("FileVault is on")
+	case strings.Contains(out, "FileVault is Off"):
+		return fail("FileVault is off")
+	default:
+		return unknown("could not read the FileVault status")
+	}
+}
@@ -0,0 +1,56 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lsblkEncrypted = `{
+   "blockdevices": [
+      {"name":"nvme0n1", "type":"disk", "mountpoint":null,
+         "children": [
+            {"name":"nvme0n1p1", "type":"part", "mountpoint":"/boot/efi"},
+            {"name":"nvme0n1p2", "type":"part", "mountpoint":null,
+               "children": [
+                  {"name":"luks-root", "type":"crypt", "mountpoint":null,
+                     "children": [
+                        {"name":"vg-root", "type":"lvm", "mountpoint":"/"},
+                        {"name":"vg-home", "type":"lvm", "mountpoint":"/home"}
+                     ]
+                  }
+               ]
+            }
+         ]
+      }
+   ]
+}`
+
+const lsblkPlainHome = `{
+   "blockdevices": [
+      {"name":"sda", "type":"disk", "mountpoint":null,
+         "children": [
+            {"name":"sda1", "type":"part", "mountpoint":null,
+               "children": [
+                  {"name":"luks-root", "type":"crypt", "mountpoint":"/"}
+               ]
+            },
+            {"name":"sda2", "type":"part", "mountpoint":"/home"}
+         ]
+      }
+   ]
+}`
+
+func Test_parseLsblk(t *testing.T) {
+	assert.Equal(t, pass("the disks are encrypted"), parseLsblk(lsblkEncrypted))
+	assert.Equal(t, fail("/home is not encrypted"), parseLsblk(lsblkPlainHome))
+	assert.Equal(t, unknown("could not find the root filesystem"), parseLsblk(`{"blockdevices": []}`))
+	assert.Equal(t, statusUnknown, parseLsblk("lsblk: not json").status)
+}
+
+func Test_parseFdesetup(t *testing.T) {
+	assert.Equal(t, statusPass, parseFdesetup("FileVault is On.\n").status)
+	assert.Equal(t, statusWarn, parseFdesetup("FileVault is On.\nEncryption in progress: Percent completed = 42.0\n").status)
+	assert.Equal(t, statusFail, parseFdesetup("FileVault is Off.\n").status)
+	assert.Equal(t, statusUnknown, parseFdesetup("").status)
+}
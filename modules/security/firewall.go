@@ -1,127 +1,241 @@
 package security
 
 import (
-	"bytes"
-	"os/exec"
-	"os/user"
-	"runtime"
 	"strings"
-
-	"github.com/wtfutil/wtf/utils"
 )
 
 const osxFirewallCmd = "/usr/libexec/ApplicationFirewall/socketfilterfw"
 
-/* -------------------- Exported Functions -------------------- */
+/* -------------------- Unexported Functions -------------------- */
 
-func FirewallState() string {
-	switch runtime.GOOS {
+func checkFirewall(sys *system) result {
+	switch sys.goos {
 	case "linux":
-		return firewallStateLinux()
+		return firewallLinux(sys)
 	case "darwin":
-		return firewallStateMacOS()
+		return firewallMacOS(sys)
 	case "windows":
-		return firewallStateWindows()
+		return firewallWindows(sys)
 	default:
-		return ""
+		return unsupported(sys)
 	}
 }
 
-func FirewallStealthState() string {
-	switch runtime.GOOS {
-	case "linux":
-		return firewallStealthStateLinux()
-	case "darwin":
-		return firewallStealthStateMacOS()
-	case "windows":
-		return firewallStealthStateWindows()
-	default:
-		return ""
+// firewallLinux asks the firewall front-ends, ufw and firewalld, first, because they are
+// what the user configures. Unless one of them is active it reads the nftables or iptables
+// rules, which a front-end that is off may not be the only source of. Reading the rules
+// usually needs root
+func firewallLinux(sys *system) result {
+	var frontEnd *result
+	reasons := []string{}
+
+	if sys.has("ufw") {
+		out, err := sys.run("ufw", "status")
+		if err != nil {
+			reasons = append(reasons, err.Error())
+		} else if res := parseUfwStatus(out); res.status == statusPass {
+			return res
+		} else if res.status == statusFail {
+			frontEnd = &res
+		}
 	}
-}
 
-/* -------------------- Unexported Functions -------------------- */
+	if sys.has("firewall-cmd") {
+		// It exits with an error when the daemon isn't running
+		out, _ := sys.run("firewall-cmd", "--state")
 
-func firewallStateLinux() string { // might be very Ubuntu specific
-	user, _ := user.Current()
+		res := parseFirewalldState(out)
+		if res.status == statusPass {
+			return res
+		}
+		if frontEnd == nil {
+			frontEnd = &res
+		}
+	}
 
-	if strings.Contains(user.Username, "root") {
-		cmd := exec.Command("ufw", "status")
+	rules := []struct {
+		command string
+		args    []string
+		parse   func(string) result
+	}{
+		{command: "nft", args: []string{"list", "ruleset"}, parse: parseNftRuleset},
+		{command: "iptables", args: []string{"-S", "INPUT"}, parse: parseIptables},
+	}
 
-		var o bytes.Buffer
-		cmd.Stdout = &o
-		if err := cmd.Run(); err != nil {
-			return "[red]NA[white]"
+	for _, rule := range rules {
+		if !sys.has(rule.command) {
+			continue
 		}
 
-		if strings.Contains(o.String(), "inactive") {
-			return "[red]Disabled[white]"
-		} else {
-			return "[green]Enabled[white]"
+		out, err := sys.run(rule.command, rule.args...)
+		if err != nil {
+			reasons = append(reasons, err.Error())
+			continue
 		}
-	} else {
-		return "[red]N/A[white]"
+
+		res := rule.parse(out)
+		if res.status == statusPass || frontEnd == nil {
+			return res
+		}
+
+		return *frontEnd
+	}
+
+	if frontEnd != nil {
+		return *frontEnd
 	}
+
+	if len(reasons) > 0 {
+		return unknown("could not read the firewall rules (%s)", reasons[0])
+	}
+
+	return fail("no firewall is installed")
 }
 
-func firewallStateMacOS() string {
-	cmd := exec.Command(osxFirewallCmd, "--getglobalstate")
-	str := utils.ExecuteCommand(cmd)
+// parseUfwStatus reads the output of `ufw status`
+func parseUfwStatus(out string) result {
+	for _, line := range strings.Split(out, "\n") {
+		switch strings.TrimSpace(line) {
+		case "Status: active":
+			return pass("ufw is active")
+		case "Status: inactive":
+			return fail("ufw is inactive")
+		}
+	}
 
-	return statusLabel(str)
+	return unknown("could not read the ufw status")
 }
 
-func firewallStateWindows() string {
-	// The raw way to do this in PS, not using netsh, nor registry, is the following:
-	//   if (((Get-NetFirewallProfile | select name,enabled)
-	//                                | where { $_.Enabled -eq $True } | measure ).Count -eq 3)
-	//   { Write-Host "OK" -ForegroundColor Green} else { Write-Host "OFF" -ForegroundColor Red }
+// parseFirewalldState reads the output of `firewall-cmd --state`
+func parseFirewalldState(out string) result {
+	if strings.TrimSpace(out) == "running" {
+		return pass("firewalld is running")
+	}
 
-	cmd := exec.Command("powershell.exe", "-NoProfile",
-		"-Command", "& { ((Get-NetFirewallProfile | select name,enabled) | where { $_.Enabled -eq $True } | measure ).Count }")
+	return fail("firewalld is not running")
+}
 
-	fwStat := utils.ExecuteCommand(cmd)
-	fwStat = strings.TrimSpace(fwStat) // Always sanitize PowerShell output:  "3\r\n"
-	//fmt.Printf("%d %q\n", len(fwStat), fwStat)
+// parseNftRuleset reads the output of `nft list ruleset`. Incoming traffic is filtered if a
+// chain on the input hook drops it by default, or ends with a rule that drops or rejects it
+func parseNftRuleset(out string) result {
+	type chain struct {
+		input      bool
+		policyDrop bool
+		lastRule   string
+	}
 
-	switch fwStat {
-	case "3":
-		return "[green]Good[white] (3/3)"
-	case "2":
-		return "[orange]Poor[white] (2/3)"
-	case "1":
-		return "[yellow]Bad[white] (1/3)"
-	case "0":
-		return "[red]Disabled[white]"
-	default:
-		return "[white]N/A[white]"
+	chains := []*chain{}
+	var current *chain
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "chain "):
+			current = &chain{}
+			chains = append(chains, current)
+		case current == nil || line == "":
+			continue
+		case line == "}":
+			current = nil
+		case strings.HasPrefix(line, "type filter hook input"):
+			current.input = true
+			current.policyDrop = strings.Contains(line, "policy drop")
+		default:
+			current.lastRule = line
+		}
 	}
-}
 
-/* -------------------- Getting Stealth State ------------------- */
-// "Stealth": Not responding to pings from unauthorized devices
+	inputChains := 0
 
-func firewallStealthStateLinux() string {
-	return "[white]N/A[white]"
-}
+	for _, c := range chains {
+		if !c.input {
+			continue
+		}
+		inputChains++
+
+		if c.policyDrop {
+			return pass("nftables drops incoming traffic by default")
+		}
 
-func firewallStealthStateMacOS() string {
-	cmd := exec.Command(osxFirewallCmd, "--getstealthmode")
-	str := utils.ExecuteCommand(cmd)
+		if strings.HasSuffix(c.lastRule, "drop") || strings.Contains(c.lastRule, "reject") {
+			return pass("nftables drops incoming traffic that isn't allowed")
+		}
+	}
+
+	if inputChains > 0 {
+		return warn("nftables accepts incoming traffic by default")
+	}
 
-	return statusLabel(str)
+	return fail("nftables has no input rules")
 }
 
-func firewallStealthStateWindows() string {
-	return "[white]N/A[white]"
+// parseIptables reads the output of `iptables -S INPUT`
+func parseIptables(out string) result {
+	rules := []string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "-P INPUT DROP" || line == "-P INPUT REJECT":
+			return pass("iptables drops incoming traffic by default")
+		case strings.HasPrefix(line, "-A INPUT"):
+			rules = append(rules, line)
+		}
+	}
+
+	if len(rules) == 0 {
+		return fail("iptables has no input rules")
+	}
+
+	last := rules[len(rules)-1]
+	if strings.HasSuffix(last, "-j DROP") || strings.Contains(last, "-j REJECT") {
+		return pass("iptables drops incoming traffic that isn't allowed")
+	}
+
+	return warn("iptables accepts incoming traffic by default")
 }
 
-func statusLabel(str string) string {
-	label := "off"
+func firewallMacOS(sys *system) result {
+	out, err := sys.run(osxFirewallCmd, "--getglobalstate")
+	if err != nil {
+		return unknown("%s", err)
+	}
 
-	if strings.Contains(str, "enabled") {
-		label = "on"
+	if !strings.Contains(out, "enabled") {
+		return fail("the application firewall is off")
 	}
 
-	return label
+	// "Stealth": Not responding to pings from unauthorized devices
+	stealth, _ := sys.run(osxFirewallCmd, "--getstealthmode")
+	if !strings.Contains(stealth, "enabled") {
+		return warn("the application firewall is on, but stealth mode is off")
+	}
+
+	return pass("the application firewall is on, in stealth mode")
+}
+
+func firewallWindows(sys *system) result {
+	// The raw way to do this in PS, not using netsh, nor registry, is the following:
+	//   if (((Get-NetFirewallProfile | select name,enabled)
+	//                                | where { $_.Enabled -eq $True } | measure ).Count -eq 3)
+	//   { Write-Host "OK" -ForegroundColor Green} else { Write-Host "OFF" -ForegroundColor Red }
+	out, err := sys.run("powershell.exe", "-NoProfile",
+		"-Command", "& { ((Get-NetFirewallProfile | select name,enabled) | where { $_.Enabled -eq $True } | measure ).Count }")
+	if err != nil {
+		return unknown("%s", err)
+	}
+
+	// Always sanitize PowerShell output:  "3\r\n"
+	switch strings.TrimSpace(out) {
+	case "3":
+		return pass("the firewall is on for all 3 profiles")
+	case "2", "1":
+		return warn("the firewall is on for %s of 3 profiles", strings.TrimSpace(out))
+	case "0":
+		return fail("the firewall is off")
+	default:
+		return unknown("could not read the firewall profiles")
+	}
 }
@@ -0,0 +1,158 @@
+package security
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const nftRulesetDrop = `table inet filter {
+	chain input {
+		type filter hook input priority filter; policy drop;
+		ct state established,related accept
+		iif "lo" accept
+		tcp dport { 22, 80 } accept
+	}
+
+	chain forward {
+		type filter hook forward priority filter; policy drop;
+	}
+}
+`
+
+const nftRulesetReject = `table inet filter {
+	chain input {
+		type filter hook input priority filter; policy accept;
+		ct state established,related accept
+		reject with icmpx type port-unreachable
+	}
+}
+`
+
+const nftRulesetAccept = `table inet filter {
+	chain input {
+		type filter hook input priority filter; policy accept;
+		tcp dport 22 accept
+	}
+	chain output {
+		type filter hook output priority filter; policy accept;
+		drop
+	}
+}
+`
+
+const iptablesDefaultDrop = `-P INPUT DROP
+-A INPUT -i lo -j ACCEPT
+-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT
+`
+
+const iptablesRejectLast = `-P INPUT ACCEPT
+-A INPUT -m state --state RELATED,ESTABLISHED -j ACCEPT
+-A INPUT -j REJECT --reject-with icmp-host-prohibited
+`
+
+const iptablesAccept = `-P INPUT ACCEPT
+-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT
+`
+
+func Test_parseUfwStatus(t *testing.T) {
+	assert.Equal(t, statusPass, parseUfwStatus("Status: active\n\nTo                         Action      From\n22                         ALLOW       Anywhere\n").status)
+	assert.Equal(t, statusFail, parseUfwStatus("Status: inactive\n").status)
+	assert.Equal(t, statusUnknown, parseUfwStatus("ERROR: You need to be root to run this script\n").status)
+}
+
+func Test_parseNftRuleset(t *testing.T) {
+	assert.Equal(t, pass("nftables drops incoming traffic by default"), parseNftRuleset(nftRulesetDrop))
+	assert.Equal(t, pass("nftables drops incoming traffic that isn't allowed"), parseNftRuleset(nftRulesetReject))
+	assert.Equal(t, warn("nftables accepts incoming traffic by default"), parseNftRuleset(nftRulesetAccept))
+	assert.Equal(t, fail("nftables has no input rules"), parseNftRuleset(""))
+}
+
+func Test_parseIptables(t *testing.T) {
+	assert.Equal(t, pass("iptables drops incoming traffic by default"), parseIptables(iptablesDefaultDrop))
+	assert.Equal(t, pass("iptables drops incoming traffic that isn't allowed"), parseIptables(iptablesRejectLast))
+	assert.Equal(t, warn("iptables accepts incoming traffic by default"), parseIptables(iptablesAccept))
+	assert.Equal(t, fail("iptables has no input rules"), parseIptables("-P INPUT ACCEPT\n"))
+}
+
+func Test_checkFirewall_Linux(t *testing.T) {
+	notRoot := errors.New("nft: Operation not permitted")
+
+	tests := []struct {
+		name     string
+		commands map[string]fakeOutput
+		expected result
+	}{
+		{
+			name:     "ufw active",
+			commands: map[string]fakeOutput{"ufw status": {out: "Status: active\n"}},
+			expected: pass("ufw is active"),
+		},
+		{
+			name: "firewalld running",
+			commands: map[string]fakeOutput{
+				"ufw status":           {out: "Status: inactive\n"},
+				"firewall-cmd --state": {out: "running\n"},
+			},
+			expected: pass("firewalld is running"),
+		},
+		{
+			name: "ufw inactive, but nftables filters",
+			commands: map[string]fakeOutput{
+				"ufw status":       {out: "Status: inactive\n"},
+				"nft list ruleset": {out: nftRulesetDrop},
+			},
+			expected: pass("nftables drops incoming traffic by default"),
+		},
+		{
+			name: "ufw inactive, and nftables accepts",
+			commands: map[string]fakeOutput{
+				"ufw status":       {out: "Status: inactive\n"},
+				"nft list ruleset": {out: nftRulesetAccept},
+			},
+			expected: fail("ufw is inactive"),
+		},
+		{
+			name: "falls back to iptables",
+			commands: map[string]fakeOutput{
+				"nft list ruleset":  {err: notRoot},
+				"iptables -S INPUT": {out: iptablesDefaultDrop},
+			},
+			expected: pass("iptables drops incoming traffic by default"),
+		},
+		{
+			name:     "rules can't be read",
+			commands: map[string]fakeOutput{"nft list ruleset": {err: notRoot}},
+			expected: unknown("could not read the firewall rules (nft: Operation not permitted)"),
+		},
+		{
+			name:     "nothing installed",
+			commands: map[string]fakeOutput{},
+			expected: fail("no firewall is installed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, checkFirewall(fakeSystem("linux", tt.commands, nil)))
+		})
+	}
+}
+
+func Test_checkFirewall_MacOS(t *testing.T) {
+	sys := fakeSystem("darwin", map[string]fakeOutput{
+		osxFirewallCmd + " --getglobalstate": {out: "Firewall is enabled. (State = 1)\n"},
+		osxFirewallCmd + " --getstealthmode": {out: "Stealth mode disabled\n"},
+	}, nil)
+
+	assert.Equal(t, statusWarn, checkFirewall(sys).status)
+}
+
+func Test_checkFirewall_Windows(t *testing.T) {
+	cmd := "powershell.exe -NoProfile -Command & { ((Get-NetFirewallProfile | select name,enabled) | where { $_.Enabled -eq $True } | measure ).Count }"
+
+	assert.Equal(t, statusPass, checkFirewall(fakeSystem("windows", map[string]fakeOutput{cmd: {out: "3\r\n"}}, nil)).status)
+	assert.Equal(t, statusWarn, checkFirewall(fakeSystem("windows", map[string]fakeOutput{cmd: {out: "2\r\n"}}, nil)).status)
+	assert.Equal(t, statusFail, checkFirewall(fakeSystem("windows", map[string]fakeOutput{cmd: {out: "0\r\n"}}, nil)).status)
+}
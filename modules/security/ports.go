@@ -0,0 +1,77 @@
+package security
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/wtfutil/wtf/utils"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// checkPorts warns if anything listens on an interface other than loopback, on a port that
+// isn't in allowedPorts. Ports are allowed as i.e.: "22" or "22/tcp"
+func checkPorts(sys *system) result {
+	if sys.goos != "linux" {
+		return unsupported(sys)
+	}
+
+	out, err := sys.run("ss", "--tcp", "--udp", "--listening", "--numeric", "--no-header")
+	if err != nil {
+		return unknown("%s", err)
+	}
+
+	exposed := []string{}
+	for _, port := range parseSS(out) {
+		proto := strings.SplitN(port, "/", 2)
+
+		if utils.Includes(sys.allowedPorts, port) || utils.Includes(sys.allowedPorts, proto[0]) {
+			continue
+		}
+
+		exposed = append(exposed, port)
+	}
+
+	if len(exposed) == 0 {
+		return pass("nothing unexpected is listening")
+	}
+
+	return warn("listening on %s", strings.Join(exposed, ", "))
+}
+
+// parseSS returns the ports, i.e.: "22/tcp", that the output of `ss --listening --numeric`
+// shows listening on an address other than loopback:
+//
+//    tcp   LISTEN 0      128          0.0.0.0:22        0.0.0.0:*
+//    tcp   LISTEN 0      4096   127.0.0.53%lo:53        0.0.0.0:*
+//    udp   UNCONN 0      0                 *:5353             *:*
+func parseSS(out string) []string {
+	ports := []string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+
+		idx := strings.LastIndex(fields[4], ":")
+		if idx < 0 {
+			continue
+		}
+
+		host := strings.Trim(fields[4][:idx], "[]")
+		host = strings.SplitN(host, "%", 2)[0]
+
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			continue
+		}
+
+		ports = appendUnique(ports, fmt.Sprintf("%s/%s", fields[4][idx+1:], fields[0]))
+	}
+
+	sort.Strings(ports)
+
+	return ports
+}
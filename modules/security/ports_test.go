@@ -0,0 +1,58 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ssListening = `udp   UNCONN 0      0            127.0.0.53%lo:53          0.0.0.0:*
+udp   UNCONN 0      0                  0.0.0.0:5353        0.0.0.0:*
+udp   UNCONN 0      0                     [::]:5353           [::]:*
+tcp   LISTEN 0      128                0.0.0.0:22          0.0.0.0:*
+tcp   LISTEN 0      4096             127.0.0.1:631         0.0.0.0:*
+tcp   LISTEN 0      128                   [::]:22             [::]:*
+tcp   LISTEN 0      511                      *:8080              *:*
+tcp   LISTEN 0      4096                 [::1]:631            [::]:*
+`
+
+func Test_parseSS(t *testing.T) {
+	assert.Equal(t, []string{"22/tcp", "5353/udp", "8080/tcp"}, parseSS(ssListening))
+}
+
+func Test_checkPorts(t *testing.T) {
+	commands := map[string]fakeOutput{
+		"ss --tcp --udp --listening --numeric --no-header": {out: ssListening},
+	}
+
+	tests := []struct {
+		name     string
+		allowed  []string
+		expected result
+	}{
+		{
+			name:     "nothing allowed",
+			allowed:  []string{},
+			expected: warn("listening on 22/tcp, 5353/udp, 8080/tcp"),
+		},
+		{
+			name:     "some allowed",
+			allowed:  []string{"22", "5353/udp"},
+			expected: warn("listening on 8080/tcp"),
+		},
+		{
+			name:     "all allowed",
+			allowed:  []string{"22/tcp", "5353", "8080"},
+			expected: pass("nothing unexpected is listening"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sys := fakeSystem("linux", commands, nil)
+			sys.allowedPorts = tt.allowed
+
+			assert.Equal(t, tt.expected, checkPorts(sys))
+		})
+	}
+}
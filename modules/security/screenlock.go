@@ -0,0 +1,83 @@
+package security
+
+import (
+	"strconv"
+	"strings"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// checkScreenLock checks that the screen locks itself when the session is idle, in GNOME
+// or KDE
+func checkScreenLock(sys *system) result {
+	if sys.goos != "linux" {
+		return unsupported(sys)
+	}
+
+	switch {
+	case sys.has("gsettings"):
+		lockEnabled, err := sys.run("gsettings", "get", "org.gnome.desktop.screensaver", "lock-enabled")
+		if err != nil {
+			return unknown("%s", err)
+		}
+
+		idleDelay, err := sys.run("gsettings", "get", "org.gnome.desktop.session", "idle-delay")
+		if err != nil {
+			return unknown("%s", err)
+		}
+
+		return parseGnomeScreenLock(lockEnabled, idleDelay)
+	case sys.has("kreadconfig5"):
+		// kreadconfig5 prints nothing for keys that have their default value, which is on
+		autolock, _ := sys.run("kreadconfig5", "--file", "kscreenlockerrc", "--group", "Daemon", "--key", "Autolock")
+		if strings.TrimSpace(autolock) == "false" {
+			return fail("the screen doesn't lock when idle")
+		}
+
+		return pass("the screen locks when idle")
+	default:
+		return unknown("no supported desktop is installed")
+	}
+}
+
+// parseGnomeScreenLock reads the output of `gsettings get` for the lock-enabled and the
+// idle-delay settings. An idle delay of 0 means the screen is never blanked, so it never
+// locks either
+func parseGnomeScreenLock(lockEnabled, idleDelay string) result {
+	if strings.TrimSpace(lockEnabled) != "true" {
+		return fail("the screen doesn't lock when idle")
+	}
+
+	// The delay is printed as i.e.: "uint32 300"
+	fields := strings.Fields(idleDelay)
+	if len(fields) == 0 {
+		return unknown("could not read the idle delay")
+	}
+
+	seconds, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return unknown("could not read the idle delay")
+	}
+
+	if seconds == 0 {
+		return fail("the screen never goes idle, so it never locks")
+	}
+
+	if seconds > 15*60 {
+		return warn("the screen locks after %d minutes idle", seconds/60)
+	}
+
+	return pass("the screen locks after %s idle", idleTime(seconds))
+}
+
+func idleTime(seconds int) string {
+	if seconds%60 != 0 {
+		return strconv.Itoa(seconds) + " seconds"
+	}
+
+	if seconds == 60 {
+		return "1 minute"
+	}
+
+	return strconv.Itoa(seconds/60) + " minutes"
+}
@@ -0,0 +1,66 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseGnomeScreenLock(t *testing.T) {
+	tests := []struct {
+		name        string
+		lockEnabled string
+		idleDelay   string
+		expected    result
+	}{
+		{
+			name:        "locks",
+			lockEnabled: "true\n",
+			idleDelay:   "uint32 300\n",
+			expected:    pass("the screen locks after 5 minutes idle"),
+		},
+		{
+			name:        "locks after one minute",
+			lockEnabled: "true\n",
+			idleDelay:   "uint32 60\n",
+			expected:    pass("the screen locks after 1 minute idle"),
+		},
+		{
+			name:        "locks late",
+			lockEnabled: "true\n",
+			idleDelay:   "uint32 1800\n",
+			expected:    warn("the screen locks after 30 minutes idle"),
+		},
+		{
+			name:        "never idle",
+			lockEnabled: "true\n",
+			idleDelay:   "uint32 0\n",
+			expected:    fail("the screen never goes idle, so it never locks"),
+		},
+		{
+			name:        "lock disabled",
+			lockEnabled: "false\n",
+			idleDelay:   "uint32 300\n",
+			expected:    fail("the screen doesn't lock when idle"),
+		},
+		{
+			name:        "unreadable delay",
+			lockEnabled: "true\n",
+			idleDelay:   "",
+			expected:    unknown("could not read the idle delay"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseGnomeScreenLock(tt.lockEnabled, tt.idleDelay))
+		})
+	}
+}
+
+func Test_checkScreenLock_KDE(t *testing.T) {
+	cmd := "kreadconfig5 --file kscreenlockerrc --group Daemon --key Autolock"
+
+	assert.Equal(t, statusPass, checkScreenLock(fakeSystem("linux", map[string]fakeOutput{cmd: {out: "\n"}}, nil)).status)
+	assert.Equal(t, statusFail, checkScreenLock(fakeSystem("linux", map[string]fakeOutput{cmd: {out: "false\n"}}, nil)).status)
+}
@@ -3,6 +3,7 @@ package security
 import (
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
 )
 
 const (
@@ -12,11 +13,23 @@ const (
 
 type Settings struct {
 	common *cfg.Common
+
+	allowedPorts []string `help:"The ports that are expected to listen on interfaces other than loopback, i.e.: 22 or 22/tcp." optional:"true"`
+	checks       []string `help:"The checks to run, in the order they're shown. Defaults to all of them." values:"dns, encryption, firewall, ports, screenlock, ssh, updates, users or wifi" optional:"true"`
+	trustedDNS   []string `help:"The DNS resolvers that are expected to be used. Any resolver is fine if this isn't set." optional:"true"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, defaultFocusable, ymlConfig, globalConfig),
+
+		allowedPorts: utils.ToStrs(ymlConfig.UList("allowedPorts")),
+		checks:       utils.ToStrs(ymlConfig.UList("checks")),
+		trustedDNS:   utils.ToStrs(ymlConfig.UList("trustedDNS")),
+	}
+
+	if len(settings.checks) == 0 {
+		settings.checks = defaultChecks
 	}
 
 	return &settings
@@ -0,0 +1,107 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const sshdConfig = "/etc/ssh/sshd_config"
+
+/* -------------------- Unexported Functions -------------------- */
+
+// checkSSH checks that the SSH server doesn't let anyone log in as root or with a password
+func checkSSH(sys *system) result {
+	if sys.goos != "linux" && sys.goos != "darwin" {
+		return unsupported(sys)
+	}
+
+	options, err := readSSHDConfig(sys, sshdConfig, 0)
+	if os.IsNotExist(err) {
+		return pass("no SSH server is installed")
+	}
+	if err != nil {
+		return unknown("%s", err)
+	}
+
+	return sshdResult(options)
+}
+
+// readSSHDConfig returns the options that are set in an sshd_config file and in the files
+// it includes. Like sshd, the first value of an option is the one that's used. Options in
+// Match blocks only apply to some connections, so they're left out
+func readSSHDConfig(sys *system, path string, depth int) (map[string]string, error) {
+	text, err := sys.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	options := map[string]string{}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(strings.Replace(line, "=", " ", 1))
+		key := strings.ToLower(fields[0])
+
+		if key == "match" {
+			break
+		}
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		if key == "include" && depth < 8 {
+			for _, pattern := range fields[1:] {
+				if !filepath.IsAbs(pattern) {
+					pattern = filepath.Join(filepath.Dir(sshdConfig), pattern)
+				}
+
+				paths, _ := sys.glob(pattern)
+				for _, included := range paths {
+					includedOptions, err := readSSHDConfig(sys, included, depth+1)
+					if err != nil {
+						continue
+					}
+
+					for includedKey, value := range includedOptions {
+						if _, ok := options[includedKey]; !ok {
+							options[includedKey] = value
+						}
+					}
+				}
+			}
+
+			continue
+		}
+
+		if _, ok := options[key]; !ok {
+			options[key] = strings.ToLower(fields[1])
+		}
+	}
+
+	return options, nil
+}
+
+// sshdResult fails if root can log in with a password or if empty passwords are allowed,
+// and warns if anyone can log in with a password
+func sshdResult(options map[string]string) result {
+	if options["permitemptypasswords"] == "yes" {
+		return fail("empty passwords are allowed")
+	}
+
+	if options["permitrootlogin"] == "yes" {
+		return fail("root can log in with a password")
+	}
+
+	// Passwords are allowed unless they're turned off
+	if options["passwordauthentication"] != "no" {
+		return warn("password logins are allowed")
+	}
+
+	return pass("only key logins are allowed")
+}
@@ -0,0 +1,71 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readSSHDConfig(t *testing.T) {
+	sys := fakeSystem("linux", nil, map[string]string{
+		sshdConfig: `# Include comes first so that drop-ins win
+Include /etc/ssh/sshd_config.d/*.conf
+
+PermitRootLogin prohibit-password
+PasswordAuthentication yes
+
+Match User backup
+	PasswordAuthentication no
+	PermitEmptyPasswords yes
+`,
+		"/etc/ssh/sshd_config.d/50-cloud-init.conf": "PasswordAuthentication=no\n",
+	})
+
+	options, err := readSSHDConfig(sys, sshdConfig, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		map[string]string{"permitrootlogin": "prohibit-password", "passwordauthentication": "no"},
+		options,
+	)
+}
+
+func Test_sshdResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]string
+		expected result
+	}{
+		{
+			name:     "defaults",
+			options:  map[string]string{},
+			expected: warn("password logins are allowed"),
+		},
+		{
+			name:     "keys only",
+			options:  map[string]string{"passwordauthentication": "no"},
+			expected: pass("only key logins are allowed"),
+		},
+		{
+			name:     "root login",
+			options:  map[string]string{"passwordauthentication": "no", "permitrootlogin": "yes"},
+			expected: fail("root can log in with a password"),
+		},
+		{
+			name:     "empty passwords",
+			options:  map[string]string{"permitemptypasswords": "yes"},
+			expected: fail("empty passwords are allowed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sshdResult(tt.options))
+		})
+	}
+}
+
+func Test_checkSSH_NotInstalled(t *testing.T) {
+	assert.Equal(t, pass("no SSH server is installed"), checkSSH(fakeSystem("linux", nil, nil)))
+}
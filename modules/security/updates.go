@@ -0,0 +1,85 @@
+package security
+
+import (
+	"strings"
+)
+
+/* -------------------- Unexported Functions -------------------- */
+
+// checkUpdates fails if security updates are waiting to be installed. It asks apt on Debian
+// and Ubuntu, and dnf on Fedora and RHEL
+func checkUpdates(sys *system) result {
+	if sys.goos != "linux" {
+		return unsupported(sys)
+	}
+
+	switch {
+	case sys.has("apt-get"):
+		// Simulates an upgrade, which doesn't need root
+		out, err := sys.run("apt-get", "--simulate", "--quiet", "upgrade")
+		if err != nil {
+			return unknown("%s", err)
+		}
+
+		return updatesResult(parseAptSimulate(out))
+	case sys.has("dnf"):
+		out, err := sys.run("dnf", "updateinfo", "list", "--security", "--quiet")
+		if err != nil {
+			return unknown("%s", err)
+		}
+
+		return updatesResult(parseDnfUpdateinfo(out))
+	default:
+		return unknown("no supported package manager is installed")
+	}
+}
+
+func updatesResult(count int) result {
+	switch count {
+	case 0:
+		return pass("no security updates are pending")
+	case 1:
+		return fail("1 security update is pending")
+	default:
+		return fail("%d security updates are pending", count)
+	}
+}
+
+// parseAptSimulate counts the packages in the output of `apt-get --simulate upgrade` that
+// would be installed from a security archive:
+//
+//    Inst openssl [1.1.1f-1ubuntu2.16] (1.1.1f-1ubuntu2.17 Ubuntu:20.04/focal-security [amd64])
+//    Inst base-files [11ubuntu5.5] (11ubuntu5.6 Ubuntu:20.04/focal-updates [amd64])
+func parseAptSimulate(out string) int {
+	count := 0
+
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, "Inst ") {
+			continue
+		}
+
+		if strings.Contains(strings.ToLower(line), "-security") {
+			count++
+		}
+	}
+
+	return count
+}
+
+// parseDnfUpdateinfo counts the packages in the output of `dnf updateinfo list --security`:
+//
+//    FEDORA-2020-1a2b3c4d5e Important/Sec. openssl-1:1.1.1g-1.fc32.x86_64
+func parseDnfUpdateinfo(out string) int {
+	packages := []string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasSuffix(fields[1], "/Sec.") {
+			continue
+		}
+
+		packages = appendUnique(packages, fields[2])
+	}
+
+	return len(packages)
+}
@@ -0,0 +1,42 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const aptSimulate = `Reading package lists...
+Building dependency tree...
+Calculating upgrade...
+The following packages will be upgraded:
+  base-files libssl1.1 openssl
+3 upgraded, 0 newly installed, 0 to remove and 0 not upgraded.
+Inst base-files [11ubuntu5.5] (11ubuntu5.6 Ubuntu:20.04/focal-updates [amd64])
+Inst libssl1.1 [1.1.1f-1ubuntu2.16] (1.1.1f-1ubuntu2.17 Ubuntu:20.04/focal-updates, Ubuntu:20.04/focal-security [amd64])
+Inst openssl [1.1.1f-1ubuntu2.16] (1.1.1f-1ubuntu2.17 Ubuntu:20.04/focal-security [amd64])
+Conf base-files (11ubuntu5.6 Ubuntu:20.04/focal-updates [amd64])
+Conf openssl (1.1.1f-1ubuntu2.17 Ubuntu:20.04/focal-security [amd64])
+`
+
+const dnfUpdateinfo = `FEDORA-2020-1a2b3c4d5e Important/Sec. openssl-1:1.1.1g-1.fc32.x86_64
+FEDORA-2020-1a2b3c4d5e Important/Sec. openssl-libs-1:1.1.1g-1.fc32.x86_64
+FEDORA-2020-6f7a8b9c0d Moderate/Sec.  openssl-1:1.1.1g-1.fc32.x86_64
+FEDORA-2020-0e1f2a3b4c bugfix         vim-enhanced-2:8.2.1224-1.fc32.x86_64
+`
+
+func Test_parseAptSimulate(t *testing.T) {
+	assert.Equal(t, 2, parseAptSimulate(aptSimulate))
+	assert.Equal(t, 0, parseAptSimulate("0 upgraded, 0 newly installed, 0 to remove and 0 not upgraded.\n"))
+}
+
+func Test_parseDnfUpdateinfo(t *testing.T) {
+	assert.Equal(t, 2, parseDnfUpdateinfo(dnfUpdateinfo))
+	assert.Equal(t, 0, parseDnfUpdateinfo(""))
+}
+
+func Test_updatesResult(t *testing.T) {
+	assert.Equal(t, pass("no security updates are pending"), updatesResult(0))
+	assert.Equal(t, fail("1 security update is pending"), updatesResult(1))
+	assert.Equal(t, fail("3 security updates are pending"), updatesResult(3))
+}
@@ -3,35 +3,55 @@ package security
 // http://applehelpwriter.com/2017/05/21/how-to-reveal-hidden-users/
 
 import (
-	"os/exec"
-	"runtime"
 	"strings"
-
-	"github.com/wtfutil/wtf/utils"
 )
 
-/* -------------------- Exported Functions -------------------- */
+/* -------------------- Unexported Functions -------------------- */
+
+// checkUsers lists the users that are logged in, or that have accounts on macOS
+func checkUsers(sys *system) result {
+	var users []string
 
-func LoggedInUsers() []string {
-	switch runtime.GOOS {
+	switch sys.goos {
 	case "linux":
-		return loggedInUsersLinux()
+		out, err := sys.run("who", "-us")
+		if err != nil {
+			return unknown("%s", err)
+		}
+		users = parseWho(out)
 	case "darwin":
-		return loggedInUsersMacOs()
+		out, err := sys.run("dscl", ".", "-list", "/Users")
+		if err != nil {
+			return unknown("%s", err)
+		}
+		users = cleanUsers(strings.Split(out, "\n"))
 	case "windows":
-		return loggedInUsersWindows()
+		// We can use either one:
+		// 		(Get-WMIObject -class Win32_ComputerSystem | select username).username
+		// 		[System.Security.Principal.WindowsIdentity]::GetCurrent().Name
+		// ToDo:  Make list for multi-user systems
+		out, err := sys.run("powershell.exe", "-NoProfile", "-Command", "& { [System.Security.Principal.WindowsIdentity]::GetCurrent().Name }")
+		if err != nil {
+			return unknown("%s", err)
+		}
+		users = cleanUsers(strings.Split(out, "\n"))
 	default:
-		return []string{}
+		return unsupported(sys)
 	}
-}
 
-/* -------------------- Unexported Functions -------------------- */
+	if len(users) == 0 {
+		return info("nobody is logged in")
+	}
+
+	return info("%s", strings.Join(users, ", "))
+}
 
 func cleanUsers(users []string) []string {
 	rejects := []string{"_", "root", "nobody", "daemon", "Guest"}
 	cleaned := []string{}
 
 	for _, user := range users {
+		user = strings.TrimSpace(user)
 		clean := true
 
 		for _, reject := range rejects {
@@ -49,52 +69,16 @@ func cleanUsers(users []string) []string {
 	return cleaned
 }
 
-func loggedInUsersLinux() []string {
-	cmd := exec.Command("who", "-us")
-	users := utils.ExecuteCommand(cmd)
-
-	cleaned := []string{}
-
-	for _, user := range strings.Split(users, "\n") {
-		clean := true
-		col := strings.Split(user, " ")
+// parseWho returns the users in the output of `who -us`, once each
+func parseWho(out string) []string {
+	users := []string{}
 
-		if len(col) > 0 {
-			for _, cleanedU := range cleaned {
-				u := strings.TrimSpace(col[0])
-				if len(u) == 0 || strings.Compare(cleanedU, col[0]) == 0 {
-					clean = false
-				}
-			}
-			if clean {
-				cleaned = append(cleaned, col[0])
-			}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			users = appendUnique(users, fields[0])
 		}
 	}
 
-	return cleaned
-}
-
-func loggedInUsersMacOs() []string {
-	cmd := exec.Command("dscl", []string{".", "-list", "/Users"}...)
-	users := utils.ExecuteCommand(cmd)
-
-	return cleanUsers(strings.Split(users, "\n"))
-}
-
-func loggedInUsersWindows() []string {
-	// We can use either one:
-	// 		(Get-WMIObject -class Win32_ComputerSystem | select username).username
-	// 		[System.Security.Principal.WindowsIdentity]::GetCurrent().Name
-	// The original was:
-	//		cmd := exec.Command("powershell.exe", "(query user) -replace '\\s{2,}', ','")
-	// But that didn't work!
-	// The real powershell command reads:
-	// 	 powershell.exe -NoProfile -Command "& { [System.Security.Principal.WindowsIdentity]::GetCurrent().Name }"
-	// But we here have to write it as:
-	cmd := exec.Command("powershell.exe", "-NoProfile", "-Command", "& { [System.Security.Principal.WindowsIdentity]::GetCurrent().Name }")
-	// ToDo:  Make list for multi-user systems
-
-	users := utils.ExecuteCommand(cmd)
-	return cleanUsers(strings.Split(users, "\n"))
+	return users
 }
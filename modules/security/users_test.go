@@ -0,0 +1,22 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseWho(t *testing.T) {
+	out := `chris    tty2         2020-08-01 09:00  old         1234 (tty2)
+chris    pts/0        2020-08-01 09:05   .          2345 (192.168.1.20)
+backup   pts/1        2020-08-01 10:00 00:10        3456 (10.0.0.5)
+`
+
+	assert.Equal(t, []string{"chris", "backup"}, parseWho(out))
+}
+
+func Test_cleanUsers(t *testing.T) {
+	users := []string{"_analyticsd", "chris", "daemon", "Guest", "nobody", "root", " sam ", ""}
+
+	assert.Equal(t, []string{"chris", "sam"}, cleanUsers(users))
+}
@@ -2,16 +2,26 @@ package security
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/view"
 )
 
+// statusLabels are how each status is shown next to a check
+var statusLabels = map[status]string{
+	statusUnknown: "[grey]  ? [white]",
+	statusInfo:    "[white]info[white]",
+	statusFail:    "[red]fail[white]",
+	statusWarn:    "[yellow]warn[white]",
+	statusPass:    "[green]pass[white]",
+}
+
 type Widget struct {
 	view.TextWidget
 
+	results  []result
 	settings *Settings
+	system   *system
 }
 
 func NewWidget(app *tview.Application, settings *Settings) *Widget {
@@ -19,6 +29,7 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 		TextWidget: view.NewTextWidget(app, settings.common),
 
 		settings: settings,
+		system:   newSystem(settings),
 	}
 
 	return &widget
@@ -27,37 +38,52 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
-
 	if widget.Disabled() {
 		return
 	}
 
-	widget.Redraw(widget.content)
+	results := runChecks(widget.system, widget.settings.checks)
+
+	widget.Redraw(func() (string, string, bool) {
+		return widget.content(results)
+	})
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) content() (string, string, bool) {
-	data := NewSecurityData()
-	data.Fetch()
-	str := fmt.Sprintf(" [%s]WiFi[white]\n", widget.settings.common.Colors.Subheading)
-	str += fmt.Sprintf(" %8s: %s\n", "Network", data.WifiName)
-	str += fmt.Sprintf(" %8s: %s\n", "Crypto", data.WifiEncryption)
-	str += "\n"
-
-	str += fmt.Sprintf(" [%s]Firewall[white]\n", widget.settings.common.Colors.Subheading)
-	str += fmt.Sprintf(" %8s: %4s\n", "Status", data.FirewallEnabled)
-	str += fmt.Sprintf(" %8s: %4s\n", "Stealth", data.FirewallStealth)
-	str += "\n"
+func (widget *Widget) content(results []result) (string, string, bool) {
+	str := fmt.Sprintf(" [%s]Score[white] %s\n\n", widget.settings.common.Colors.Subheading, scoreLabel(score(results)))
 
-	str += fmt.Sprintf(" [%s]Users[white]\n", widget.settings.common.Colors.Subheading)
-	str += fmt.Sprintf("  %s", strings.Join(data.LoggedInUsers, "\n  "))
-	str += "\n\n"
+	longestName := 0
+	for _, res := range results {
+		if len(res.name) > longestName {
+			longestName = len(res.name)
+		}
+	}
 
-	str += fmt.Sprintf(" [%s]DNS[white]\n", widget.settings.common.Colors.Subheading)
-	str += fmt.Sprintf("  %12s\n", data.DnsAt(0))
-	str += fmt.Sprintf("  %12s\n", data.DnsAt(1))
-	str += "\n"
+	for _, res := range results {
+		str += fmt.Sprintf(
+			" %s %-*s %s\n",
+			statusLabels[res.status],
+			longestName,
+			res.name,
+			tview.Escape(res.reason),
+		)
+	}
 
 	return widget.CommonSettings().Title, str, false
 }
+
+// scoreLabel colors the score by how good it is
+func scoreLabel(score int) string {
+	switch {
+	case score < 0:
+		return "[grey]n/a[white]"
+	case score >= 80:
+		return fmt.Sprintf("[green]%d%%[white]", score)
+	case score >= 50:
+		return fmt.Sprintf("[yellow]%d%%[white]", score)
+	default:
+		return fmt.Sprintf("[red]%d%%[white]", score)
+	}
+}
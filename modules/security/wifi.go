@@ -1,115 +1,125 @@
 package security
 
 import (
-	"os/exec"
-	"runtime"
 	"strings"
-
-	"github.com/wtfutil/wtf/utils"
 )
 
 // https://github.com/yelinaung/wifi-name/blob/master/wifi-name.go
 const osxWifiCmd = "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
 const osxWifiArg = "-I"
 
-/* -------------------- Exported Functions -------------------- */
+/* -------------------- Unexported Functions -------------------- */
 
-func WifiEncryption() string {
-	switch runtime.GOOS {
-	case "linux":
-		return wifiEncryptionLinux()
-	case "darwin":
-		return wifiEncryptionMacOS()
-	case "windows":
-		return wifiEncryptionWindows()
-	default:
-		return ""
-	}
-}
+// checkWifi checks the encryption of the WiFi network the machine is connected to
+func checkWifi(sys *system) result {
+	var ssid, auth string
 
-func WifiName() string {
-	switch runtime.GOOS {
+	switch sys.goos {
 	case "linux":
-		return wifiNameLinux()
+		out, err := sys.run("nmcli", "-t", "-f", "active,ssid,security", "dev", "wifi")
+		if err != nil {
+			return unknown("%s", err)
+		}
+		ssid, auth = parseNmcliWifi(out)
 	case "darwin":
-		return wifiNameMacOS()
+		out, err := sys.run(osxWifiCmd, osxWifiArg)
+		if err != nil {
+			return unknown("%s", err)
+		}
+		ssid, auth = parseColonFields(out, "SSID", "link auth")
 	case "windows":
-		return wifiNameWindows()
+		out, err := sys.run("netsh.exe", "wlan", "show", "interfaces")
+		if err != nil {
+			return unknown("%s", err)
+		}
+		ssid, auth = parseColonFields(out, "SSID", "Authentication")
 	default:
-		return ""
-	}
-}
-
-/* -------------------- Unexported Functions -------------------- */
-
-func wifiEncryptionLinux() string {
-	cmd := exec.Command("nmcli", "-t", "-f", "in-use,security", "dev", "wifi")
-	out := utils.ExecuteCommand(cmd)
-
-	name := utils.FindMatch(`\*:(.+)`, out)
-
-	if len(name) > 0 {
-		return name[0][1]
+		return unsupported(sys)
 	}
 
-	return ""
+	return wifiResult(ssid, auth)
 }
 
-func wifiEncryptionMacOS() string {
-	name := utils.FindMatch(`s*auth: (.+)s*`, wifiInfo())
-	return matchStr(name)
-}
+// wifiResult passes networks that use WPA2 or WPA3, warns about WPA, and fails open and
+// WEP networks
+func wifiResult(ssid, auth string) result {
+	if ssid == "" {
+		return info("not connected")
+	}
 
-func wifiInfo() string {
-	cmd := exec.Command(osxWifiCmd, osxWifiArg)
-	return utils.ExecuteCommand(cmd)
+	normalized := strings.ToLower(strings.Replace(auth, "-", "", -1))
+
+	switch {
+	case strings.Contains(normalized, "wpa3") || strings.Contains(normalized, "wpa2") || strings.Contains(normalized, "sae"):
+		return pass("%s uses %s", ssid, auth)
+	case strings.Contains(normalized, "wep"):
+		return fail("%s uses WEP, which is broken", ssid)
+	case strings.Contains(normalized, "wpa"):
+		return warn("%s uses %s, which is outdated", ssid, auth)
+	case normalized == "" || normalized == "none" || normalized == "open" || normalized == "--":
+		return fail("%s is not encrypted", ssid)
+	default:
+		return unknown("%s uses %s", ssid, auth)
+	}
 }
 
-func wifiNameLinux() string {
-	cmd, _ := exec.Command("iwgetid", "-r").Output()
-	return string(cmd)
-}
+// parseNmcliWifi returns the SSID and the security of the active network in the output of
+// `nmcli -t -f active,ssid,security dev wifi`. Colons in the SSID are escaped:
+//
+//    no:Neighbours:WPA2
+//    yes:Cafe\: Free:
+func parseNmcliWifi(out string) (string, string) {
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, "yes:") {
+			continue
+		}
 
-func wifiNameMacOS() string {
-	name := utils.FindMatch(`s*SSID: (.+)s*`, wifiInfo())
-	return matchStr(name)
-}
+		fields := []string{}
+		field := strings.Builder{}
+		escaped := false
+
+		for _, char := range line {
+			switch {
+			case escaped:
+				field.WriteRune(char)
+				escaped = false
+			case char == '\\':
+				escaped = true
+			case char == ':':
+				fields = append(fields, field.String())
+				field.Reset()
+			default:
+				field.WriteRune(char)
+			}
+		}
+		fields = append(fields, field.String())
 
-func matchStr(data [][]string) string {
-	if len(data) <= 1 {
-		return ""
+		if len(fields) >= 3 {
+			return fields[1], strings.TrimSpace(fields[2])
+		}
 	}
 
-	return data[1][1]
-}
-
-//Windows
-func wifiEncryptionWindows() string {
-	return parseWlanNetsh("Authentication")
+	return "", ""
 }
 
-func wifiNameWindows() string {
-	return parseWlanNetsh("SSID")
-}
+// parseColonFields returns the values of two "key: value" lines, as printed by airport and
+// netsh
+func parseColonFields(out, ssidKey, authKey string) (string, string) {
+	ssid, auth := "", ""
 
-func parseWlanNetsh(target string) string {
-	cmd := exec.Command("netsh.exe", "wlan", "show", "interfaces")
-	out, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-	splits := strings.Split(string(out), "\n")
-	var words []string
-	for _, line := range splits {
-		token := strings.Split(line, ":")
-		for _, word := range token {
-			words = append(words, strings.TrimSpace(word))
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
 		}
-	}
-	for i, token := range words {
-		if token == target {
-			return words[i+1]
+
+		switch strings.TrimSpace(parts[0]) {
+		case ssidKey:
+			ssid = strings.TrimSpace(parts[1])
+		case authKey:
+			auth = strings.TrimSpace(parts[1])
 		}
 	}
-	return "N/A"
+
+	return ssid, auth
 }
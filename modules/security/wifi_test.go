@@ -0,0 +1,65 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const airportInfo = `     agrCtlRSSI: -52
+     agrCtlNoise: -92
+          state: running
+        op mode: station
+     lastTxRate: 585
+        maxRate: 867
+lastAssocStatus: 0
+    802.11 auth: open
+      link auth: wpa2-psk
+          BSSID: 0:11:22:33:44:55
+           SSID: Home Network
+            MCS: 7
+        channel: 36,80
+`
+
+func Test_parseNmcliWifi(t *testing.T) {
+	ssid, auth := parseNmcliWifi("no:Neighbours:WPA2\nyes:Cafe\\: Free:\n")
+	assert.Equal(t, "Cafe: Free", ssid)
+	assert.Equal(t, "", auth)
+
+	ssid, auth = parseNmcliWifi("yes:Home:WPA2 WPA3\n")
+	assert.Equal(t, "Home", ssid)
+	assert.Equal(t, "WPA2 WPA3", auth)
+
+	ssid, _ = parseNmcliWifi("no:Neighbours:WPA2\n")
+	assert.Equal(t, "", ssid)
+}
+
+func Test_parseColonFields(t *testing.T) {
+	ssid, auth := parseColonFields(airportInfo, "SSID", "link auth")
+
+	assert.Equal(t, "Home Network", ssid)
+	assert.Equal(t, "wpa2-psk", auth)
+}
+
+func Test_wifiResult(t *testing.T) {
+	tests := []struct {
+		auth     string
+		expected status
+	}{
+		{auth: "WPA2", expected: statusPass},
+		{auth: "wpa2-psk", expected: statusPass},
+		{auth: "WPA3-Personal", expected: statusPass},
+		{auth: "WPA", expected: statusWarn},
+		{auth: "WEP", expected: statusFail},
+		{auth: "", expected: statusFail},
+		{auth: "Open", expected: statusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.auth, func(t *testing.T) {
+			assert.Equal(t, tt.expected, wifiResult("Home", tt.auth).status)
+		})
+	}
+
+	assert.Equal(t, info("not connected"), wifiResult("", ""))
+}
@@ -2,6 +2,8 @@ package todo
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/checklist"
@@ -14,50 +16,121 @@ func (widget *Widget) display() {
 
 func (widget *Widget) content() (string, string, bool) {
 	str := ""
-	newList := checklist.NewChecklist(
-		widget.settings.common.Sigils.Checkbox.Checked,
-		widget.settings.common.Sigils.Checkbox.Unchecked,
-	)
-
-	offset := 0
 	selectedItem := widget.SelectedItem()
-	for idx, item := range widget.list.UncheckedItems() {
-		str += widget.formattedItemLine(idx, item, selectedItem, widget.list.LongestLine())
-		newList.Items = append(newList.Items, item)
-		offset++
+
+	// Unchecked items are listed first, but the list itself keeps the file's order
+	widget.visible = widget.visibleItems()
+	widget.SetItemCount(len(widget.visible))
+
+	now := time.Now()
+	for idx, item := range widget.visible {
+		str += widget.formattedItemLine(idx, item, selectedItem, now)
 	}
 
-	for idx, item := range widget.list.CheckedItems() {
-		str += widget.formattedItemLine(idx+offset, item, selectedItem, widget.list.LongestLine())
-		newList.Items = append(newList.Items, item)
+	for idx, item := range widget.visible {
+		if item == selectedItem {
+			widget.Selected = idx
+		}
 	}
 
-	if idx, ok := newList.IndexByItem(selectedItem); ok {
-		widget.Selected = idx
+	if widget.Selected >= len(widget.visible) {
+		widget.Selected = len(widget.visible) - 1
 	}
 
-	widget.SetList(newList)
+	return widget.title(), str, false
+}
+
+// visibleItems returns the items that are displayed: those tagged with the tag being
+// filtered on, unchecked ones first, each in the order being sorted by
+func (widget *Widget) visibleItems() []*checklist.ChecklistItem {
+	filtered := widget.list.Filter(widget.tagFilter)
+	items := []*checklist.ChecklistItem{}
+
+	for _, group := range [][]*checklist.ChecklistItem{filtered.UncheckedItems(), filtered.CheckedItems()} {
+		list := checklist.Checklist{Items: group}
+
+		switch widget.sortBy {
+		case sortByDue:
+			list.SortByDue()
+		case sortByPriority:
+			list.SortByPriority()
+		}
 
-	return widget.CommonSettings().Title, str, false
+		items = append(items, list.Items...)
+	}
+
+	return items
 }
 
-func (widget *Widget) formattedItemLine(idx int, currItem *checklist.ChecklistItem, selectedItem *checklist.ChecklistItem, maxLen int) string {
+// title returns the widget's title, with the tag being filtered on and the order being
+// sorted by, if any
+func (widget *Widget) title() string {
+	modes := []string{}
+
+	if widget.tagFilter != "" {
+		modes = append(modes, "#"+widget.tagFilter)
+	}
+
+	switch widget.sortBy {
+	case sortByDue:
+		modes = append(modes, "by due date")
+	case sortByPriority:
+		modes = append(modes, "by priority")
+	}
+
+	if len(modes) == 0 {
+		return widget.CommonSettings().Title
+	}
+
+	return fmt.Sprintf("%s (%s)", widget.CommonSettings().Title, tview.Escape(strings.Join(modes, ", ")))
+}
+
+func (widget *Widget) formattedItemLine(idx int, currItem *checklist.ChecklistItem, selectedItem *checklist.ChecklistItem, now time.Time) string {
 	rowColor := widget.RowColor(idx)
 
 	if currItem.Checked {
 		rowColor = widget.settings.common.Colors.CheckboxTheme.Checked
 	}
 
+	if currItem.IsOverdue(now) {
+		rowColor = widget.settings.overdueColor
+	}
+
 	if widget.View.HasFocus() && (currItem == selectedItem) {
 		rowColor = widget.RowColor(idx)
 	}
 
+	text := currItem.Text
+	if currItem.Priority > 0 {
+		text = fmt.Sprintf("!%d %s", currItem.Priority, text)
+	}
+	if due := dueLabel(currItem, now); due != "" {
+		text = fmt.Sprintf("%s (due %s)", text, due)
+	}
+
 	row := fmt.Sprintf(
 		` [%s]|%s| %s[white]`,
 		rowColor,
 		currItem.CheckMark(),
-		tview.Escape(currItem.Text),
+		tview.Escape(text),
 	)
 
-	return utils.HighlightableHelper(widget.View, row, idx, len(currItem.Text))
+	return utils.HighlightableHelper(widget.View, row, idx, len(text))
+}
+
+// dueLabel returns when an item is due, as "today", "tomorrow" or its due date
+func dueLabel(item *checklist.ChecklistItem, now time.Time) string {
+	due, ok := item.DueDate()
+	if !ok {
+		return ""
+	}
+
+	switch due.Format(checklist.DueDateFormat) {
+	case now.Format(checklist.DueDateFormat):
+		return "today"
+	case now.AddDate(0, 0, 1).Format(checklist.DueDateFormat):
+		return "tomorrow"
+	default:
+		return item.Due
+	}
 }
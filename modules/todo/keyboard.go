@@ -1,10 +1,9 @@
 package todo
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/gdamore/tcell"
-	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/utils"
 )
 
@@ -14,8 +13,10 @@ func (widget *Widget) initializeKeyboardControls() {
 	widget.SetKeyboardChar("j", widget.Next, "Select next item")
 	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
 	widget.SetKeyboardChar(" ", widget.toggleChecked, "Toggle checkmark")
+	widget.SetKeyboardChar("f", widget.filterByTag, "Filter items by tag")
 	widget.SetKeyboardChar("n", widget.newItem, "Create new item")
 	widget.SetKeyboardChar("o", widget.openFile, "Open file")
+	widget.SetKeyboardChar("s", widget.cycleSort, "Sort items by file order, due date or priority")
 
 	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
 	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
@@ -27,14 +28,32 @@ func (widget *Widget) initializeKeyboardControls() {
 
 }
 
+// cycleSort switches to the next order the items can be sorted in
+func (widget *Widget) cycleSort() {
+	next := 0
+	for idx, order := range sortOrders {
+		if order == widget.sortBy {
+			next = (idx + 1) % len(sortOrders)
+		}
+	}
+
+	widget.sortBy = sortOrders[next]
+	widget.display()
+}
+
 func (widget *Widget) deleteSelected() {
 
 	if !widget.isItemSelected() {
 		return
 	}
 
-	widget.list.Delete(widget.Selected)
-	widget.ScrollableWidget.SetItemCount(len(widget.list.Items))
+	idx, ok := widget.list.IndexByItem(widget.SelectedItem())
+	if !ok {
+		return
+	}
+
+	widget.list.Delete(idx)
+	widget.ScrollableWidget.SetItemCount(len(widget.visible) - 1)
 	widget.Prev()
 	widget.persist()
 	widget.display()
@@ -46,20 +65,61 @@ func (widget *Widget) demoteSelected() {
 	}
 
 	j := widget.Selected + 1
-	if j >= len(widget.list.Items) {
+	if j >= len(widget.visible) {
 		j = 0
 	}
 
-	widget.list.Swap(widget.Selected, j)
-	widget.Selected = j
+	widget.moveSelected(j)
+}
+
+// filterByTag lists the items' tags, and displays only the items tagged with the one
+// that is chosen
+func (widget *Widget) filterByTag() {
+	tags := widget.list.Tags()
+	if len(tags) == 0 {
+		widget.ShowMessage(" None of the items have #tags")
+		return
+	}
+
+	choices := []string{"All items"}
+	for _, tag := range tags {
+		choices = append(choices, "#"+tag)
+	}
+
+	widget.ShowList("Filter by tag", choices, func(idx int) {
+		widget.tagFilter = strings.TrimPrefix(choices[idx], "#")
+		if idx == 0 {
+			widget.tagFilter = ""
+		}
+
+		widget.Selected = -1
+		widget.display()
+	})
+}
+
+// moveSelected swaps the selected item with the item displayed at idx. Items can only be
+// moved while they are in file order, as that is the order that moving changes
+func (widget *Widget) moveSelected(idx int) {
+	if widget.sortBy != sortManually {
+		return
+	}
+
+	i, iOk := widget.list.IndexByItem(widget.SelectedItem())
+	j, jOk := widget.list.IndexByItem(widget.visible[idx])
+	if !iOk || !jOk {
+		return
+	}
+
+	widget.list.Swap(i, j)
+	widget.visible[widget.Selected], widget.visible[idx] = widget.visible[idx], widget.visible[widget.Selected]
+	widget.Selected = idx
 
 	widget.persist()
 	widget.display()
 }
 
 func (widget *Widget) openFile() {
-	confDir, _ := cfg.WtfConfigDir()
-	utils.OpenFile(fmt.Sprintf("%s/%s", confDir, widget.filePath))
+	utils.OpenFile(widget.fullPath())
 }
 
 func (widget *Widget) promoteSelected() {
@@ -69,13 +129,10 @@ func (widget *Widget) promoteSelected() {
 
 	k := widget.Selected - 1
 	if k < 0 {
-		k = len(widget.list.Items) - 1
+		k = len(widget.visible) - 1
 	}
 
-	widget.list.Swap(widget.Selected, k)
-	widget.Selected = k
-	widget.persist()
-	widget.display()
+	widget.moveSelected(k)
 }
 
 func (widget *Widget) toggleChecked() {
@@ -0,0 +1,209 @@
+package todo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wtfutil/wtf/checklist"
+)
+
+// lineFormat reads and writes the task lines of a format that has one task per line
+type lineFormat interface {
+	// parseLines returns the tasks in the lines, keyed by the index of their line
+	parseLines(lines []string) map[int]*checklist.ChecklistItem
+
+	// formatLine returns the line for an item. original is the line the item was read
+	// from, or "" if it is a new item
+	formatLine(item *checklist.ChecklistItem, original string) string
+}
+
+// taskLine is a line that a task was read from or written to
+type taskLine struct {
+	index int
+	line  string
+	item  checklist.ChecklistItem
+}
+
+// lineFile is a taskFile for formats with one task per line, in which the tasks can be
+// mixed in with other lines, i.e.: the checklists in a Markdown file. Writing the list
+// back only touches the lines of the tasks that changed:
+//
+//    - a task that didn't change keeps its line exactly as it was
+//    - a deleted task's line is removed
+//    - moved tasks trade places with each other, so the other lines stay where they are
+//    - a new task goes before the task that follows it in the list, or after the last task
+type lineFile struct {
+	lineFormat lineFormat
+	lines      []string
+	newline    string
+	tasks      map[*checklist.ChecklistItem]taskLine
+	trailing   bool
+}
+
+func newLineFile(format lineFormat) *lineFile {
+	return &lineFile{
+		lineFormat: format,
+		newline:    "\n",
+		tasks:      map[*checklist.ChecklistItem]taskLine{},
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (file *lineFile) parse(data []byte) ([]*checklist.ChecklistItem, error) {
+	text := string(data)
+
+	file.newline = "\n"
+	if strings.Contains(text, "\r\n") {
+		file.newline = "\r\n"
+		text = strings.Replace(text, "\r\n", "\n", -1)
+	}
+
+	file.trailing = strings.HasSuffix(text, "\n")
+
+	file.lines = []string{}
+	if text != "" {
+		file.lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	}
+
+	parsed := file.lineFormat.parseLines(file.lines)
+
+	indexes := []int{}
+	for idx := range parsed {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	items := []*checklist.ChecklistItem{}
+	file.tasks = map[*checklist.ChecklistItem]taskLine{}
+
+	for _, idx := range indexes {
+		item := parsed[idx]
+		items = append(items, item)
+		file.tasks[item] = taskLine{index: idx, line: file.lines[idx], item: *item}
+	}
+
+	return items, nil
+}
+
+func (file *lineFile) format(items []*checklist.ChecklistItem) []byte {
+	// The lines of the tasks that are still in the list are handed out to them in list
+	// order, so tasks that were moved swap lines
+	kept := []*checklist.ChecklistItem{}
+	slots := []int{}
+
+	for _, item := range items {
+		if task, ok := file.tasks[item]; ok {
+			kept = append(kept, item)
+			slots = append(slots, task.index)
+		}
+	}
+	sort.Ints(slots)
+
+	slotItems := map[int]*checklist.ChecklistItem{}
+	for idx, item := range kept {
+		slotItems[slots[idx]] = item
+	}
+
+	// New tasks go before the line of the next task in the list that already has one
+	insertBefore := map[int][]*checklist.ChecklistItem{}
+	appended := []*checklist.ChecklistItem{}
+	keptIdx := 0
+
+	for _, item := range items {
+		if _, ok := file.tasks[item]; ok {
+			keptIdx++
+			continue
+		}
+
+		if keptIdx < len(kept) {
+			insertBefore[slots[keptIdx]] = append(insertBefore[slots[keptIdx]], item)
+		} else {
+			appended = append(appended, item)
+		}
+	}
+
+	// Tasks that come after all of the existing ones go after the last task line,
+	// or at the end of the file if it has none
+	appendAfter := -1
+	taskLines := map[int]bool{}
+
+	for _, task := range file.tasks {
+		taskLines[task.index] = true
+
+		if task.index > appendAfter {
+			appendAfter = task.index
+		}
+	}
+
+	if len(file.tasks) == 0 {
+		appendAfter = len(file.lines) - 1
+	}
+
+	lines := []string{}
+	tasks := map[*checklist.ChecklistItem]taskLine{}
+
+	emit := func(item *checklist.ChecklistItem) {
+		line := file.lineFor(item)
+		tasks[item] = taskLine{index: len(lines), line: line, item: *item}
+		lines = append(lines, line)
+	}
+
+	if appendAfter < 0 {
+		for _, item := range appended {
+			emit(item)
+		}
+	}
+
+	for idx, line := range file.lines {
+		for _, item := range insertBefore[idx] {
+			emit(item)
+		}
+
+		if item, ok := slotItems[idx]; ok {
+			emit(item)
+		} else if !taskLines[idx] {
+			lines = append(lines, line)
+		}
+
+		if idx == appendAfter {
+			for _, item := range appended {
+				emit(item)
+			}
+		}
+	}
+
+	// A file that was empty gets a final newline, others keep the ending they had
+	file.trailing = file.trailing || len(file.lines) == 0
+	file.lines = lines
+	file.tasks = tasks
+
+	if len(lines) == 0 {
+		return []byte{}
+	}
+
+	text := strings.Join(lines, file.newline)
+	if file.trailing {
+		text += file.newline
+	}
+
+	return []byte(text)
+}
+
+// lineFor returns the line for an item: the line it was read from if it hasn't changed
+// since, or a new line
+func (file *lineFile) lineFor(item *checklist.ChecklistItem) string {
+	task, ok := file.tasks[item]
+	if !ok {
+		return file.lineFormat.formatLine(item, "")
+	}
+
+	if item.Checked == task.item.Checked &&
+		item.Text == task.item.Text &&
+		item.Due == task.item.Due &&
+		item.Priority == task.item.Priority {
+		return task.line
+	}
+
+	return file.lineFormat.formatLine(item, task.line)
+}
@@ -0,0 +1,74 @@
+package todo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/wtfutil/wtf/checklist"
+)
+
+// markdownTaskPattern matches a GitHub-flavored Markdown task list item, i.e.:
+//
+//    - [ ] Write the docs
+//      * [x] Fix the build
+//    1. [ ] Release
+var markdownTaskPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+)\[([ xX])\]\s+(.*)$`)
+
+// markdownLines is the task lists in a Markdown file, i.e.: a project's README. Every
+// other line, and anything in a fenced code block, is left as it is. Due dates and
+// priorities are written into the task's text, as they are when the task is edited
+type markdownLines struct{}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (format *markdownLines) parseLines(lines []string) map[int]*checklist.ChecklistItem {
+	items := map[int]*checklist.ChecklistItem{}
+	fence := ""
+
+	for idx, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		match := markdownTaskPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		item := checklist.NewChecklistItem(match[2] != " ", "", "", "")
+		item.SetText(match[3])
+
+		items[idx] = item
+	}
+
+	return items
+}
+
+func (format *markdownLines) formatLine(item *checklist.ChecklistItem, original string) string {
+	prefix := "- "
+	mark := " "
+
+	match := markdownTaskPattern.FindStringSubmatch(original)
+	if match != nil {
+		prefix = match[1]
+	}
+
+	if item.Checked {
+		mark = "x"
+		if match != nil && match[2] == "X" {
+			mark = "X"
+		}
+	}
+
+	return prefix + "[" + mark + "] " + item.EditableText()
+}
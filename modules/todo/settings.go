@@ -14,9 +14,12 @@ const (
 type Settings struct {
 	common *cfg.Common
 
-	filePath  string
-	checked   string
-	unchecked string
+	filePath     string `yaml:"filename"`
+	format       string `help:"The format of the file: yaml, todotxt or markdown. By default it is picked by the file's extension: .txt is todotxt, .md is markdown and anything else is yaml." optional:"true"`
+	checked      string `yaml:"checkedIcon"`
+	unchecked    string `yaml:"uncheckedIcon"`
+	overdueColor string `help:"The color of items that are past their due date" optional:"true"`
+	sortBy       string `help:"How to sort the items: by their order in the file, by due date or by priority." values:"manual, due or priority" optional:"true"`
 }
 
 // NewSettingsFromYAML creates a new settings instance from a YAML config block
@@ -26,9 +29,12 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common: common,
 
-		filePath:  ymlConfig.UString("filename"),
-		checked:   ymlConfig.UString("checkedIcon", common.Checkbox.Checked),
-		unchecked: ymlConfig.UString("uncheckedIcon", common.Checkbox.Unchecked),
+		filePath:     ymlConfig.UString("filename"),
+		format:       ymlConfig.UString("format"),
+		checked:      ymlConfig.UString("checkedIcon", common.Checkbox.Checked),
+		unchecked:    ymlConfig.UString("uncheckedIcon", common.Checkbox.Unchecked),
+		overdueColor: ymlConfig.UString("overdueColor", "red"),
+		sortBy:       ymlConfig.UString("sortBy", sortManually),
 	}
 
 	return &settings
@@ -0,0 +1,49 @@
+package todo
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/checklist"
+)
+
+// The formats the todo list can be stored in
+const (
+	formatMarkdown = "markdown"
+	formatTodoTxt  = "todotxt"
+	formatYAML     = "yaml"
+)
+
+// taskFile reads a todo list from a file's contents and writes it back in the same
+// format. Implementations can remember what they read, so that writing the list back
+// changes no more of the file than the list did
+type taskFile interface {
+	// parse returns the items in the contents of a file
+	parse(data []byte) ([]*checklist.ChecklistItem, error)
+
+	// format returns the contents of the file with the items written into it
+	format(items []*checklist.ChecklistItem) []byte
+}
+
+// newTaskFile returns the taskFile for the format. Without a format, it is picked by the
+// file's extension: .txt files are todo.txt, .md files are Markdown, anything else is YAML
+func newTaskFile(format, path string) taskFile {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".txt":
+			format = formatTodoTxt
+		case ".md", ".markdown":
+			format = formatMarkdown
+		}
+	}
+
+	switch format {
+	case formatTodoTxt:
+		return newLineFile(&todoTxtLines{now: time.Now})
+	case formatMarkdown:
+		return newLineFile(&markdownLines{})
+	default:
+		return &yamlFile{}
+	}
+}
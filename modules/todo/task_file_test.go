@@ -0,0 +1,184 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/checklist"
+)
+
+const readme = `# Project
+
+Some intro text.
+
+## Tasks
+
+- [ ] Write the docs #docs
+- [x] Fix the build !1
+  * [X] Fix the linter due:2020-08-01
+
+` + "```" + `
+- [ ] not a task, it's in a code block
+` + "```" + `
+
+1. [ ] Release
+Trailing text
+`
+
+const todoTxt = `(A) 2020-07-30 Call the bank @phone +money due:2020-08-01
+x 2020-08-02 2020-07-29 Pay rent +home pri:B
+
+(K) Water the plants   @home
+`
+
+func testNow() time.Time {
+	return time.Date(2020, 8, 3, 9, 0, 0, 0, time.Local)
+}
+
+func itemTexts(items []*checklist.ChecklistItem) []string {
+	texts := []string{}
+	for _, item := range items {
+		texts = append(texts, item.Text)
+	}
+
+	return texts
+}
+
+func Test_newTaskFile(t *testing.T) {
+	assert.IsType(t, &yamlFile{}, newTaskFile("", "todo.yml"))
+	assert.IsType(t, &markdownLines{}, newTaskFile("", "/src/README.md").(*lineFile).lineFormat)
+	assert.IsType(t, &todoTxtLines{}, newTaskFile("", "~/todo.TXT").(*lineFile).lineFormat)
+	assert.IsType(t, &todoTxtLines{}, newTaskFile(formatTodoTxt, "tasks").(*lineFile).lineFormat)
+	assert.IsType(t, &yamlFile{}, newTaskFile(formatYAML, "todo.txt"))
+}
+
+func Test_Markdown_Parse(t *testing.T) {
+	items, err := newLineFile(&markdownLines{}).parse([]byte(readme))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Write the docs #docs", "Fix the build", "Fix the linter", "Release"}, itemTexts(items))
+	assert.Equal(t, []bool{false, true, true, false}, []bool{items[0].Checked, items[1].Checked, items[2].Checked, items[3].Checked})
+	assert.Equal(t, 1, items[1].Priority)
+	assert.Equal(t, "2020-08-01", items[2].Due)
+}
+
+func Test_Markdown_Unchanged(t *testing.T) {
+	file := newLineFile(&markdownLines{})
+	items, _ := file.parse([]byte(readme))
+
+	assert.Equal(t, readme, string(file.format(items)))
+}
+
+func Test_Markdown_Edits(t *testing.T) {
+	file := newLineFile(&markdownLines{})
+	items, _ := file.parse([]byte(readme))
+
+	items[0].Toggle()
+	items[2].Toggle()
+	items[3].SetText("Release v1.0 !2")
+
+	// Move "Fix the linter" to the top, delete "Fix the build" and add two new tasks
+	newFirst := checklist.NewChecklistItem(false, "New first", "", "")
+	newLast := checklist.NewChecklistItem(false, "New last", "", "")
+	items = []*checklist.ChecklistItem{newFirst, items[2], items[0], items[3], newLast}
+
+	expected := `# Project
+
+Some intro text.
+
+## Tasks
+
+- [ ] New first
+  * [ ] Fix the linter due:2020-08-01
+- [x] Write the docs #docs
+
+` + "```" + `
+- [ ] not a task, it's in a code block
+` + "```" + `
+
+1. [ ] Release v1.0 !2
+- [ ] New last
+Trailing text
+`
+	assert.Equal(t, expected, string(file.format(items)))
+
+	// What was written is what the next write starts from
+	items[0].Toggle()
+	assert.Contains(t, string(file.format(items)), "\n- [x] New first\n  * [ ] Fix the linter")
+}
+
+func Test_Markdown_NoTasks(t *testing.T) {
+	file := newLineFile(&markdownLines{})
+	items, _ := file.parse([]byte("# Notes\r\n\r\nNothing to do"))
+	assert.Empty(t, items)
+
+	items = append(items, checklist.NewChecklistItem(false, "Something to do", "", ""))
+	assert.Equal(t, "# Notes\r\n\r\nNothing to do\r\n- [ ] Something to do", string(file.format(items)))
+
+	empty := newLineFile(&markdownLines{})
+	_, _ = empty.parse([]byte{})
+	assert.Equal(t, "- [ ] First\n", string(empty.format([]*checklist.ChecklistItem{checklist.NewChecklistItem(false, "First", "", "")})))
+}
+
+func Test_TodoTxt_Parse(t *testing.T) {
+	items, err := newLineFile(&todoTxtLines{now: testNow}).parse([]byte(todoTxt))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Call the bank @phone +money", "Pay rent +home", "Water the plants @home"}, itemTexts(items))
+
+	assert.False(t, items[0].Checked)
+	assert.Equal(t, 1, items[0].Priority)
+	assert.Equal(t, "2020-08-01", items[0].Due)
+	assert.Equal(t, []string{"phone", "money"}, items[0].Tags)
+	assert.True(t, items[0].HasTag("money"))
+
+	assert.True(t, items[1].Checked)
+	assert.Equal(t, 2, items[1].Priority)
+
+	assert.Equal(t, 9, items[2].Priority)
+}
+
+func Test_TodoTxt_Unchanged(t *testing.T) {
+	file := newLineFile(&todoTxtLines{now: testNow})
+	items, _ := file.parse([]byte(todoTxt))
+
+	assert.Equal(t, todoTxt, string(file.format(items)))
+}
+
+func Test_TodoTxt_Edits(t *testing.T) {
+	file := newLineFile(&todoTxtLines{now: testNow})
+	items, _ := file.parse([]byte(todoTxt))
+
+	items[0].Toggle()
+	items[1].Toggle()
+	// The edit modal starts from the editable text, so the priority is kept as !9
+	items[2].SetText(strings.Replace(items[2].EditableText(), "@home", "@garden due:2020-08-05", 1))
+
+	list := checklist.NewChecklist("x", " ")
+	list.Items = items
+	list.Add(false, "Buy milk !3 +errands")
+
+	expected := `(C) 2020-08-03 Buy milk +errands
+x 2020-08-03 2020-07-30 Call the bank @phone +money pri:A due:2020-08-01
+(B) 2020-07-29 Pay rent +home
+
+(K) Water the plants @garden due:2020-08-05
+`
+	assert.Equal(t, expected, string(file.format(list.Items)))
+	assert.Equal(t, []string{"garden"}, items[2].Tags)
+}
+
+func Test_yamlFile(t *testing.T) {
+	data := "items:\n- checked: false\n  checkedicon: x\n  text: Buy milk\n  uncheckedicon: ' '\n"
+	file := &yamlFile{}
+
+	items, err := file.parse([]byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Buy milk"}, itemTexts(items))
+	assert.Equal(t, data, string(file.format(items)))
+
+	_, err = file.parse([]byte("items: [not: [valid"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package todo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/checklist"
+)
+
+var (
+	todoTxtDatePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	todoTxtPriorityPattern = regexp.MustCompile(`^\(([A-Z])\)$`)
+)
+
+// todoTxtTask is a line of a todo.txt file, split into its parts:
+//
+//    x 2020-08-02 2020-07-30 Pay rent +home @phone due:2020-08-01 pri:A
+//    (B) 2020-07-30 Call the bank @phone
+type todoTxtTask struct {
+	completed      bool
+	completionDate string
+	creationDate   string
+	due            string
+	priority       string
+	words          []string
+}
+
+// todoTxtLines is the todo.txt format, https://github.com/todotxt/todo.txt, in which every
+// line that isn't blank is a task. Priorities (A) to (I) are the checklist's priorities 1
+// to 9, and lower ones are read as 9. +projects and @contexts are the items' tags
+type todoTxtLines struct {
+	now func() time.Time
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (format *todoTxtLines) parseLines(lines []string) map[int]*checklist.ChecklistItem {
+	items := map[int]*checklist.ChecklistItem{}
+
+	for idx, line := range lines {
+		task, ok := parseTodoTxtLine(line)
+		if !ok {
+			continue
+		}
+
+		item := checklist.NewChecklistItem(task.completed, strings.Join(task.words, " "), "", "")
+		item.Due = task.due
+		item.Priority = todoTxtPriority(task.priority)
+		item.Tags = todoTxtTags(item.Text)
+
+		items[idx] = item
+	}
+
+	return items
+}
+
+func (format *todoTxtLines) formatLine(item *checklist.ChecklistItem, original string) string {
+	task, _ := parseTodoTxtLine(original)
+	today := format.now().Format(checklist.DueDateFormat)
+
+	// Keep the letter of a priority below (I), unless the priority was changed
+	letter := task.priority
+	if todoTxtPriority(letter) != item.Priority {
+		letter = ""
+		if item.Priority > 0 {
+			letter = string(rune('A' + item.Priority - 1))
+		}
+	}
+
+	parts := []string{}
+
+	if item.Checked {
+		completionDate := task.completionDate
+		if !task.completed || completionDate == "" {
+			completionDate = today
+		}
+
+		parts = append(parts, "x", completionDate)
+	} else if letter != "" {
+		parts = append(parts, fmt.Sprintf("(%s)", letter))
+	}
+
+	switch {
+	case task.creationDate != "":
+		parts = append(parts, task.creationDate)
+	case original == "":
+		parts = append(parts, today)
+	}
+
+	if item.Text != "" {
+		parts = append(parts, item.Text)
+	}
+
+	// Completed tasks keep their priority as a tag, as the (A) form is for open tasks
+	if item.Checked && letter != "" {
+		parts = append(parts, "pri:"+letter)
+	}
+
+	if item.Due != "" {
+		parts = append(parts, "due:"+item.Due)
+	}
+
+	item.Tags = todoTxtTags(item.Text)
+
+	return strings.Join(parts, " ")
+}
+
+// parseTodoTxtLine splits a todo.txt line into its parts. It returns false for blank lines
+func parseTodoTxtLine(line string) (todoTxtTask, bool) {
+	task := todoTxtTask{words: []string{}}
+
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return task, false
+	}
+
+	if words[0] == "x" {
+		task.completed = true
+		words = words[1:]
+
+		if len(words) > 0 && todoTxtDatePattern.MatchString(words[0]) {
+			task.completionDate = words[0]
+			words = words[1:]
+		}
+	} else if match := todoTxtPriorityPattern.FindStringSubmatch(words[0]); match != nil {
+		task.priority = match[1]
+		words = words[1:]
+	}
+
+	if len(words) > 0 && todoTxtDatePattern.MatchString(words[0]) {
+		task.creationDate = words[0]
+		words = words[1:]
+	}
+
+	for _, word := range words {
+		switch {
+		case strings.HasPrefix(word, "due:") && todoTxtDatePattern.MatchString(word[len("due:"):]):
+			task.due = word[len("due:"):]
+		case strings.HasPrefix(word, "pri:") && len(word) == len("pri:A") && word[4] >= 'A' && word[4] <= 'Z':
+			task.priority = word[len("pri:"):]
+		default:
+			task.words = append(task.words, word)
+		}
+	}
+
+	return task, true
+}
+
+// todoTxtPriority returns the checklist priority of a todo.txt priority letter
+func todoTxtPriority(letter string) int {
+	if letter == "" {
+		return 0
+	}
+
+	priority := int(letter[0]-'A') + 1
+	if priority > 9 {
+		priority = 9
+	}
+
+	return priority
+}
+
+// todoTxtTags returns the +projects and @contexts in a task's text
+func todoTxtTags(text string) []string {
+	tags := []string{}
+
+	for _, word := range strings.Fields(text) {
+		if len(word) > 1 && (word[0] == '+' || word[0] == '@') {
+			tags = append(tags, word[1:])
+		}
+	}
+
+	return tags
+}
@@ -1,17 +1,23 @@
 package todo
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell"
+	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/checklist"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/view"
 	"github.com/wtfutil/wtf/wtf"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -20,16 +26,35 @@ const (
 	offscreen   = -1000
 )
 
+// watchInterval is how often the file is checked for changes made outside of WTF
+const watchInterval = time.Second
+
+// The orders the items can be sorted in
+const (
+	sortManually   = "manual"
+	sortByDue      = "due"
+	sortByPriority = "priority"
+)
+
+// sortOrders are the orders the sort key cycles through
+var sortOrders = []string{sortManually, sortByDue, sortByPriority}
+
 // A Widget represents a Todo widget
 type Widget struct {
 	view.KeyboardWidget
 	view.ScrollableWidget
 
-	app      *tview.Application
-	filePath string
-	list     checklist.Checklist
-	pages    *tview.Pages
-	settings *Settings
+	app       *tview.Application
+	fileData  []byte
+	filePath  string
+	list      checklist.Checklist
+	pages     *tview.Pages
+	settings  *Settings
+	sortBy    string
+	tagFilter string
+	taskFile  taskFile
+	visible   []*checklist.ChecklistItem
+	watcher   *watcher.Watcher
 }
 
 // NewWidget creates a new instance of a widget
@@ -43,9 +68,14 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		filePath: settings.filePath,
 		list:     checklist.NewChecklist(settings.common.Sigils.Checkbox.Checked, settings.common.Sigils.Checkbox.Unchecked),
 		pages:    pages,
+		sortBy:   settings.sortBy,
+		watcher:  watcher.New(),
 	}
 
+	widget.taskFile = newTaskFile(settings.format, widget.fullPath())
+
 	widget.init()
+	go widget.watchForFileChanges()
 
 	widget.initializeKeyboardControls()
 	widget.View.SetInputCapture(widget.InputCapture)
@@ -70,7 +100,7 @@ func (widget *Widget) HelpText() string {
 func (widget *Widget) SelectedItem() *checklist.ChecklistItem {
 	var selectedItem *checklist.ChecklistItem
 	if widget.isItemSelected() {
-		selectedItem = widget.list.Items[widget.Selected]
+		selectedItem = widget.visible[widget.Selected]
 	}
 
 	return selectedItem
@@ -86,33 +116,58 @@ func (widget *Widget) SetList(list checklist.Checklist) {
 	widget.list = list
 }
 
+// Stop stops watching the todo file, as well as stopping the widget
+func (widget *Widget) Stop() {
+	widget.watcher.Close()
+	widget.ScrollableWidget.Stop()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) init() {
+	if !widget.isInConfigDir() {
+		return
+	}
+
 	_, err := cfg.CreateFile(widget.filePath)
 	if err != nil {
 		return
 	}
 }
 
+// fullPath returns the path of the todo file. Relative paths are in the config directory
+func (widget *Widget) fullPath() string {
+	if !widget.isInConfigDir() {
+		filePath, _ := utils.ExpandHomeDir(widget.filePath)
+		return filePath
+	}
+
+	confDir, _ := cfg.WtfConfigDir()
+	return filepath.Join(confDir, widget.filePath)
+}
+
+// isInConfigDir returns true if the todo file's path is relative to the config directory,
+// rather than absolute or in the home directory
+func (widget *Widget) isInConfigDir() bool {
+	return !filepath.IsAbs(widget.filePath) && !strings.HasPrefix(widget.filePath, "~")
+}
+
 // isItemSelected returns whether any item of the todo is selected or not
 func (widget *Widget) isItemSelected() bool {
-	return widget.Selected >= 0 && widget.Selected < len(widget.list.Items)
+	return widget.Selected >= 0 && widget.Selected < len(widget.visible)
 }
 
-// Loads the todo list from3 Yaml file
+// load reads the todo list from the file, in the file's format
 func (widget *Widget) load() {
-	confDir, _ := cfg.WtfConfigDir()
-	filePath := fmt.Sprintf("%s/%s", confDir, widget.filePath)
+	fileData, _ := utils.ReadFileBytes(widget.fullPath())
 
-	fileData, _ := utils.ReadFileBytes(filePath)
-
-	err := yaml.Unmarshal(fileData, &widget.list)
+	items, err := widget.taskFile.parse(fileData)
 	if err != nil {
 		return
 	}
 
-	widget.ScrollableWidget.SetItemCount(len(widget.list.Items))
+	widget.fileData = fileData
+	widget.list.Items = items
 	widget.setItemChecks()
 }
 
@@ -123,7 +178,6 @@ func (widget *Widget) newItem() {
 		text := form.GetFormItem(0).(*tview.InputField).GetText()
 
 		widget.list.Add(false, text)
-		widget.SetItemCount(len(widget.list.Items))
 		widget.persist()
 		widget.pages.RemovePage("modal")
 		widget.app.SetFocus(widget.View)
@@ -138,18 +192,17 @@ func (widget *Widget) newItem() {
 	})
 }
 
-// persist writes the todo list to Yaml file
+// persist writes the todo list to the file, in the file's format
 func (widget *Widget) persist() {
-	confDir, _ := cfg.WtfConfigDir()
-	filePath := fmt.Sprintf("%s/%s", confDir, widget.filePath)
+	fileData := widget.taskFile.format(widget.list.Items)
 
-	fileData, _ := yaml.Marshal(&widget.list)
-
-	err := ioutil.WriteFile(filePath, fileData, 0644)
+	err := ioutil.WriteFile(widget.fullPath(), fileData, 0644)
 
 	if err != nil {
 		panic(err)
 	}
+
+	widget.fileData = fileData
 }
 
 // setItemChecks rolls through the checklist and ensures that all checklist
@@ -167,7 +220,7 @@ func (widget *Widget) updateSelected() {
 		return
 	}
 
-	form := widget.modalForm("Edit:", widget.SelectedItem().Text)
+	form := widget.modalForm("Edit:", widget.SelectedItem().EditableText())
 
 	saveFctn := func() {
 		text := form.GetFormItem(0).(*tview.InputField).GetText()
@@ -187,14 +240,52 @@ func (widget *Widget) updateSelected() {
 	})
 }
 
-// updateSelectedItem update the text of the selected item.
+// updateSelectedItem update the text of the selected item, and its due date and priority
+// if the text has them
 func (widget *Widget) updateSelectedItem(text string) {
 	selectedItem := widget.SelectedItem()
 	if selectedItem == nil {
 		return
 	}
 
-	selectedItem.Text = text
+	selectedItem.SetText(text)
+}
+
+// watchForFileChanges reloads the todo list when the file is changed by something other
+// than this widget, i.e.: an editor
+func (widget *Widget) watchForFileChanges() {
+	watch := widget.watcher
+	watch.FilterOps(watcher.Write, watcher.Create)
+
+	if err := watch.Add(widget.fullPath()); err != nil {
+		if !os.IsNotExist(err) {
+			logger.Log(fmt.Sprintf("Watching %s failed: %s", widget.filePath, err.Error()))
+		}
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case <-watch.Event:
+				widget.app.QueueUpdate(func() {
+					// The widget's own writes change nothing it doesn't already know about
+					fileData, _ := utils.ReadFileBytes(widget.fullPath())
+					if !bytes.Equal(fileData, widget.fileData) {
+						widget.Refresh()
+					}
+				})
+			case err := <-watch.Error:
+				logger.Log(fmt.Sprintf("Watching %s failed: %s", widget.filePath, err.Error()))
+			case <-watch.Closed:
+				return
+			}
+		}
+	}()
+
+	if err := watch.Start(watchInterval); err != nil {
+		logger.Log(fmt.Sprintf("Watching %s failed: %s", widget.filePath, err.Error()))
+	}
 }
 
 /* -------------------- Modal Form -------------------- */
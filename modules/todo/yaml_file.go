@@ -0,0 +1,26 @@
+package todo
+
+import (
+	"github.com/wtfutil/wtf/checklist"
+	"gopkg.in/yaml.v2"
+)
+
+// yamlFile is the todo widget's own format: the checklist, written out as YAML
+type yamlFile struct{}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (file *yamlFile) parse(data []byte) ([]*checklist.ChecklistItem, error) {
+	list := checklist.Checklist{}
+
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (file *yamlFile) format(items []*checklist.ChecklistItem) []byte {
+	data, _ := yaml.Marshal(&checklist.Checklist{Items: items})
+	return data
+}
@@ -19,7 +19,7 @@ type Settings struct {
 	consumerKey    string
 	consumerSecret string
 	count          int
-	screenNames    []interface{}
+	screenNames    []interface{} `yaml:"screenName"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
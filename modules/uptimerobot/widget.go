@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/notify"
 	"github.com/wtfutil/wtf/view"
 )
 
@@ -18,6 +19,7 @@ type Widget struct {
 	view.ScrollableWidget
 
 	monitors []Monitor
+	notifier *notify.Notifier
 	settings *Settings
 	err      error
 }
@@ -27,6 +29,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		KeyboardWidget:   view.NewKeyboardWidget(app, pages, settings.common),
 		ScrollableWidget: view.NewScrollableWidget(app, settings.common),
 
+		notifier: notify.NewNotifier(settings.common),
 		settings: settings,
 	}
 
@@ -67,6 +70,10 @@ func (widget *Widget) Refresh() {
 	widget.err = err
 	widget.SetItemCount(len(monitors))
 
+	if err == nil {
+		widget.notifier.Publish(widget.monitorEvents(monitors)...)
+	}
+
 	widget.Render()
 }
 
@@ -127,6 +134,31 @@ func (widget *Widget) contentFrom(monitors []Monitor) string {
 	return str
 }
 
+// monitorEvents returns an event for each monitor that is up or down. Paused monitors,
+// and monitors that have not been checked yet, have no event
+func (widget *Widget) monitorEvents(monitors []Monitor) []notify.Event {
+	events := []notify.Event{}
+
+	for _, monitor := range monitors {
+		event := notify.Event{Key: monitor.Name, Message: monitor.Name}
+
+		switch monitor.State {
+		case 2:
+			event.Type = notify.MonitorUp
+			event.Title = fmt.Sprintf("%s: monitor up", widget.CommonSettings().Title)
+		case 8, 9:
+			event.Type = notify.MonitorDown
+			event.Title = fmt.Sprintf("%s: monitor down", widget.CommonSettings().Title)
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
 func formatUptimes(str string) string {
 	splits := strings.Split(str, "-")
 	str = ""
@@ -1,53 +1,133 @@
 package victorops
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/wtfutil/wtf/logger"
 )
 
+const apiURL = "https://api.victorops.com/api-public/v1"
+
+// Client is a VictorOps API client, shared by this module and the oncall module
+type Client struct {
+	// APIURL is the address of the VictorOps public API
+	APIURL string
+
+	apiID      string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// Incident is a VictorOps incident
+type Incident struct {
+	IncidentNumber    string    `json:"incidentNumber"`
+	CurrentPhase      string    `json:"currentPhase"`
+	EntityDisplayName string    `json:"entityDisplayName"`
+	EntityID          string    `json:"entityId"`
+	Service           string    `json:"service"`
+	StartTime         time.Time `json:"startTime"`
+}
+
+// NewClient creates a client for the API ID and key
+func NewClient(apiID, apiKey string) *Client {
+	return &Client{
+		APIURL: apiURL,
+
+		apiID:      apiID,
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+	}
+}
+
 // Fetch gets the current oncall users
 func Fetch(apiID, apiKey string) ([]OnCallTeam, error) {
-	scheduleURL := "https://api.victorops.com/api-public/v1/oncall/current"
-	response, err := victorOpsRequest(scheduleURL, apiID, apiKey)
+	response, err := NewClient(apiID, apiKey).OnCallNow()
+	if err != nil {
+		logger.Log(fmt.Sprintf("Failed to fetch on-call users from VictorOps. ERROR: %s", err))
+		return nil, err
+	}
 
-	return response, err
+	return parseTeams(response), nil
 }
 
-/* ---------------- Unexported Functions ---------------- */
+/* -------------------- Exported Functions -------------------- */
 
-func victorOpsRequest(url string, apiID string, apiKey string) ([]OnCallTeam, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		logger.Log(fmt.Sprintf("Failed to initialize sessions to VictorOps. ERROR: %s", err))
+// OnCallNow returns who is on call now for each escalation policy of each team
+func (client *Client) OnCallNow() (*OnCallResponse, error) {
+	response := &OnCallResponse{}
+	if err := client.request("GET", "/oncall/current", nil, response); err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-VO-Api-Id", apiID)
-	req.Header.Set("X-VO-Api-Key", apiKey)
-	client := &http.Client{}
+	return response, nil
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		logger.Log(fmt.Sprintf("Failed to make request to VictorOps. ERROR: %s", err))
+// Incidents returns the incidents, including the ones that have been resolved
+func (client *Client) Incidents() ([]Incident, error) {
+	response := struct {
+		Incidents []Incident `json:"incidents"`
+	}{}
+
+	if err := client.request("GET", "/incidents", nil, &response); err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("%s", resp.Status)
+
+	return response.Incidents, nil
+}
+
+// ManageIncidents acknowledges or resolves incidents as the user. action is ack or resolve
+func (client *Client) ManageIncidents(action, userName string, incidentNames []string) error {
+	body := map[string]interface{}{
+		"userName":      userName,
+		"incidentNames": incidentNames,
+	}
+
+	return client.request("PATCH", "/incidents/"+action, body, nil)
+}
+
+/* ---------------- Unexported Functions ---------------- */
+
+// request makes a request to the VictorOps API, sending body as JSON if it isn't nil and
+// decoding the response into result if it isn't nil
+func (client *Client) request(method, path string, body interface{}, result interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(client.APIURL, "/")+path, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-VO-Api-Id", client.apiID)
+	req.Header.Set("X-VO-Api-Key", client.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	response := &OnCallResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		logger.Log(fmt.Sprintf("Failed to decode JSON response. ERROR: %s", err))
-		return nil, err
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("VictorOps API returned %s", resp.Status)
+	}
+
+	if result == nil {
+		return nil
 	}
 
-	teams := parseTeams(response)
-	return teams, nil
+	return json.NewDecoder(resp.Body).Decode(result)
 }
 
 func parseTeams(input *OnCallResponse) []OnCallTeam {
@@ -12,7 +12,7 @@ const (
 
 type Settings struct {
 	common *cfg.Common
-	city   string
+	city   string `yaml:"locationid"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
@@ -21,7 +21,7 @@ type Settings struct {
 	common *cfg.Common
 
 	apiKey   string
-	cityIDs  []interface{}
+	cityIDs  []interface{} `yaml:"cityids"`
 	language string
 	tempUnit string
 	useEmoji bool
@@ -0,0 +1,32 @@
+package notify
+
+// EventType is a kind of state change a module reports
+type EventType string
+
+// The events modules publish. A module's notify.events setting lists these by name
+const (
+	BuildFailed          EventType = "buildFailed"
+	BuildFixed           EventType = "buildFixed"
+	IncidentAcknowledged EventType = "incidentAcknowledged"
+	IncidentTriggered    EventType = "incidentTriggered"
+	MonitorDown          EventType = "monitorDown"
+	MonitorUp            EventType = "monitorUp"
+)
+
+// Event is a single state change, i.e.: a specific incident being triggered
+type Event struct {
+	Type EventType
+
+	// Key identifies the thing the event is about, i.e.: the incident's ID. Events with
+	// the same type and key are the same event, and are only sent once
+	Key string
+
+	Title   string
+	Message string
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (event Event) id() string {
+	return string(event.Type) + ":" + event.Key
+}
@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+)
+
+// terminal writes escape sequences to the terminal the app is displayed in. Until Init is
+// called there is no terminal, and the bell and OSC sinks send nothing
+var (
+	terminal      func(seq string)
+	terminalMutex = &sync.Mutex{}
+)
+
+// Notifier sends notifications for the events a module publishes. Modules publish every
+// event that describes their current state after each successful refresh, i.e.: an
+// incidentTriggered event for every triggered incident, and the notifier sends only the
+// ones that were not published by the previous refresh. The events published by the first
+// refresh describe the state WTF started in, so they are never sent
+type Notifier struct {
+	enabled map[EventType]bool
+	module  string
+	primed  bool
+	seen    map[string]bool
+	sinks   []Sink
+	mutex   sync.Mutex
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Init sets the function the bell and OSC sinks use to write to the terminal. The app
+// passes a function that writes between screen draws, so that the sequences are not
+// interleaved with drawing
+func Init(writeTerminal func(seq string)) {
+	terminalMutex.Lock()
+	defer terminalMutex.Unlock()
+
+	terminal = writeTerminal
+}
+
+// NewNotifier creates and returns a notifier for the module the settings belong to
+func NewNotifier(common *cfg.Common) *Notifier {
+	notifier := &Notifier{
+		enabled: map[EventType]bool{},
+		module:  common.Name,
+		seen:    map[string]bool{},
+	}
+
+	for _, event := range common.Notify.Events {
+		notifier.enabled[EventType(event)] = true
+	}
+
+	for _, name := range common.Notify.Sinks {
+		sink, err := newSink(name, common.Notify.Command, writeTerminal)
+		if err != nil {
+			logger.Log(fmt.Sprintf("%s notifications: %s", common.Name, err.Error()))
+			continue
+		}
+
+		notifier.sinks = append(notifier.sinks, sink)
+	}
+
+	return notifier
+}
+
+// Publish records the events that describe the module's current state, and sends the
+// ones that are new since the last time Publish was called
+func (notifier *Notifier) Publish(events ...Event) {
+	for _, event := range notifier.newEvents(events) {
+		for _, sink := range notifier.sinks {
+			go notifier.send(sink, event)
+		}
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// newEvents returns the events that were not published last time and that the module is
+// configured to send, and remembers the events for next time
+func (notifier *Notifier) newEvents(events []Event) []Event {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	seen := map[string]bool{}
+	fresh := []Event{}
+
+	for _, event := range events {
+		if seen[event.id()] {
+			continue
+		}
+		seen[event.id()] = true
+
+		if notifier.primed && !notifier.seen[event.id()] && notifier.enabled[event.Type] {
+			fresh = append(fresh, event)
+		}
+	}
+
+	notifier.primed = true
+	notifier.seen = seen
+
+	return fresh
+}
+
+func (notifier *Notifier) send(sink Sink, event Event) {
+	if err := sink.Send(notifier.module, event); err != nil {
+		logger.Log(fmt.Sprintf("%s notification failed: %s", notifier.module, err.Error()))
+	}
+}
+
+// writeTerminal writes the sequence using the function passed to Init, if there is one
+func writeTerminal(seq string) {
+	terminalMutex.Lock()
+	write := terminal
+	terminalMutex.Unlock()
+
+	if write != nil {
+		write(seq)
+	}
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+type fakeSink struct {
+	events []Event
+	mutex  sync.Mutex
+	wg     *sync.WaitGroup
+}
+
+func (sink *fakeSink) Send(module string, event Event) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	sink.events = append(sink.events, event)
+	sink.wg.Done()
+
+	return nil
+}
+
+func testNotifier(events ...string) (*Notifier, *fakeSink) {
+	common := &cfg.Common{
+		Module: cfg.Module{Name: "pagerduty"},
+		Notify: cfg.NotifySettings{Events: events},
+	}
+
+	notifier := NewNotifier(common)
+
+	sink := &fakeSink{wg: &sync.WaitGroup{}}
+	notifier.sinks = []Sink{sink}
+
+	return notifier, sink
+}
+
+func Test_Notifier_Publish(t *testing.T) {
+	notifier, sink := testNotifier("incidentTriggered")
+
+	first := Event{Type: IncidentTriggered, Key: "P1"}
+	second := Event{Type: IncidentTriggered, Key: "P2"}
+	acked := Event{Type: IncidentAcknowledged, Key: "P1"}
+
+	// The first refresh is the state WTF started in
+	notifier.Publish(first)
+
+	// P1 is still triggered, and P2 is new
+	sink.wg.Add(1)
+	notifier.Publish(first, second, second)
+	sink.wg.Wait()
+
+	// P1 is acknowledged, which is not an enabled event, and P2 is resolved
+	notifier.Publish(acked)
+
+	// P2 is triggered again
+	sink.wg.Add(1)
+	notifier.Publish(acked, second)
+	sink.wg.Wait()
+
+	assert.Equal(t, []Event{second, second}, sink.events)
+}
+
+func Test_Notifier_disabled(t *testing.T) {
+	notifier, sink := testNotifier()
+
+	notifier.Publish()
+	notifier.Publish(Event{Type: MonitorDown, Key: "api"})
+
+	assert.Empty(t, sink.events)
+}
+
+func Test_NewNotifier_sinks(t *testing.T) {
+	common := &cfg.Common{
+		Module: cfg.Module{Name: "jenkins"},
+		Notify: cfg.NotifySettings{
+			Events: []string{"buildFailed"},
+			Sinks:  []string{"bell", "osc9", "command", "pigeon"},
+		},
+	}
+
+	// The command sink is skipped without a command, and unknown sinks are skipped
+	notifier := NewNotifier(common)
+	assert.Equal(t, 2, len(notifier.sinks))
+	assert.Equal(t, map[EventType]bool{BuildFailed: true}, notifier.enabled)
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Sink is somewhere notifications are sent
+type Sink interface {
+	Send(module string, event Event) error
+}
+
+// bellSink rings the terminal bell
+type bellSink struct {
+	terminal func(seq string)
+}
+
+// commandSink runs a shell command with the event in its environment
+type commandSink struct {
+	command string
+}
+
+// oscSink writes an OSC 9 or OSC 777 desktop notification escape sequence to the terminal.
+// OSC 9 is supported by iTerm2, ConEmu and Windows Terminal, among others, and OSC 777 by
+// urxvt, foot and VTE-based terminals
+type oscSink struct {
+	code     int
+	terminal func(seq string)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Send rings the bell
+func (sink *bellSink) Send(module string, event Event) error {
+	sink.terminal("\a")
+	return nil
+}
+
+// Send runs the command. The command is run by the shell, so it can use the event's
+// environment variables in its arguments
+func (sink *commandSink) Send(module string, event Event) error {
+	cmd := exec.Command("sh", "-c", sink.command)
+	cmd.Env = append(
+		os.Environ(),
+		"WTF_NOTIFY_EVENT="+string(event.Type),
+		"WTF_NOTIFY_MODULE="+module,
+		"WTF_NOTIFY_TITLE="+event.Title,
+		"WTF_NOTIFY_MESSAGE="+event.Message,
+	)
+
+	return cmd.Run()
+}
+
+// Send writes the escape sequence
+func (sink *oscSink) Send(module string, event Event) error {
+	title := sanitizeOSC(event.Title)
+	message := sanitizeOSC(event.Message)
+
+	switch sink.code {
+	case 9:
+		sink.terminal(fmt.Sprintf("\x1b]9;%s: %s\a", title, message))
+	default:
+		sink.terminal(fmt.Sprintf("\x1b]777;notify;%s;%s\a", title, message))
+	}
+
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// newSink returns the sink with the given name, or an error if there is no such sink
+func newSink(name, command string, terminal func(seq string)) (Sink, error) {
+	switch name {
+	case "bell":
+		return &bellSink{terminal: terminal}, nil
+	case "command":
+		if command == "" {
+			return nil, fmt.Errorf("the command sink needs a notify.command")
+		}
+
+		return &commandSink{command: command}, nil
+	case "osc9":
+		return &oscSink{code: 9, terminal: terminal}, nil
+	case "osc777":
+		return &oscSink{code: 777, terminal: terminal}, nil
+	}
+
+	return nil, fmt.Errorf("unknown notification sink %q", name)
+}
+
+// sanitizeOSC removes the characters that would end the escape sequence early, or, for
+// OSC 777, be read as a field separator
+func sanitizeOSC(str string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ';':
+			return ','
+		case r < ' ' || r == 0x7f:
+			return ' '
+		}
+
+		return r
+	}, str)
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_oscSink(t *testing.T) {
+	written := []string{}
+	terminal := func(seq string) { written = append(written, seq) }
+
+	event := Event{Type: BuildFailed, Title: "Jenkins: deploy failed", Message: "http://ci;\nlog"}
+
+	assert.NoError(t, (&oscSink{code: 9, terminal: terminal}).Send("jenkins", event))
+	assert.NoError(t, (&oscSink{code: 777, terminal: terminal}).Send("jenkins", event))
+	assert.NoError(t, (&bellSink{terminal: terminal}).Send("jenkins", event))
+
+	assert.Equal(t,
+		[]string{
+			"\x1b]9;Jenkins: deploy failed: http://ci, log\a",
+			"\x1b]777;notify;Jenkins: deploy failed;http://ci, log\a",
+			"\a",
+		},
+		written,
+	)
+}
+
+func Test_commandSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "notification")
+	sink, err := newSink("command", `echo "$WTF_NOTIFY_MODULE $WTF_NOTIFY_EVENT $WTF_NOTIFY_TITLE: $WTF_NOTIFY_MESSAGE" > `+output, nil)
+	assert.NoError(t, err)
+
+	event := Event{Type: MonitorDown, Title: "UptimeRobot: monitor down", Message: "api; rm -rf /"}
+	assert.NoError(t, sink.Send("uptimerobot", event))
+
+	contents, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "uptimerobot monitorDown UptimeRobot: monitor down: api; rm -rf /\n", string(contents))
+}
+
+func Test_newSink(t *testing.T) {
+	_, err := newSink("command", "", nil)
+	assert.Error(t, err)
+
+	_, err = newSink("pigeon", "", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// fuzzyConsecutiveBonus is added for each matched character that follows another matched character
+	fuzzyConsecutiveBonus = 5
+
+	// fuzzyWordStartBonus is added for each matched character that starts a word
+	fuzzyWordStartBonus = 3
+)
+
+// FuzzyMatch returns TRUE if every character of the pattern appears in the text, in order
+// but not necessarily next to each other, ignoring case. It also returns a score for the
+// match: the more of the matched characters that are next to each other or start words,
+// the higher the score. An empty pattern matches everything with a score of zero
+//
+// Examples:
+//
+//    FuzzyMatch("git", "GitHub") => true, 16
+//    FuzzyMatch("rg", "GitHub") => false, 0
+//
+func FuzzyMatch(pattern, text string) (bool, int) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return true, 0
+	}
+
+	score := 0
+	matched := 0
+	lastMatch := -2
+
+	textRunes := []rune(strings.ToLower(text))
+	for idx, char := range textRunes {
+		if char != patternRunes[matched] {
+			continue
+		}
+
+		score++
+
+		if lastMatch == idx-1 {
+			score += fuzzyConsecutiveBonus
+		}
+
+		if idx == 0 || !unicode.IsLetter(textRunes[idx-1]) && !unicode.IsDigit(textRunes[idx-1]) {
+			score += fuzzyWordStartBonus
+		}
+
+		lastMatch = idx
+		matched++
+
+		if matched == len(patternRunes) {
+			return true, score
+		}
+	}
+
+	return false, 0
+}
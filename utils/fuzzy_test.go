@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		text          string
+		expectedMatch bool
+		expectedScore int
+	}{
+		{
+			name:          "with an empty pattern",
+			pattern:       "",
+			text:          "GitHub",
+			expectedMatch: true,
+			expectedScore: 0,
+		},
+		{
+			name:          "with characters out of order",
+			pattern:       "rg",
+			text:          "GitHub",
+			expectedMatch: false,
+			expectedScore: 0,
+		},
+		{
+			name:          "with a missing character",
+			pattern:       "gitz",
+			text:          "GitHub",
+			expectedMatch: false,
+			expectedScore: 0,
+		},
+		{
+			name:          "with a prefix",
+			pattern:       "git",
+			text:          "GitHub",
+			expectedMatch: true,
+			expectedScore: 4 + 6 + 6,
+		},
+		{
+			name:          "with scattered characters",
+			pattern:       "ghpr",
+			text:          "GitHub: Open pull request",
+			expectedMatch: true,
+			expectedScore: 4 + 1 + 1 + 4,
+		},
+		{
+			name:          "with different case",
+			pattern:       "HUB",
+			text:          "github",
+			expectedMatch: true,
+			expectedScore: 1 + 6 + 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, score := FuzzyMatch(tt.pattern, tt.text)
+
+			assert.Equal(t, tt.expectedMatch, match)
+			assert.Equal(t, tt.expectedScore, score)
+		})
+	}
+}
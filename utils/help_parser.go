@@ -2,7 +2,6 @@ package utils
 
 import (
 	"reflect"
-	"regexp"
 	"strconv"
 	"unicode"
 	"unicode/utf8"
@@ -39,10 +38,10 @@ func HelpFromInterface(item interface{}) string {
 	return result
 }
 
-// StripColorTags removes tcell color tags from a given string
+// StripColorTags removes tview color and region tags from a given string, and unescapes
+// any text that was escaped with tview.Escape
 func StripColorTags(input string) string {
-	openColorRegex := regexp.MustCompile(`\[.*?\]`)
-	return openColorRegex.ReplaceAllString(input, "")
+	return replaceColorTags(input, func(fg, bg, attrs string) string { return "" })
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -60,9 +59,15 @@ func helpFromValue(field reflect.StructField) string {
 		help = "Optional " + help
 	}
 
+	// The yaml tag names the config key when it differs from the field name
+	name := field.Tag.Get("yaml")
+	if name == "" || name == "-" {
+		name = lowercaseTitle(field.Name)
+	}
+
 	values := field.Tag.Get("values")
 	if help != "" {
-		result += "\n\n " + lowercaseTitle(field.Name)
+		result += "\n\n " + name
 		result += "\n " + help
 
 		if values != "" {
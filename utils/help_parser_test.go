@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StripColorTags(t *testing.T) {
+	assert.Equal(t, "cat", StripColorTags("cat"))
+	assert.Equal(t, "cat", StripColorTags("[red]cat[white]"))
+	assert.Equal(t, "cat dog", StripColorTags("[green::b]cat[::-] [#ff0000:blue]dog"))
+	assert.Equal(t, "cat", StripColorTags("[\"0\"]cat[\"\"]"))
+	assert.Equal(t, "[1, 2]", StripColorTags(tview.Escape("[1, 2]")))
+	assert.Equal(t, "[]", StripColorTags("[]"))
+}
@@ -3,14 +3,71 @@ package utils
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"strings"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 
+	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 )
 
+// These match the patterns tview uses to find tags in text
+var (
+	colorTagRegex   = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([lbdru]+|\-)?)?)?\]`)
+	escapedTagRegex = regexp.MustCompile(`\[([a-zA-Z0-9_,;: \-\."#]+)\[(\[*)\]`)
+	regionTagRegex  = regexp.MustCompile(`\["([a-zA-Z0-9_,;: \-\.]*)"\]`)
+)
+
+var ansiAttributes = map[rune]string{
+	'b': "1",
+	'd': "2",
+	'u': "4",
+	'l': "5",
+	'r': "7",
+}
+
+// ColorTagsToANSI converts the tview color tags in a string into ANSI escape sequences
+// so that the text can be written to a terminal outside of tview
+//
+// Example:
+//
+//    x := ColorTagsToANSI("[red]cat")
+//    > "\x1b[38;2;255;0;0mcat\x1b[0m"
+//
+func ColorTagsToANSI(input string) string {
+	converted := replaceColorTags(input, func(fg, bg, attrs string) string {
+		codes := []string{}
+
+		if fg != "" {
+			codes = append(codes, ansiColor(fg, 38))
+		}
+
+		if bg != "" {
+			codes = append(codes, ansiColor(bg, 48))
+		}
+
+		switch attrs {
+		case "":
+		case "-":
+			codes = append(codes, "22", "24", "25", "27")
+		default:
+			for _, attr := range attrs {
+				codes = append(codes, ansiAttributes[attr])
+			}
+		}
+
+		if len(codes) == 0 {
+			return ""
+		}
+
+		return "\x1b[" + strings.Join(codes, ";") + "m"
+	})
+
+	return converted + "\x1b[0m"
+}
+
 // CenterText takes a string and a width and pads the left and right of the string with
 // empty spaces to ensure that the string is in the middle of the returned value
 //
@@ -87,3 +144,40 @@ func PrettyNumber(number float64) string {
 
 	return p.Sprintf("%.2f", number)
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// ansiColor returns the ANSI parameters that select the named color. base is 38 for
+// the foreground and 48 for the background
+func ansiColor(name string, base int) string {
+	if name == "-" {
+		return fmt.Sprint(base + 1)
+	}
+
+	r, g, b := tcell.GetColor(name).RGB()
+	if r < 0 {
+		return fmt.Sprint(base + 1)
+	}
+
+	return fmt.Sprintf("%d;2;%d;%d;%d", base, r, g, b)
+}
+
+// replaceColorTags replaces every color tag in the input with the result of calling
+// replace with the tag's foreground, background, and attributes. Region tags are
+// removed and escaped text is unescaped, the same way tview does when drawing text
+func replaceColorTags(input string, replace func(fg, bg, attrs string) string) string {
+	output := regionTagRegex.ReplaceAllString(input, "")
+
+	output = colorTagRegex.ReplaceAllStringFunc(output, func(tag string) string {
+		// Like tview, ignore empty tags. These are the closing half of escaped text
+		if tag == "[]" {
+			return tag
+		}
+
+		parts := colorTagRegex.FindStringSubmatch(tag)
+
+		return replace(parts[1], parts[3], parts[5])
+	})
+
+	return escapedTagRegex.ReplaceAllString(output, "[$1$2]")
+}
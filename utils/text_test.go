@@ -57,3 +57,11 @@ func Test_PrettyNumber(t *testing.T) {
 	assert.Equal(t, "0", PrettyNumber(0))
 	assert.Equal(t, "0.10", PrettyNumber(0.1))
 }
+
+func Test_ColorTagsToANSI(t *testing.T) {
+	assert.Equal(t, "cat\x1b[0m", ColorTagsToANSI("cat"))
+	assert.Equal(t, "\x1b[38;2;255;0;0mcat\x1b[0m", ColorTagsToANSI("[red]cat"))
+	assert.Equal(t, "\x1b[38;2;255;0;0;48;2;0;0;255;1;4mcat\x1b[39;22;24;25;27m\x1b[0m", ColorTagsToANSI("[red:blue:bu]cat[-::-]"))
+	assert.Equal(t, "cat\x1b[0m", ColorTagsToANSI("[\"0\"]cat[\"\"]"))
+	assert.Equal(t, "[cat]\x1b[0m", ColorTagsToANSI(tview.Escape("[cat]")))
+}
@@ -136,6 +136,39 @@ func ReadFileBytes(filePath string) ([]byte, error) {
 	return fileData, nil
 }
 
+// WriteFileAtomic writes data to a file, creating its directory if need be. The data is
+// written to a temporary file that is then moved into place, so that a crash mid-write
+// never leaves a truncated file behind
+func WriteFileAtomic(filePath string, data []byte) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(dir, filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	if err := os.Rename(tmpFile.Name(), filePath); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	return nil
+}
+
 // ParseJSON is a standard JSON reader from text
 func ParseJSON(obj interface{}, text io.Reader) error {
 	d := json.NewDecoder(text)
@@ -200,6 +233,20 @@ func MaxInt(x, y int) int {
 	return y
 }
 
+// MinInt returns the smaller of x or y
+//
+// Examples:
+//
+//   MinInt(3, 2) => 2
+//   MinInt(2, 3) => 2
+//
+func MinInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
 // Clamp restricts values to a minimum and maximum value
 //
 // Examples:
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -131,3 +134,25 @@ func Test_ReadFileBytes(t *testing.T) {
 		})
 	}
 }
+
+func Test_WriteFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-utils")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	filePath := filepath.Join(dir, "nested", "data.json")
+
+	assert.NoError(t, WriteFileAtomic(filePath, []byte("first")))
+	assert.NoError(t, WriteFileAtomic(filePath, []byte("second")))
+
+	data, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	// Only the file itself is left behind, not the temporary files it was written through
+	files, err := ioutil.ReadDir(filepath.Join(dir, "nested"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -3,6 +3,7 @@ package view
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
@@ -17,10 +18,15 @@ type Base struct {
 	focusChar       string
 	focusable       bool
 	name            string
+	nextRetry       time.Time
 	quitChan        chan bool
+	refreshChan     chan bool
+	refreshErr      error
 	refreshing      bool
 	refreshInterval int
+	staleSince      time.Time
 	enabledMutex    *sync.Mutex
+	refreshMutex    *sync.Mutex
 }
 
 func NewBase(app *tview.Application, commonSettings *cfg.Common) Base {
@@ -32,10 +38,12 @@ func NewBase(app *tview.Application, commonSettings *cfg.Common) Base {
 		focusChar:       commonSettings.FocusChar(),
 		focusable:       commonSettings.Focusable,
 		name:            commonSettings.Name,
-		quitChan:        make(chan bool),
+		quitChan:        make(chan bool, 1),
+		refreshChan:     make(chan bool, 1),
 		refreshInterval: commonSettings.RefreshInterval,
 		refreshing:      false,
 		enabledMutex:    &sync.Mutex{},
+		refreshMutex:    &sync.Mutex{},
 	}
 	return base
 }
@@ -65,6 +73,18 @@ func (base *Base) ConfigText() string {
 }
 
 func (base *Base) ContextualTitle(defaultStr string) string {
+	for _, marker := range []string{base.staleTitle(), base.retryTitle()} {
+		if marker == "" {
+			continue
+		}
+
+		if defaultStr == "" {
+			defaultStr = marker
+		} else {
+			defaultStr = fmt.Sprintf("%s %s", defaultStr, marker)
+		}
+	}
+
 	switch {
 	case defaultStr == "" && base.FocusChar() == "":
 		return ""
@@ -120,20 +140,87 @@ func (base *Base) QuitChan() chan bool {
 	return base.quitChan
 }
 
+// RefreshChan returns the channel on which the scheduler receives requests to refresh the
+// widget ahead of its next scheduled refresh
+func (base *Base) RefreshChan() chan bool {
+	return base.refreshChan
+}
+
 // Refreshing returns TRUE if the base is currently refreshing its data, FALSE if it is not
 func (base *Base) Refreshing() bool {
 	return base.refreshing
 }
 
+// RefreshError returns the error reported by the most recent refresh, or nil if it succeeded
+func (base *Base) RefreshError() error {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	return base.refreshErr
+}
+
 // RefreshInterval returns how often, in seconds, the base will return its data
 func (base *Base) RefreshInterval() int {
 	return base.refreshInterval
 }
 
+// RequestRefresh asks the scheduler to refresh the widget as soon as it can, i.e.: after a
+// keyboard action changed its data. Unlike calling Refresh() directly, the refresh's result
+// is recorded and a failure is retried with backoff. Requests made while one is already
+// pending are dropped, as the pending one covers them
+func (base *Base) RequestRefresh() {
+	select {
+	case base.refreshChan <- true:
+	default:
+	}
+}
+
 func (base *Base) SetFocusChar(char string) {
 	base.focusChar = char
 }
 
+// SetNextRetry stores the time at which the scheduler will retry a failed refresh so
+// that it can be displayed in the widget's title. Pass a zero time to clear it
+func (base *Base) SetNextRetry(next time.Time) {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	base.nextRetry = next
+}
+
+// SetRefreshError records the outcome of a refresh. Modules should call this at the
+// end of Refresh() with the error that caused the refresh to fail, or with nil on success.
+// A successful refresh means the data displayed is no longer stale
+func (base *Base) SetRefreshError(err error) {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	base.refreshErr = err
+
+	if err == nil {
+		base.staleSince = time.Time{}
+	}
+}
+
+// SetStaleSince marks the data displayed as having been fetched at the given time, i.e.:
+// when it was loaded from a cache, so that its age can be displayed in the widget's title.
+// The mark is cleared by the next successful refresh
+func (base *Base) SetStaleSince(fetchedAt time.Time) {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	base.staleSince = fetchedAt
+}
+
+// StaleSince returns when the data displayed was fetched if it is stale, or a zero time if
+// it is up to date
+func (base *Base) StaleSince() time.Time {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	return base.staleSince
+}
+
 func (base *Base) Stop() {
 	base.enabledMutex.Lock()
 	base.enabled = false
@@ -144,3 +231,32 @@ func (base *Base) Stop() {
 func (base *Base) String() string {
 	return base.name
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (base *Base) retryTitle() string {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	if base.nextRetry.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf("[red](retry %s)[white]", base.nextRetry.Format("15:04:05"))
+}
+
+func (base *Base) staleTitle() string {
+	base.refreshMutex.Lock()
+	defer base.refreshMutex.Unlock()
+
+	if base.staleSince.IsZero() {
+		return ""
+	}
+
+	format := "15:04"
+	if time.Since(base.staleSince) > 24*time.Hour {
+		format = "Jan 2 15:04"
+	}
+
+	return fmt.Sprintf("[gray](stale since %s)[white]", base.staleSince.Format(format))
+}
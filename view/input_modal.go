@@ -0,0 +1,79 @@
+package view
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+const (
+	inputModalHeight = 3
+	listModalHeight  = 14
+)
+
+// NewInputModal creates and returns a modal dialog with a single line of text input,
+// centered on the screen. submitFunc is called with the text when Enter is pressed, and
+// closeFunc when Esc is pressed
+// An example of this is the comment prompt of the GitHub widget
+func NewInputModal(title string, submitFunc func(text string), closeFunc func()) *tview.Flex {
+	input := tview.NewInputField()
+	input.SetBorder(true)
+	input.SetTitle(" " + title + " ")
+	input.SetFieldBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			submitFunc(input.GetText())
+		case tcell.KeyEscape:
+			closeFunc()
+		}
+	})
+
+	return centered(input, modalWidth, inputModalHeight)
+}
+
+// NewListModal creates and returns a modal dialog that lists the items, centered on the
+// screen. selectFunc is called with the index of the item chosen with Enter, and closeFunc
+// when Esc is pressed
+// An example of this is the transition picker of the Jira widget
+func NewListModal(title string, items []string, selectFunc func(idx int), closeFunc func()) *tview.Flex {
+	list := tview.NewList()
+	list.SetBorder(true)
+	list.SetTitle(" " + title + " ")
+	list.ShowSecondaryText(false)
+	list.SetHighlightFullLine(true)
+
+	for _, item := range items {
+		list.AddItem(item, "", 0, nil)
+	}
+
+	list.SetSelectedFunc(func(idx int, _ string, _ string, _ rune) {
+		selectFunc(idx)
+	})
+	list.SetDoneFunc(closeFunc)
+
+	height := len(items) + 2
+	if height > listModalHeight {
+		height = listModalHeight
+	}
+
+	return centered(list, modalWidth/2, height)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// centered returns a layout that displays the primitive in the middle of the screen. The
+// empty items are transparent, so the widgets stay visible around it
+func centered(primitive tview.Primitive, width, height int) *tview.Flex {
+	column := tview.NewFlex().SetDirection(tview.FlexRow)
+	column.AddItem(nil, 0, 1, false)
+	column.AddItem(primitive, height, 0, true)
+	column.AddItem(nil, 0, 1, false)
+
+	row := tview.NewFlex()
+	row.AddItem(nil, 0, 1, false)
+	row.AddItem(column, width, 0, true)
+	row.AddItem(nil, 0, 1, false)
+
+	return row
+}
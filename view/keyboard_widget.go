@@ -12,6 +12,16 @@ import (
 type helpItem struct {
 	Key  string
 	Text string
+
+	action func()
+}
+
+// KeyboardAction is a key that a widget responds to, the function the key runs, and the
+// help text that describes it
+type KeyboardAction struct {
+	Key    string
+	Help   string
+	Action func()
 }
 
 // KeyboardWidget manages keyboard control for a widget
@@ -59,7 +69,7 @@ func (widget *KeyboardWidget) SetKeyboardChar(char string, fn func(), helpText s
 	}
 
 	widget.charMap[char] = fn
-	widget.charHelp = append(widget.charHelp, helpItem{char, helpText})
+	widget.charHelp = append(widget.charHelp, helpItem{Key: char, Text: helpText, action: fn})
 }
 
 // SetKeyboardKey sets a tcell.Key/function combination that responds to key presses
@@ -69,7 +79,7 @@ func (widget *KeyboardWidget) SetKeyboardChar(char string, fn func(), helpText s
 //
 func (widget *KeyboardWidget) SetKeyboardKey(key tcell.Key, fn func(), helpText string) {
 	widget.keyMap[key] = fn
-	widget.keyHelp = append(widget.keyHelp, helpItem{tcell.KeyNames[key], helpText})
+	widget.keyHelp = append(widget.keyHelp, helpItem{Key: tcell.KeyNames[key], Text: helpText, action: fn})
 
 	if len(tcell.KeyNames[key]) > widget.maxKey {
 		widget.maxKey = len(tcell.KeyNames[key])
@@ -127,6 +137,18 @@ func (widget *KeyboardWidget) HelpText() string {
 	return str
 }
 
+// KeyboardActions returns the actions set with SetKeyboardChar and SetKeyboardKey, in the
+// order they are listed in the help text
+func (widget *KeyboardWidget) KeyboardActions() []KeyboardAction {
+	actions := []KeyboardAction{}
+
+	for _, item := range append(append([]helpItem{}, widget.charHelp...), widget.keyHelp...) {
+		actions = append(actions, KeyboardAction{Key: item.Key, Help: item.Text, Action: item.action})
+	}
+
+	return actions
+}
+
 func (widget *KeyboardWidget) SetView(view *tview.TextView) {
 	widget.view = view
 }
@@ -146,3 +168,115 @@ func (widget *KeyboardWidget) ShowHelp() {
 		widget.app.Draw()
 	})
 }
+
+// ShowChoice displays a modal with the text and a button for each choice. chooseFunc is
+// called with the chosen button's label. Pressing Esc closes the modal without a choice.
+// It is safe to call from outside the UI goroutine
+func (widget *KeyboardWidget) ShowChoice(text string, choices []string, chooseFunc func(choice string)) {
+	widget.app.QueueUpdateDraw(func() {
+		modal := tview.NewModal()
+		modal.SetText(text)
+		modal.AddButtons(choices)
+		modal.SetDoneFunc(func(idx int, label string) {
+			widget.pages.RemovePage("choice")
+			widget.app.SetFocus(widget.view)
+
+			if idx >= 0 {
+				chooseFunc(label)
+			}
+		})
+
+		widget.pages.RemovePage("choice")
+		widget.pages.AddPage("choice", modal, true, true)
+		widget.app.SetFocus(modal)
+	})
+}
+
+// ShowInput displays a modal that prompts for a single line of text. submitFunc is
+// called with the text when it is submitted. Empty text is not submitted. It is safe to
+// call from outside the UI goroutine
+func (widget *KeyboardWidget) ShowInput(title string, submitFunc func(text string)) {
+	closeFunc := func() {
+		widget.pages.RemovePage("input")
+		widget.app.SetFocus(widget.view)
+	}
+
+	widget.app.QueueUpdateDraw(func() {
+		modal := NewInputModal(
+			title,
+			func(text string) {
+				closeFunc()
+
+				if strings.TrimSpace(text) != "" {
+					submitFunc(text)
+				}
+			},
+			closeFunc,
+		)
+
+		widget.pages.RemovePage("input")
+		widget.pages.AddPage("input", modal, true, true)
+		widget.app.SetFocus(modal)
+	})
+}
+
+// ShowList displays a modal that lists the items. selectFunc is called with the index of
+// the chosen item. Pressing Esc closes the modal without a choice. It is safe to call
+// from outside the UI goroutine
+func (widget *KeyboardWidget) ShowList(title string, items []string, selectFunc func(idx int)) {
+	closeFunc := func() {
+		widget.pages.RemovePage("list")
+		widget.app.SetFocus(widget.view)
+	}
+
+	widget.app.QueueUpdateDraw(func() {
+		modal := NewListModal(
+			title,
+			items,
+			func(idx int) {
+				closeFunc()
+				selectFunc(idx)
+			},
+			closeFunc,
+		)
+
+		widget.pages.RemovePage("list")
+		widget.pages.AddPage("list", modal, true, true)
+		widget.app.SetFocus(modal)
+	})
+}
+
+// ShowMessage displays the text in a modal, i.e.: to report the result of an action.
+// It is safe to call from outside the UI goroutine
+func (widget *KeyboardWidget) ShowMessage(text string) {
+	widget.app.QueueUpdateDraw(func() {
+		closeFunc := func() {
+			widget.pages.RemovePage("message")
+			widget.app.SetFocus(widget.view)
+		}
+
+		modal := NewBillboardModal(text, closeFunc)
+
+		widget.pages.RemovePage("message")
+		widget.pages.AddPage("message", modal, false, true)
+		widget.app.SetFocus(modal)
+	})
+}
+
+// ReportAction displays the outcome of an action, such as approving a pull request, in a
+// modal. If the action worked, and success isn't empty, success is displayed. Then
+// requestRefresh is called so that the widget reflects the change. Pass the widget's
+// RequestRefresh, so that the refresh goes through the scheduler. It is safe to call from
+// outside the UI goroutine
+func (widget *KeyboardWidget) ReportAction(success string, err error, requestRefresh func()) {
+	if err != nil {
+		widget.ShowMessage(fmt.Sprintf(" [red]%s[white]", tview.Escape(err.Error())))
+		return
+	}
+
+	if success != "" {
+		widget.ShowMessage(" " + tview.Escape(success))
+	}
+
+	requestRefresh()
+}
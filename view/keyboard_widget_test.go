@@ -1,6 +1,7 @@
 package view
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gdamore/tcell"
@@ -213,3 +214,37 @@ func Test_ShowHelp(t *testing.T) {
 
 	assert.NotPanics(t, func() { keyWid.ShowHelp() })
 }
+
+func Test_KeyboardActions(t *testing.T) {
+	keyWid := testKeyboardWidget()
+
+	calls := []string{}
+	keyWid.SetKeyboardKey(tcell.KeyEnter, func() { calls = append(calls, "enter") }, "Open item")
+	keyWid.SetKeyboardChar("d", func() { calls = append(calls, "d") }, "Delete item")
+
+	actions := keyWid.KeyboardActions()
+
+	assert.Equal(t, 2, len(actions))
+	assert.Equal(t, "d", actions[0].Key)
+	assert.Equal(t, "Delete item", actions[0].Help)
+	assert.Equal(t, "Enter", actions[1].Key)
+	assert.Equal(t, "Open item", actions[1].Help)
+
+	actions[1].Action()
+	actions[0].Action()
+
+	assert.Equal(t, []string{"enter", "d"}, calls)
+}
+
+func Test_ReportAction(t *testing.T) {
+	keyWid := testKeyboardWidget()
+
+	refreshes := 0
+	requestRefresh := func() { refreshes++ }
+
+	keyWid.ReportAction("", errors.New("forbidden"), requestRefresh)
+	assert.Equal(t, 0, refreshes)
+
+	keyWid.ReportAction("Approved #1", nil, requestRefresh)
+	assert.Equal(t, 1, refreshes)
+}
@@ -0,0 +1,144 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// SparklineBlock draws one value per character, with block elements
+	SparklineBlock = "block"
+
+	// SparklineBraille draws two values per character, with braille dots
+	SparklineBraille = "braille"
+)
+
+// sparkBlocks are the characters for values of 0/8 to 8/8 of the maximum
+var sparkBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// brailleDots are the dots of the left and the right column of a braille character, from
+// the bottom up
+var brailleDots = [2][4]rune{
+	{0x40, 0x04, 0x02, 0x01},
+	{0x80, 0x20, 0x10, 0x08},
+}
+
+// Sparkline defines a single row of sparklines
+type Sparkline struct {
+	Label      string
+	Values     []float64
+	Max        float64
+	ValueLabel string
+	LabelColor string
+}
+
+// BuildSparklines builds the string to display a sparkline for each row, width characters
+// wide, with the labels lined up. Each row is scaled to its Max, or to its largest value if
+// Max is 0. The most recent values are on the right
+func BuildSparklines(data []Sparkline, width int, style string) string {
+	var buffer bytes.Buffer
+
+	longestLabel := 0
+	for _, line := range data {
+		if len(line.Label) > longestLabel {
+			longestLabel = len(line.Label)
+		}
+	}
+
+	for _, line := range data {
+		labelColor := line.LabelColor
+		if labelColor == "" {
+			labelColor = "default"
+		}
+
+		buffer.WriteString(
+			fmt.Sprintf(
+				"[%s]%s[default]%s [%s]%s[default] %s\n",
+				labelColor,
+				line.Label,
+				strings.Repeat(" ", longestLabel-len(line.Label)),
+				labelColor,
+				BuildSparkline(line.Values, line.Max, width, style),
+				line.ValueLabel,
+			),
+		)
+	}
+
+	return buffer.String()
+}
+
+// BuildSparkline draws the most recent values that fit in width characters, scaled to max,
+// or to the largest value if max is 0. It's padded on the left when there are too few
+// values to fill it
+func BuildSparkline(values []float64, max float64, width int, style string) string {
+	if width <= 0 {
+		return ""
+	}
+
+	perChar := 1
+	if style == SparklineBraille {
+		perChar = 2
+	}
+
+	if len(values) > width*perChar {
+		values = values[len(values)-width*perChar:]
+	}
+
+	if max <= 0 {
+		for _, value := range values {
+			max = math.Max(max, value)
+		}
+	}
+
+	// Pads on the left with zeros so that the values are aligned to the right
+	padded := make([]float64, width*perChar-len(values), width*perChar)
+	padded = append(padded, values...)
+
+	chars := make([]rune, 0, width)
+
+	for idx := 0; idx < len(padded); idx += perChar {
+		if style == SparklineBraille {
+			chars = append(chars, brailleChar(padded[idx], padded[idx+1], max))
+		} else {
+			chars = append(chars, sparkBlocks[sparkLevel(padded[idx], max, len(sparkBlocks)-1)])
+		}
+	}
+
+	return string(chars)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// brailleChar returns the braille character with a column of dots for each value
+func brailleChar(left, right, max float64) rune {
+	char := rune(0x2800)
+
+	for col, value := range []float64{left, right} {
+		for dot := 0; dot < sparkLevel(value, max, len(brailleDots[col])); dot++ {
+			char |= brailleDots[col][dot]
+		}
+	}
+
+	return char
+}
+
+// sparkLevel scales the value to one of 0 to levels. Values above 0 are never drawn as 0,
+// so that a little activity still shows up
+func sparkLevel(value, max float64, levels int) int {
+	if max <= 0 || value <= 0 {
+		return 0
+	}
+
+	level := int(math.Round(value / max * float64(levels)))
+
+	if level < 1 {
+		return 1
+	}
+	if level > levels {
+		return levels
+	}
+
+	return level
+}
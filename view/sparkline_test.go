@@ -0,0 +1,94 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BuildSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		max      float64
+		width    int
+		style    string
+		expected string
+	}{
+		{
+			name:     "blocks",
+			values:   []float64{0, 25, 50, 100},
+			max:      100,
+			width:    4,
+			style:    SparklineBlock,
+			expected: " ▂▄█",
+		},
+		{
+			name:     "too few values",
+			values:   []float64{100},
+			max:      100,
+			width:    3,
+			style:    SparklineBlock,
+			expected: "  █",
+		},
+		{
+			name:     "too many values",
+			values:   []float64{100, 100, 0, 50},
+			max:      100,
+			width:    2,
+			style:    SparklineBlock,
+			expected: " ▄",
+		},
+		{
+			name:     "autoscaled",
+			values:   []float64{2, 4},
+			max:      0,
+			width:    2,
+			style:    SparklineBlock,
+			expected: "▄█",
+		},
+		{
+			name:     "small values",
+			values:   []float64{0.1, 200},
+			max:      100,
+			width:    2,
+			style:    SparklineBlock,
+			expected: "▁█",
+		},
+		{
+			name:     "braille",
+			values:   []float64{0, 100, 50, 25},
+			max:      100,
+			width:    2,
+			style:    SparklineBraille,
+			expected: "⢸⣄",
+		},
+		{
+			name:     "no width",
+			values:   []float64{1},
+			width:    0,
+			style:    SparklineBlock,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BuildSparkline(tt.values, tt.max, tt.width, tt.style))
+		})
+	}
+}
+
+func Test_BuildSparklines(t *testing.T) {
+	data := []Sparkline{
+		{Label: "CPU", Values: []float64{50, 100}, Max: 100, ValueLabel: "100%", LabelColor: "red"},
+		{Label: "Mem", Values: []float64{100}, Max: 100, ValueLabel: "1G/2G"},
+		{Label: "eth0 rx", Values: []float64{}, ValueLabel: "0B/s"},
+	}
+
+	expected := "[red]CPU[default]     [red]▄█[default] 100%\n" +
+		"[default]Mem[default]     [default] █[default] 1G/2G\n" +
+		"[default]eth0 rx[default] [default]  [default] 0B/s\n"
+
+	assert.Equal(t, expected, BuildSparklines(data, 2, SparklineBlock))
+}
@@ -2,6 +2,7 @@ package view
 
 import (
 	"strings"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
@@ -12,6 +13,8 @@ import (
 type TextWidget struct {
 	Base
 	View *tview.TextView
+
+	title string
 }
 
 // NewTextWidget creates and returns an instance of TextWidget
@@ -34,6 +37,7 @@ func (widget *TextWidget) TextView() *tview.TextView {
 func (widget *TextWidget) Redraw(data func() (string, string, bool)) {
 	widget.app.QueueUpdateDraw(func() {
 		title, content, wrap := data()
+		widget.title = title
 
 		widget.View.Clear()
 		widget.View.SetWrap(wrap)
@@ -44,6 +48,16 @@ func (widget *TextWidget) Redraw(data func() (string, string, bool)) {
 	})
 }
 
+// SetNextRetry stores the time of the next scheduled retry and redraws the title so
+// that the retry time shows up without waiting for the next refresh
+func (widget *TextWidget) SetNextRetry(next time.Time) {
+	widget.Base.SetNextRetry(next)
+
+	widget.app.QueueUpdateDraw(func() {
+		widget.View.SetTitle(widget.ContextualTitle(widget.title))
+	})
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *TextWidget) createView(bordered bool) *tview.TextView {
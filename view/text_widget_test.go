@@ -1,7 +1,9 @@
 package view
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
@@ -206,3 +208,26 @@ func Test_String(t *testing.T) {
 		t.Errorf("\nexpected: %s\n     got: %s", expected, actual)
 	}
 }
+
+func Test_ContextualTitle_Stale(t *testing.T) {
+	txtWid := testTextWidget()
+	fetchedAt := time.Now().Add(-time.Minute)
+
+	txtWid.SetStaleSince(fetchedAt)
+	expected := " Jira [gray](stale since " + fetchedAt.Format("15:04") + ")[white] "
+
+	if actual := txtWid.ContextualTitle("Jira"); expected != actual {
+		t.Errorf("\nexpected: %s\n     got: %s", expected, actual)
+	}
+
+	// A failed refresh leaves the cached data, and the mark, in place
+	txtWid.SetRefreshError(errors.New("unreachable"))
+	if actual := txtWid.ContextualTitle("Jira"); expected != actual {
+		t.Errorf("\nexpected: %s\n     got: %s", expected, actual)
+	}
+
+	txtWid.SetRefreshError(nil)
+	if actual := txtWid.ContextualTitle("Jira"); actual != " Jira " {
+		t.Errorf("\nexpected:  Jira \n     got: %s", actual)
+	}
+}
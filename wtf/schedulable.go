@@ -1,8 +1,18 @@
 package wtf
 
+import "time"
+
 // Schedulable is the interface that enforces scheduling capabilities on a module
 type Schedulable interface {
 	Refresh()
 	Refreshing() bool
 	RefreshInterval() int
+
+	// RefreshError returns the error, if any, reported by the most recent refresh. The
+	// scheduler uses this to back off from data sources that are failing
+	RefreshError() error
+
+	// SetNextRetry tells the module when the scheduler will next retry a failed refresh.
+	// A zero time means the module is refreshing on its normal interval
+	SetNextRetry(time.Time)
 }
@@ -19,6 +19,8 @@ type Wtfable interface {
 	HelpText() string
 	Name() string
 	QuitChan() chan bool
+	RefreshChan() chan bool
+	RequestRefresh()
 	SetFocusChar(string)
 	TextView() *tview.TextView
 